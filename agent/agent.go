@@ -398,6 +398,9 @@ type callbackContextState struct {
 func (c *callbackContextState) Get(key string) (any, error) {
 	if c.ctx.actions != nil && c.ctx.actions.StateDelta != nil {
 		if val, ok := c.ctx.actions.StateDelta[key]; ok {
+			if val == nil {
+				return nil, session.ErrStateKeyNotExist
+			}
 			return val, nil
 		}
 	}
@@ -411,6 +414,13 @@ func (c *callbackContextState) Set(key string, val any) error {
 	return c.ctx.invocationContext.Session().State().Set(key, val)
 }
 
+func (c *callbackContextState) Delete(key string) error {
+	if c.ctx.actions != nil && c.ctx.actions.StateDelta != nil {
+		c.ctx.actions.StateDelta[key] = nil
+	}
+	return c.ctx.invocationContext.Session().State().Delete(key)
+}
+
 func (c *callbackContextState) All() iter.Seq2[string, any] {
 	return c.ctx.invocationContext.Session().State().All()
 }
@@ -124,5 +124,8 @@ type CallbackContext interface {
 	ReadonlyContext
 
 	Artifacts() Artifacts
+	// State returns the state of the session, whose changes are recorded in
+	// the state delta of the current event. See session.State for the
+	// scopes of the keys.
 	State() session.State
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"iter"
 	"strings"
+	"time"
 
 	"google.golang.org/genai"
 
@@ -62,6 +63,22 @@ func New(cfg Config) (agent.Agent, error) {
 		onToolErrorCallback = append(onToolErrorCallback, llminternal.OnToolErrorCallback(c))
 	}
 
+	if err := llminternal.ValidateToolDependencies(cfg.ToolDependencies); err != nil {
+		return nil, err
+	}
+
+	var requestLayout []llminternal.RequestSection
+	for _, s := range cfg.RequestLayout {
+		requestLayout = append(requestLayout, llminternal.RequestSection(s))
+	}
+	if err := llminternal.ValidateRequestLayout(requestLayout); err != nil {
+		return nil, err
+	}
+
+	if cfg.EmptyResponsePolicy == EmptyResponseRetry && cfg.EmptyResponseMaxRetries <= 0 {
+		cfg.EmptyResponseMaxRetries = 1
+	}
+
 	a := &llmAgent{
 		model:                 cfg.Model,
 		beforeModelCallbacks:  beforeModelCallbacks,
@@ -75,14 +92,16 @@ func New(cfg Config) (agent.Agent, error) {
 		outputSchema:          cfg.OutputSchema,
 
 		State: llminternal.State{
-			Model:                    cfg.Model,
-			GenerateContentConfig:    cfg.GenerateContentConfig,
-			Tools:                    cfg.Tools,
-			Toolsets:                 cfg.Toolsets,
-			DisallowTransferToParent: cfg.DisallowTransferToParent,
-			DisallowTransferToPeers:  cfg.DisallowTransferToPeers,
-			InputSchema:              cfg.InputSchema,
-			OutputSchema:             cfg.OutputSchema,
+			Model:                     cfg.Model,
+			GenerateContentConfig:     cfg.GenerateContentConfig,
+			Tools:                     cfg.Tools,
+			Toolsets:                  cfg.Toolsets,
+			ToolDescriptions:          cfg.ToolDescriptions,
+			LocalizedToolDescriptions: cfg.LocalizedToolDescriptions,
+			DisallowTransferToParent:  cfg.DisallowTransferToParent,
+			DisallowTransferToPeers:   cfg.DisallowTransferToPeers,
+			InputSchema:               cfg.InputSchema,
+			OutputSchema:              cfg.OutputSchema,
 			// TODO: internal type for includeContents
 			IncludeContents:           string(cfg.IncludeContents),
 			Instruction:               cfg.Instruction,
@@ -90,6 +109,19 @@ func New(cfg Config) (agent.Agent, error) {
 			GlobalInstruction:         cfg.GlobalInstruction,
 			GlobalInstructionProvider: llminternal.InstructionProvider(cfg.GlobalInstructionProvider),
 			OutputKey:                 cfg.OutputKey,
+			EmptyResponsePolicy:       llminternal.EmptyResponsePolicy(cfg.EmptyResponsePolicy),
+			EmptyResponseMaxRetries:   cfg.EmptyResponseMaxRetries,
+
+			ToolDeclarationsOnFirstTurnOnly: cfg.ToolDeclarationsOnFirstTurnOnly,
+			ToolDependencies:                cfg.ToolDependencies,
+			DescribeToolOutputSchemas:       cfg.DescribeToolOutputSchemas,
+
+			ToolResultSummaryModel:       cfg.ToolResultSummaryModel,
+			MaxToolResultSize:            cfg.MaxToolResultSize,
+			ToolResultSummaryInstruction: cfg.ToolResultSummaryInstruction,
+
+			CurrentTime:   cfg.CurrentTime,
+			RequestLayout: requestLayout,
 		},
 	}
 
@@ -112,6 +144,21 @@ func New(cfg Config) (agent.Agent, error) {
 	return a, nil
 }
 
+// NewChat creates a chat agent named "chat_agent" without tools, answering
+// with the model m and following the given instructions, joined by blank
+// lines. Use New for any other configuration.
+func NewChat(m model.LLM, instructions ...string) (agent.Agent, error) {
+	if m == nil {
+		return nil, fmt.Errorf("a model is required")
+	}
+	return New(Config{
+		Name:        "chat_agent",
+		Description: "Chats with the user.",
+		Model:       m,
+		Instruction: strings.Join(instructions, "\n\n"),
+	})
+}
+
 // Config of the LLMAgent.
 type Config struct {
 	// Name must be a non-empty string, unique within the agent tree.
@@ -150,6 +197,10 @@ type Config struct {
 	//
 	// For example: use this config to adjust model temperature, configure
 	// safety settings, etc.
+	//
+	// It applies to every request of the agent. The fields set in
+	// agent.RunConfig.GenerateContentConfig override it for an invocation,
+	// and the fields set in neither are left to the model's defaults.
 	GenerateContentConfig *genai.GenerateContentConfig
 
 	// BeforeModelCallbacks will be called in the order they are provided until
@@ -168,6 +219,16 @@ type Config struct {
 	//
 	// This is the ideal place to log model responses, collect metrics on token
 	// usage, or perform post-processing on the raw `LLMResponse`.
+	//
+	// The callbacks form a chain, run after the AfterModelCallback of the
+	// runner's plugins. A callback continues the chain by returning (nil,
+	// nil), possibly after modifying the response in place: the next
+	// callbacks get the modified response. It halts the chain by returning a
+	// response or an error, which replaces the model response, and the next
+	// callbacks are skipped. E.g. a blocklist guardrail halts the chain with
+	// a refusal, so that no later callback can reintroduce the blocked
+	// content; callbacks that must see every response, e.g. for auditing,
+	// should come before it.
 	AfterModelCallbacks []AfterModelCallback
 
 	OnModelErrorCallbacks []OnModelErrorCallback
@@ -257,6 +318,27 @@ type Config struct {
 	// Toolsets will be used by llmagent to extract tools and pass to the
 	// underlying LLM.
 	Toolsets []tool.Toolset
+	// ToolDescriptions overrides the descriptions of the agent's tools, keyed
+	// by tool name. The overrides only apply to the function declarations sent
+	// in this agent's requests; the tools themselves are not modified, so the
+	// same tool can be shared by agents that describe it differently.
+	ToolDescriptions map[string]string
+	// LocalizedToolDescriptions are translations of the descriptions of the
+	// agent's tools, keyed by tool name, then by language tag, e.g.
+	// {"get_weather": {"fr": "Donne la météo d'une ville."}}. The
+	// description sent in a request is the translation for the
+	// agent.RunConfig.Locale of the invocation, or for its base language,
+	// e.g. "pt" for "pt-BR". Without a translation for the locale, the
+	// description is the one of ToolDescriptions, or of the tool.
+	LocalizedToolDescriptions map[string]map[string]string
+	// DescribeToolOutputSchemas adds to the system instruction a
+	// human-readable description of the output schemas of the agent's
+	// function tools, e.g. "get_weather returns an object with: ...". Some
+	// models use the tool results more reliably when their structure is
+	// also described in natural language. The tools returning the same
+	// structure are described once, and the tools without an output schema
+	// are left out.
+	DescribeToolOutputSchemas bool
 
 	OnToolErrorCallbacks []OnToolErrorCallback
 
@@ -266,6 +348,65 @@ type Config struct {
 	// - Extracts agent reply for later use, such as in tools, callbacks, etc.
 	// - Connects agents to coordinate with each other.
 	OutputKey string
+
+	// EmptyResponsePolicy defines how the agent handles model responses
+	// without content, e.g. an empty or blocked candidate. By default, such
+	// responses are skipped, or passed through if they carry an error code.
+	EmptyResponsePolicy EmptyResponsePolicy
+	// EmptyResponseMaxRetries is the number of times the model is called
+	// again after an empty response, with EmptyResponseRetry. Defaults to 1.
+	EmptyResponseMaxRetries int
+
+	// ToolDeclarationsOnFirstTurnOnly sends the function declarations of the
+	// agent's tools only with its first model request of an invocation, and
+	// omits them from the follow-up requests that carry the tool results.
+	// This saves tokens with backends that cache the context of previous
+	// turns. The declarations are sent again if the model calls an unknown
+	// function or produces a malformed function call.
+	ToolDeclarationsOnFirstTurnOnly bool
+
+	// ToolDependencies declares execution ordering constraints between
+	// tools, as a map from a tool name to the names of the tools it depends
+	// on. When the model calls several tools in one response, each call runs
+	// after the calls of the tools it depends on, directly or transitively,
+	// e.g. "query" after "authenticate". Other calls run in the order of the
+	// model. New fails if the dependencies contain a cycle.
+	ToolDependencies map[string][]string
+
+	// ToolResultSummaryModel, if set, summarizes the tool results whose JSON
+	// encoding is larger than MaxToolResultSize bytes, instead of sending
+	// them to the agent's model. The original result is saved as an
+	// artifact, if the runner has an artifact service, and the model gets
+	// the summary and the artifact name, e.g. to load it with
+	// loadartifactstool. Error results and multimodal results are not
+	// summarized.
+	ToolResultSummaryModel model.LLM
+	// MaxToolResultSize is the size in bytes above which tool results are
+	// summarized. Summarization is disabled unless it is positive.
+	MaxToolResultSize int
+	// ToolResultSummaryInstruction is the instruction given to the
+	// summarizer model. Defaults to a generic summarization instruction.
+	ToolResultSummaryInstruction string
+
+	// CurrentTime, if set, is called before each model request, and the
+	// returned date and time, with its time zone, is added to the system
+	// instruction. Models have no clock, this lets them answer questions
+	// about "today" correctly, also in long conversations. Typically set to
+	// time.Now, or to a function returning the time in the user's location.
+	CurrentTime func() time.Time
+
+	// RequestLayout is the order of the sections of the model requests, as
+	// different models prefer different layouts. It must place the history
+	// and the current turn. The instruction sections it places are sent as
+	// user contents at their position, the others stay in the system
+	// instruction. By default, the instructions, including the tool context,
+	// are in the system instruction, followed by the history and the current
+	// turn, e.g. a layout of
+	//
+	//	[]RequestSection{RequestSectionHistory, RequestSectionSystemInstruction, RequestSectionCurrentTurn}
+	//
+	// restates the instructions right before the user's last message.
+	RequestLayout []RequestSection
 }
 
 // BeforeModelCallback that is called before sending a request to the model.
@@ -277,7 +418,9 @@ type BeforeModelCallback func(ctx agent.CallbackContext, llmRequest *model.LLMRe
 // AfterModelCallback that is called after receiving a response from the model.
 //
 // If it returns non-nil LLMResponse or error, the actual model response/error
-// is replaced with the returned response/error.
+// is replaced with the returned response/error, and the remaining callbacks
+// are skipped. If it returns (nil, nil), the next callback is called with the
+// response, including any change the callback made to it in place.
 type AfterModelCallback func(ctx agent.CallbackContext, llmResponse *model.LLMResponse, llmResponseError error) (*model.LLMResponse, error)
 
 // OnModelErrorCallback that is called when receiving an error response from the llm model.
@@ -313,6 +456,52 @@ type AfterToolCallback func(ctx tool.Context, tool tool.Tool, args, result map[s
 // is replaced with the returned response/error.
 type OnToolErrorCallback func(ctx tool.Context, tool tool.Tool, args map[string]any, err error) (map[string]any, error)
 
+// EmptyResponsePolicy defines how llmagent handles model responses without
+// content.
+type EmptyResponsePolicy string
+
+const (
+	// EmptyResponseIgnore skips empty responses, or passes them through if
+	// they have an error code. This is the default.
+	EmptyResponseIgnore EmptyResponsePolicy = ""
+	// EmptyResponseRetry calls the model again, up to
+	// Config.EmptyResponseMaxRetries times, and then reports the empty response
+	// as with EmptyResponseEvent.
+	EmptyResponseRetry EmptyResponsePolicy = "retry"
+	// EmptyResponseEvent yields an event with ErrorCode set, to
+	// EmptyResponseErrorCode unless the model reported one, and ErrorMessage
+	// including the finish reason. The event ends the agent turn.
+	EmptyResponseEvent EmptyResponsePolicy = "event"
+	// EmptyResponseError fails the invocation with an error wrapping
+	// ErrEmptyResponse and including the finish reason.
+	EmptyResponseError EmptyResponsePolicy = "error"
+)
+
+// EmptyResponseErrorCode is the error code of the event reporting an empty
+// model response with EmptyResponseEvent.
+const EmptyResponseErrorCode = llminternal.EmptyResponseErrorCode
+
+// ErrEmptyResponse is returned when the model returns a response without
+// content and the agent is configured with EmptyResponseError.
+var ErrEmptyResponse = llminternal.ErrEmptyResponse
+
+// RequestSection is a section of the model request, placed by
+// Config.RequestLayout.
+type RequestSection string
+
+const (
+	// RequestSectionSystemInstruction is the global and agent instructions.
+	RequestSectionSystemInstruction RequestSection = RequestSection(llminternal.RequestSectionSystemInstruction)
+	// RequestSectionToolContext is the instructions added by tools, e.g. the
+	// list of available artifacts.
+	RequestSectionToolContext RequestSection = RequestSection(llminternal.RequestSectionToolContext)
+	// RequestSectionHistory is the conversation before the current turn.
+	RequestSectionHistory RequestSection = RequestSection(llminternal.RequestSectionHistory)
+	// RequestSectionCurrentTurn is the user's last message, and the model
+	// responses and tool results that followed it.
+	RequestSectionCurrentTurn RequestSection = RequestSection(llminternal.RequestSectionCurrentTurn)
+)
+
 // IncludeContents controls what parts of prior conversation history is received by llmagent.
 type IncludeContents string
 
@@ -367,6 +556,18 @@ func (a *llmAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, er
 		BeforeToolCallbacks:   a.beforeToolCallbacks,
 		AfterToolCallbacks:    a.afterToolCallbacks,
 		OnToolErrorCallbacks:  a.onToolErrorCallbacks,
+
+		EmptyResponsePolicy:     a.EmptyResponsePolicy,
+		EmptyResponseMaxRetries: a.EmptyResponseMaxRetries,
+
+		ToolDeclarationsOnFirstTurnOnly: a.ToolDeclarationsOnFirstTurnOnly,
+		ToolDependencies:                a.ToolDependencies,
+
+		ToolResultSummaryModel:       a.ToolResultSummaryModel,
+		MaxToolResultSize:            a.MaxToolResultSize,
+		ToolResultSummaryInstruction: a.ToolResultSummaryInstruction,
+
+		RequestLayout: a.RequestLayout,
 	}
 
 	return func(yield func(*session.Event, error) bool) {
@@ -15,23 +15,30 @@
 package llmagent_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"iter"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/internal/httprr"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/functiontool"
@@ -87,6 +94,34 @@ func TestLLMAgent(t *testing.T) {
 	}
 }
 
+func TestNewChat(t *testing.T) {
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("Hello!", genai.RoleModel),
+	}}
+	a, err := llmagent.NewChat(mockModel, "Be friendly.", "Answer briefly.")
+	if err != nil {
+		t.Fatalf("NewChat() failed: %v", err)
+	}
+	texts, err := testutil.CollectTextParts(testutil.NewTestAgentRunner(t, a).Run(t, "session", "hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"Hello!"}, texts); diff != "" {
+		t.Errorf("texts mismatch (-want +got):\n%s", diff)
+	}
+	req := mockModel.Requests[0]
+	if got := req.Config.SystemInstruction.Parts[0].Text; !strings.Contains(got, "Be friendly.\n\nAnswer briefly.") {
+		t.Errorf("got system instruction %q, want the joined instructions", got)
+	}
+	if len(req.Config.Tools) != 0 {
+		t.Errorf("got tools %v, want none", req.Config.Tools)
+	}
+
+	if _, err := llmagent.NewChat(nil); err == nil {
+		t.Error("NewChat(nil) succeeded, want an error")
+	}
+}
+
 func TestLLMAgentStreamingModeSSE(t *testing.T) {
 	model := newGeminiModel(t, "gemini-2.5-flash", nil)
 	a, err := llmagent.New(llmagent.Config{
@@ -828,6 +863,539 @@ func TestInstructionProvider(t *testing.T) {
 	}
 }
 
+func TestToolDescriptions(t *testing.T) {
+	t.Parallel()
+
+	type Args struct {
+		Query string `json:"query"`
+	}
+	search, err := functiontool.New(functiontool.Config{
+		Name:        "search",
+		Description: "searches the web",
+	}, func(_ tool.Context, args Args) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name             string
+		toolDescriptions map[string]string
+		wantDescription  string
+	}{
+		{
+			name:            "no override",
+			wantDescription: "searches the web",
+		},
+		{
+			name:             "override",
+			toolDescriptions: map[string]string{"search": "searches recent news articles"},
+			wantDescription:  "searches recent news articles",
+		},
+		{
+			name:             "override for unknown tool is ignored",
+			toolDescriptions: map[string]string{"other": "does something else"},
+			wantDescription:  "searches the web",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &testutil.MockModel{
+				Responses: []*genai.Content{
+					genai.NewContentFromText("llm resp stub", genai.RoleModel),
+				},
+			}
+			a, err := llmagent.New(llmagent.Config{
+				Name:             "test_agent",
+				Model:            model,
+				Tools:            []tool.Tool{search},
+				ToolDescriptions: tc.toolDescriptions,
+			})
+			if err != nil {
+				t.Fatalf("failed to create LLM Agent: %v", err)
+			}
+
+			testRunner := testutil.NewTestAgentRunner(t, a)
+			if _, err := testutil.CollectTextParts(testRunner.Run(t, "session", "user input")); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(model.Requests) != 1 {
+				t.Fatalf("got %d LLM requests, want 1", len(model.Requests))
+			}
+			var got []string
+			for _, gt := range model.Requests[0].Config.Tools {
+				for _, decl := range gt.FunctionDeclarations {
+					got = append(got, decl.Description)
+				}
+			}
+			if diff := cmp.Diff([]string{tc.wantDescription}, got); diff != "" {
+				t.Errorf("unexpected tool descriptions (-want +got):\n%s", diff)
+			}
+			if got := search.Description(); got != "searches the web" {
+				t.Errorf("shared tool description = %q, want it unchanged", got)
+			}
+		})
+	}
+}
+
+func TestLocalizedToolDescriptions(t *testing.T) {
+	t.Parallel()
+
+	type Args struct {
+		Query string `json:"query"`
+	}
+	search, err := functiontool.New(functiontool.Config{
+		Name:        "search",
+		Description: "searches the web",
+	}, func(_ tool.Context, args Args) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name            string
+		locale          string
+		wantDescription string
+	}{
+		{name: "no locale", wantDescription: "searches recent news articles"},
+		{name: "exact locale", locale: "pt-BR", wantDescription: "pesquisa na web"},
+		{name: "base language", locale: "fr_CA", wantDescription: "cherche sur le web"},
+		{name: "missing translation", locale: "de", wantDescription: "searches recent news articles"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			llm := &testutil.MockModel{
+				Responses: []*genai.Content{
+					genai.NewContentFromText("llm resp stub", genai.RoleModel),
+				},
+			}
+			a, err := llmagent.New(llmagent.Config{
+				Name:             "test_agent",
+				Model:            llm,
+				Tools:            []tool.Tool{search},
+				ToolDescriptions: map[string]string{"search": "searches recent news articles"},
+				LocalizedToolDescriptions: map[string]map[string]string{
+					"search": {"pt-BR": "pesquisa na web", "pt": "pesquisa", "fr": "cherche sur le web"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to create LLM Agent: %v", err)
+			}
+
+			testRunner := testutil.NewTestAgentRunner(t, a)
+			stream := testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{Locale: tc.locale})
+			if _, err := testutil.CollectTextParts(stream); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(llm.Requests) != 1 {
+				t.Fatalf("got %d LLM requests, want 1", len(llm.Requests))
+			}
+			if got := llm.Requests[0].Config.Tools[0].FunctionDeclarations[0].Description; got != tc.wantDescription {
+				t.Errorf("got tool description %q, want %q", got, tc.wantDescription)
+			}
+		})
+	}
+}
+
+func TestStreamThoughts(t *testing.T) {
+	t.Parallel()
+
+	budget := int32(1024)
+	thinkingConfig := &genai.ThinkingConfig{ThinkingBudget: &budget}
+	llm := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromParts([]*genai.Part{{Text: "The user greets.", Thought: true}, {Text: "Hello"}}, genai.RoleModel),
+			genai.NewContentFromText(" there", genai.RoleModel),
+		},
+		StreamResponsesCount: 2,
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                  "test_agent",
+		Model:                 llm,
+		GenerateContentConfig: &genai.GenerateContentConfig{ThinkingConfig: thinkingConfig},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	events, err := testutil.CollectEvents(testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{
+		StreamingMode:  agent.StreamingModeSSE,
+		StreamThoughts: true,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := llm.Requests[0].Config.ThinkingConfig
+	if !got.IncludeThoughts || got.ThinkingBudget == nil || *got.ThinkingBudget != budget {
+		t.Errorf("got thinking config %+v, want the thoughts included with the budget of the agent", got)
+	}
+	if thinkingConfig.IncludeThoughts {
+		t.Error("the thinking config of the agent was modified")
+	}
+
+	var categories []session.EventCategory
+	for _, ev := range events {
+		categories = append(categories, ev.Category())
+	}
+	want := []session.EventCategory{
+		session.EventCategoryThinking,
+		session.EventCategoryContent,
+		session.EventCategoryContent,
+		session.EventCategoryContent,
+	}
+	if diff := cmp.Diff(want, categories); diff != "" {
+		t.Errorf("event categories mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreamCallRationale(t *testing.T) {
+	t.Parallel()
+
+	weather, err := functiontool.New(functiontool.Config{
+		Name:        "weather",
+		Description: "returns the weather",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromText("I need the weather ", genai.RoleModel),
+			genai.NewContentFromText("in Paris to answer.", genai.RoleModel),
+			genai.NewContentFromFunctionCall("weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+			genai.NewContentFromText("It is sunny.", genai.RoleModel),
+		},
+		StreamResponsesCount: 3,
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: llm,
+		Tools: []tool.Tool{weather},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	events, err := testutil.CollectEvents(testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("weather in Paris?", genai.RoleUser), agent.RunConfig{
+		StreamingMode: agent.StreamingModeSSE,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rationales []string
+	for _, ev := range events {
+		if ev.Partial || ev.Content == nil {
+			continue
+		}
+		for _, p := range ev.Content.Parts {
+			if p.FunctionCall != nil {
+				rationales = append(rationales, ev.CallRationale(p.FunctionCall.ID))
+			}
+		}
+	}
+	if diff := cmp.Diff([]string{"I need the weather in Paris to answer."}, rationales); diff != "" {
+		t.Errorf("call rationales mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMaxLLMCalls(t *testing.T) {
+	t.Parallel()
+
+	echo, err := functiontool.New(functiontool.Config{
+		Name:        "echo",
+		Description: "echoes the input",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The model calls the tool 4 times before giving the final answer.
+	var responses []*genai.Content
+	for range 4 {
+		responses = append(responses, genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel))
+	}
+	responses = append(responses, genai.NewContentFromText("done", genai.RoleModel))
+
+	for _, tc := range []struct {
+		name         string
+		maxLLMCalls  int
+		wantRequests int
+		wantErr      error
+	}{
+		{
+			name:         "limit exceeded",
+			maxLLMCalls:  2,
+			wantRequests: 2,
+			wantErr:      agent.ErrMaxLLMCallsExceeded,
+		},
+		{
+			name:         "limit not reached",
+			maxLLMCalls:  5,
+			wantRequests: 5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &testutil.MockModel{Responses: slices.Clone(responses)}
+			a, err := llmagent.New(llmagent.Config{
+				Name:  "test_agent",
+				Model: model,
+				Tools: []tool.Tool{echo},
+			})
+			if err != nil {
+				t.Fatalf("failed to create LLM Agent: %v", err)
+			}
+
+			testRunner := testutil.NewTestAgentRunner(t, a)
+			stream := testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{
+				MaxLLMCalls: tc.maxLLMCalls,
+			})
+			var gotErr error
+			for _, err := range stream {
+				if err != nil {
+					gotErr = err
+					break
+				}
+			}
+
+			if !errors.Is(gotErr, tc.wantErr) {
+				t.Errorf("got error %v, want %v", gotErr, tc.wantErr)
+			}
+			if got := len(model.Requests); got != tc.wantRequests {
+				t.Errorf("got %d LLM requests, want %d", got, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestInvocationUsage(t *testing.T) {
+	t.Parallel()
+
+	echo, err := functiontool.New(functiontool.Config{
+		Name:        "echo",
+		Description: "echoes the input",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usageModel := &usageReportingModel{
+		responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+		},
+	}
+	// 10 input tokens at $40 and 5 output tokens at $120 per million tokens
+	// cost $0.001 per call.
+	prices := model.PricingTable{"usage": {Input: 40, Output: 120}}
+	var (
+		gotTotals []int32
+		gotCosts  []float64
+	)
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: usageModel,
+		Tools: []tool.Tool{echo},
+		AfterModelCallbacks: []llmagent.AfterModelCallback{
+			func(ctx agent.CallbackContext, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+				gotTotals = append(gotTotals, agent.InvocationUsage(ctx).TotalTokenCount)
+				gotCosts = append(gotCosts, agent.InvocationCost(ctx, prices))
+				return nil, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "user input")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tool call and the follow-up call are summed.
+	if diff := cmp.Diff([]int32{15, 30}, gotTotals); diff != "" {
+		t.Errorf("unexpected running totals (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]float64{0.001, 0.002}, gotCosts); diff != "" {
+		t.Errorf("unexpected running costs (-want +got):\n%s", diff)
+	}
+	if got := agent.InvocationUsage(t.Context()); got != nil {
+		t.Errorf("InvocationUsage() outside of an invocation = %v, want nil", got)
+	}
+}
+
+// usageReportingModel returns the responses in order, each reporting the same
+// usage.
+type usageReportingModel struct {
+	responses []*genai.Content
+}
+
+func (m *usageReportingModel) Name() string { return "usage" }
+
+func (m *usageReportingModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if len(m.responses) == 0 {
+			yield(nil, errors.New("no more responses"))
+			return
+		}
+		content := m.responses[0]
+		m.responses = m.responses[1:]
+		yield(&model.LLMResponse{
+			Content: content,
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{
+				PromptTokenCount:     10,
+				CandidatesTokenCount: 5,
+				TotalTokenCount:      15,
+			},
+		}, nil)
+	}
+}
+
+func TestEmptyResponsePolicy(t *testing.T) {
+	t.Parallel()
+
+	empty := &model.LLMResponse{FinishReason: genai.FinishReasonStop}
+	blocked := &model.LLMResponse{ErrorCode: "SAFETY", FinishReason: genai.FinishReasonSafety}
+	text := &model.LLMResponse{Content: genai.NewContentFromText("hello", genai.RoleModel)}
+
+	for _, tc := range []struct {
+		name          string
+		policy        llmagent.EmptyResponsePolicy
+		responses     []*model.LLMResponse
+		wantRequests  int
+		wantText      string
+		wantErrorCode string
+		wantMessage   string
+		wantErr       error
+	}{
+		{
+			name:         "ignore skips the empty response",
+			responses:    []*model.LLMResponse{empty},
+			wantRequests: 1,
+		},
+		{
+			name:         "retry",
+			policy:       llmagent.EmptyResponseRetry,
+			responses:    []*model.LLMResponse{empty, text},
+			wantRequests: 2,
+			wantText:     "hello",
+		},
+		{
+			name:          "retries exhausted",
+			policy:        llmagent.EmptyResponseRetry,
+			responses:     []*model.LLMResponse{empty, empty},
+			wantRequests:  2,
+			wantErrorCode: llmagent.EmptyResponseErrorCode,
+			wantMessage:   "finish reason: STOP",
+		},
+		{
+			name:          "event keeps the model error code",
+			policy:        llmagent.EmptyResponseEvent,
+			responses:     []*model.LLMResponse{blocked},
+			wantRequests:  1,
+			wantErrorCode: "SAFETY",
+			wantMessage:   "finish reason: SAFETY",
+		},
+		{
+			name:         "error",
+			policy:       llmagent.EmptyResponseError,
+			responses:    []*model.LLMResponse{empty},
+			wantRequests: 1,
+			wantErr:      llmagent.ErrEmptyResponse,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &responsesModel{responses: tc.responses}
+			a, err := llmagent.New(llmagent.Config{
+				Name:                "test_agent",
+				Model:               m,
+				EmptyResponsePolicy: tc.policy,
+			})
+			if err != nil {
+				t.Fatalf("failed to create LLM Agent: %v", err)
+			}
+
+			testRunner := testutil.NewTestAgentRunner(t, a)
+			// Events without content are expected, do not use
+			// testutil.CollectEvents which rejects them.
+			var (
+				events []*session.Event
+				runErr error
+			)
+			for ev, err := range testRunner.Run(t, "session", "user input") {
+				if err != nil {
+					runErr = err
+					break
+				}
+				events = append(events, ev)
+			}
+			if !errors.Is(runErr, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", runErr, tc.wantErr)
+			}
+			if m.requests != tc.wantRequests {
+				t.Errorf("got %d LLM requests, want %d", m.requests, tc.wantRequests)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			var gotText, gotErrorCode, gotMessage string
+			for _, ev := range events {
+				if ev.Content != nil {
+					for _, p := range ev.Content.Parts {
+						gotText += p.Text
+					}
+				}
+				if ev.ErrorCode != "" {
+					gotErrorCode, gotMessage = ev.ErrorCode, ev.ErrorMessage
+				}
+			}
+			if gotText != tc.wantText {
+				t.Errorf("got text %q, want %q", gotText, tc.wantText)
+			}
+			if gotErrorCode != tc.wantErrorCode {
+				t.Errorf("got error code %q, want %q", gotErrorCode, tc.wantErrorCode)
+			}
+			if !strings.Contains(gotMessage, tc.wantMessage) {
+				t.Errorf("got error message %q, want it to contain %q", gotMessage, tc.wantMessage)
+			}
+		})
+	}
+}
+
+// responsesModel returns the responses in order, one per call.
+type responsesModel struct {
+	responses []*model.LLMResponse
+	requests  int
+}
+
+func (m *responsesModel) Name() string { return "responses" }
+
+func (m *responsesModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.requests++
+		if len(m.responses) == 0 {
+			yield(nil, errors.New("no more responses"))
+			return
+		}
+		resp := m.responses[0]
+		m.responses = m.responses[1:]
+		yield(resp, nil)
+	}
+}
+
 func TestFunctionTool(t *testing.T) {
 	model := newGeminiModel(t, modelName, nil)
 
@@ -1111,3 +1679,978 @@ type roundTripperFunc func(*http.Request) (*http.Response, error)
 func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return fn(req)
 }
+
+func TestToolDeclarationsOnFirstTurnOnly(t *testing.T) {
+	t.Parallel()
+
+	echo, err := functiontool.New(functiontool.Config{
+		Name:        "echo",
+		Description: "echoes the input",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel),
+		// The model lost track of the tools, so the next request includes
+		// the declarations again.
+		genai.NewContentFromFunctionCall("ech", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                            "test_agent",
+		Model:                           model,
+		Tools:                           []tool.Tool{echo},
+		ToolDeclarationsOnFirstTurnOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	stream := testRunner.Run(t, "session", "user input")
+	if _, err := testutil.CollectEvents(stream); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	var got []bool
+	for _, req := range model.Requests {
+		hasDeclarations := false
+		if req.Config != nil {
+			for _, tool := range req.Config.Tools {
+				if len(tool.FunctionDeclarations) > 0 {
+					hasDeclarations = true
+				}
+			}
+		}
+		got = append(got, hasDeclarations)
+	}
+	if diff := cmp.Diff([]bool{true, false, true, false}, got); diff != "" {
+		t.Errorf("requests with tool declarations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatusEvents(t *testing.T) {
+	t.Parallel()
+
+	echo, err := functiontool.New(functiontool.Config{
+		Name:        "echo",
+		Description: "echoes the input",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+		Tools: []tool.Tool{echo},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	stream := testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("user input", genai.RoleUser), agent.RunConfig{
+		StatusEvents: true,
+	})
+	var got []string
+	for ev, err := range stream {
+		if err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+		switch {
+		case ev.Kind == session.EventKindStatus:
+			if ev.Author != "test_agent" || ev.Content != nil {
+				t.Errorf("got status event %+v, want one authored by the agent without content", ev)
+			}
+			got = append(got, "status:"+string(ev.Status))
+		case ev.Content != nil && ev.Content.Parts[0].FunctionCall != nil:
+			got = append(got, "function_call")
+		case ev.Content != nil && ev.Content.Parts[0].FunctionResponse != nil:
+			got = append(got, "function_response")
+		default:
+			got = append(got, "text")
+		}
+	}
+
+	want := []string{
+		"status:planning",
+		"function_call",
+		"status:calling_tools",
+		"function_response",
+		"status:planning",
+		"status:finalizing",
+		"text",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToolDependencies(t *testing.T) {
+	t.Parallel()
+
+	var executed []string
+	newTool := func(name string) tool.Tool {
+		tl, err := functiontool.New(functiontool.Config{
+			Name:        name,
+			Description: name,
+		}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+			executed = append(executed, name)
+			return map[string]any{}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tl
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		{
+			Role: genai.RoleModel,
+			Parts: []*genai.Part{
+				genai.NewPartFromFunctionCall("query", map[string]any{}),
+				genai.NewPartFromFunctionCall("authenticate", map[string]any{}),
+			},
+		},
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:             "test_agent",
+		Model:            model,
+		Tools:            []tool.Tool{newTool("query"), newTool("authenticate")},
+		ToolDependencies: map[string][]string{"query": {"authenticate"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "user input")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"authenticate", "query"}, executed); diff != "" {
+		t.Errorf("tool execution order mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = llmagent.New(llmagent.Config{
+		Name:             "cyclic_agent",
+		Model:            model,
+		ToolDependencies: map[string][]string{"query": {"authenticate"}, "authenticate": {"query"}},
+	})
+	if err == nil {
+		t.Error("llmagent.New() with cyclic tool dependencies succeeded, want error")
+	}
+}
+
+func TestToolErrorFormatter(t *testing.T) {
+	t.Parallel()
+
+	search, err := functiontool.New(functiontool.Config{
+		Name:        "search",
+		Description: "searches the web",
+		ErrorFormatter: func(err error) string {
+			return "The search service is temporarily unavailable, ask the user to try again later."
+		},
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return nil, errors.New("dial tcp 10.0.0.7:443: connection refused")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("search", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("sorry", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+		Tools: []tool.Tool{search},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	events, err := testutil.CollectEvents(testRunner.Run(t, "session", "user input"))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	var got any
+	for _, ev := range events {
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil {
+				got = p.FunctionResponse.Response["error"]
+			}
+		}
+	}
+	if want := "The search service is temporarily unavailable, ask the user to try again later."; got != want {
+		t.Errorf("function response error = %v, want %q", got, want)
+	}
+}
+
+func TestToolMultimodalResult(t *testing.T) {
+	t.Parallel()
+
+	image := []byte{0x89, 'P', 'N', 'G'}
+	chart, err := functiontool.New(functiontool.Config{
+		Name:        "chart",
+		Description: "renders a chart",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		result := tool.NewResult(
+			tool.TextPart("Sales grew by 12%."),
+			tool.BlobPart(image, "image/png"),
+		)
+		result["points"] = 4
+		return result, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("chart", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+		Tools: []tool.Tool{chart},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	events, err := testutil.CollectEvents(testRunner.Run(t, "session", "user input"))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	var got *genai.FunctionResponse
+	for _, ev := range events {
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil {
+				got = p.FunctionResponse
+			}
+		}
+	}
+	if got == nil {
+		t.Fatal("no function response event")
+	}
+	want := &genai.FunctionResponse{
+		Name:     "chart",
+		Response: map[string]any{"output": "Sales grew by 12%.", "points": float64(4)},
+		Parts: []*genai.FunctionResponsePart{
+			{InlineData: &genai.FunctionResponseBlob{MIMEType: "image/png", Data: image}},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(genai.FunctionResponse{}, "ID")); diff != "" {
+		t.Errorf("function response mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToolResultRef(t *testing.T) {
+	t.Parallel()
+
+	fetch, err := functiontool.New(functiontool.Config{
+		Name:        "fetch",
+		Description: "fetches the document",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"document": strings.Repeat("lorem ipsum ", 100)}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	review, err := functiontool.New(functiontool.Config{
+		Name:        "review",
+		Description: "reviews a fetched document",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return tool.NewResult(
+			tool.TextPart("No issues found in:"),
+			tool.FunctionResponseRefPart(args["call_id"].(string)),
+		), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(id, name string, args map[string]any) *genai.Content {
+		return &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: id, Name: name, Args: args}},
+		}}
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		call("call-1", "fetch", map[string]any{}),
+		call("call-2", "review", map[string]any{"call_id": "call-1"}),
+		call("call-3", "review", map[string]any{"call_id": "unknown"}),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+		Tools: []tool.Tool{fetch, review},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	events, err := testutil.CollectEvents(testRunner.Run(t, "session", "review the document"))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	got := map[string]map[string]any{}
+	for _, ev := range events {
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil {
+				got[p.FunctionResponse.ID] = p.FunctionResponse.Response
+			}
+		}
+	}
+	want := map[string]any{"output": "No issues found in:\n[See the response of the earlier fetch call with ID \"call-1\".]"}
+	if diff := cmp.Diff(want, got["call-2"]); diff != "" {
+		t.Errorf("response with reference mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := got["call-3"]["error"]; !ok {
+		t.Errorf("response with unknown reference = %v, want an error", got["call-3"])
+	}
+}
+
+func TestToolResultSummarization(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	rows := strings.Repeat("row,", 100)
+	export, err := functiontool.New(functiontool.Config{
+		Name:        "export",
+		Description: "exports the table",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"rows": rows}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	summarizer := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("100 rows", genai.RoleModel),
+	}}
+	mainModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("export", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                   "test_agent",
+		Model:                  mainModel,
+		Tools:                  []tool.Tool{export},
+		ToolResultSummaryModel: summarizer,
+		MaxToolResultSize:      100,
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	sessionService := session.InMemoryService()
+	artifactService := artifact.InMemoryService()
+	r, err := runner.New(runner.Config{
+		AppName:         "test_app",
+		Agent:           a,
+		SessionService:  sessionService,
+		ArtifactService: artifactService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "test_app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	for ev, err := range r.Run(ctx, "user", created.Session.ID(), genai.NewContentFromText("export the table", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil {
+				got = p.FunctionResponse.Response
+			}
+		}
+	}
+
+	if got["summary"] != "100 rows" {
+		t.Errorf("function response = %v, want the summary", got)
+	}
+	name, _ := got["artifact"].(string)
+	loaded, err := artifactService.Load(ctx, &artifact.LoadRequest{
+		AppName:   "test_app",
+		UserID:    "user",
+		SessionID: created.Session.ID(),
+		FileName:  name,
+	})
+	if err != nil {
+		t.Fatalf("failed to load the original result artifact %q: %v", name, err)
+	}
+	if want := `{"rows":"` + rows + `"}`; string(loaded.Part.InlineData.Data) != want {
+		t.Errorf("artifact = %s, want %s", loaded.Part.InlineData.Data, want)
+	}
+	if len(summarizer.Requests) != 1 || !strings.Contains(summarizer.Requests[0].Contents[0].Parts[0].Text, rows) {
+		t.Errorf("summarizer requests = %v, want one with the original result", summarizer.Requests)
+	}
+}
+
+func TestStreamedFunctionCallArgs(t *testing.T) {
+	t.Parallel()
+
+	type Args struct {
+		Query string `json:"query"`
+	}
+	var partial []string
+	var got string
+	search, err := functiontool.New(functiontool.Config{
+		Name:        "search",
+		Description: "searches the web",
+		OnPartialArgs: func(_ context.Context, _ string, args map[string]any) {
+			q, _ := args["query"].(string)
+			partial = append(partial, q)
+		},
+	}, func(_ tool.Context, args Args) (map[string]any, error) {
+		got = args.Query
+		return map[string]any{"results": 3}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	continues := genai.Ptr(true)
+	model := &testutil.MockModel{
+		Responses: []*genai.Content{
+			{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "search", WillContinue: continues}}}},
+			{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+				PartialArgs:  []*genai.PartialArg{{JsonPath: "$.query", StringValue: "go ", WillContinue: continues}},
+				WillContinue: continues,
+			}}}},
+			{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+				PartialArgs: []*genai.PartialArg{{JsonPath: "$.query", StringValue: "iterators"}},
+			}}}},
+			genai.NewContentFromText("found 3 results", genai.RoleModel),
+		},
+		StreamResponsesCount: 3,
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+		Tools: []tool.Tool{search},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	stream := testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("search", genai.RoleUser), agent.RunConfig{StreamingMode: agent.StreamingModeSSE})
+	var responses int
+	for ev, err := range stream {
+		if err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil {
+				responses++
+			}
+		}
+	}
+
+	if want := []string{"", "go ", "go iterators"}; !slices.Equal(partial, want) {
+		t.Errorf("partial queries = %q, want %q", partial, want)
+	}
+	if got != "go iterators" {
+		t.Errorf("tool got query %q, want %q", got, "go iterators")
+	}
+	if responses != 1 {
+		t.Errorf("got %d function responses, want the tool to run once", responses)
+	}
+}
+
+func TestCurrentTime(t *testing.T) {
+	t.Parallel()
+
+	echo, err := functiontool.New(functiontool.Config{
+		Name:        "echo",
+		Description: "echoes",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paris := time.FixedZone("CEST", 2*60*60)
+	now := time.Date(2026, 10, 15, 23, 59, 0, 0, paris)
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("echo", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:        "test_agent",
+		Model:       model,
+		Instruction: "Be brief.",
+		Tools:       []tool.Tool{echo},
+		CurrentTime: func() time.Time {
+			t := now
+			now = now.Add(2 * time.Minute)
+			return t
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "what day is it?")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	want := []string{
+		"Be brief.\n\nThe current date and time is Thursday, October 15, 2026 23:59 CEST (UTC+02:00).",
+		"Be brief.\n\nThe current date and time is Friday, October 16, 2026 00:01 CEST (UTC+02:00).",
+	}
+	var got []string
+	for _, req := range model.Requests {
+		var texts []string
+		for _, p := range req.Config.SystemInstruction.Parts {
+			texts = append(texts, p.Text)
+		}
+		got = append(got, strings.Join(texts, "\n\n"))
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("system instructions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRequestLayout(t *testing.T) {
+	t.Parallel()
+
+	if _, err := llmagent.New(llmagent.Config{
+		Name:          "invalid",
+		RequestLayout: []llmagent.RequestSection{llmagent.RequestSectionSystemInstruction},
+	}); err == nil {
+		t.Error("llmagent.New() succeeded with a layout without the history and current turn")
+	}
+
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("hi", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:        "test_agent",
+		Model:       model,
+		Instruction: "Be brief.",
+		RequestLayout: []llmagent.RequestSection{
+			llmagent.RequestSectionHistory,
+			llmagent.RequestSectionSystemInstruction,
+			llmagent.RequestSectionCurrentTurn,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "hello")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	req := model.Requests[0]
+	if req.Config.SystemInstruction != nil {
+		t.Errorf("system instruction = %+v, want it moved to the contents", req.Config.SystemInstruction)
+	}
+	want := []*genai.Content{
+		genai.NewContentFromText("Be brief.", genai.RoleUser),
+		genai.NewContentFromText("hello", genai.RoleUser),
+	}
+	if diff := cmp.Diff(want, req.Contents); diff != "" {
+		t.Errorf("contents mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEphemeralContents(t *testing.T) {
+	t.Parallel()
+
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("sunny", genai.RoleModel),
+		genai.NewContentFromText("you're welcome", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "test_agent",
+		Model: model,
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	weather := genai.NewContentFromText("Current weather: sunny, 25C.", genai.RoleUser)
+	cfg := agent.RunConfig{EphemeralContents: []*genai.Content{weather}}
+	if _, err := testutil.CollectEvents(testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("how is the weather?", genai.RoleUser), cfg)); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "thanks")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	wantRequests := [][]*genai.Content{
+		{
+			weather,
+			genai.NewContentFromText("how is the weather?", genai.RoleUser),
+		},
+		{
+			genai.NewContentFromText("how is the weather?", genai.RoleUser),
+			genai.NewContentFromText("sunny", genai.RoleModel),
+			genai.NewContentFromText("thanks", genai.RoleUser),
+		},
+	}
+	if len(model.Requests) != len(wantRequests) {
+		t.Fatalf("got %d requests, want %d", len(model.Requests), len(wantRequests))
+	}
+	for i, want := range wantRequests {
+		if diff := cmp.Diff(want, model.Requests[i].Contents); diff != "" {
+			t.Errorf("request %d contents mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestOutputSchemaWithTools(t *testing.T) {
+	t.Parallel()
+
+	type weatherReport struct {
+		City        string  `json:"city"`
+		Temperature float64 `json:"temperature"`
+	}
+	schema := &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"city":        {Type: genai.TypeString},
+			"temperature": {Type: genai.TypeNumber},
+		},
+		Required: []string{"city", "temperature"},
+	}
+	getWeather, err := functiontool.New(functiontool.Config{
+		Name:        "get_weather",
+		Description: "returns the weather of a city",
+	}, func(_ tool.Context, args struct {
+		City string `json:"city"`
+	},
+	) (map[string]any, error) {
+		return map[string]any{"temperature": 25}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+		// The first answer does not match the schema, the model fixes it.
+		genai.NewContentFromFunctionCall("set_model_response", map[string]any{"city": "Paris", "temperature": "warm"}, genai.RoleModel),
+		genai.NewContentFromFunctionCall("set_model_response", map[string]any{"city": "Paris", "temperature": 25.0}, genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:         "weather_agent",
+		Model:        model,
+		Tools:        []tool.Tool{getWeather},
+		OutputSchema: schema,
+		OutputKey:    "report",
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	events, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "weather in Paris?"))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	// The model cannot combine a response schema with function calling, so
+	// no turn forces the schema, the final answer goes through
+	// set_model_response instead.
+	if len(model.Requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(model.Requests))
+	}
+	for i, req := range model.Requests {
+		if req.Config.ResponseSchema != nil {
+			t.Errorf("request %d: ResponseSchema = %v, want nil", i, req.Config.ResponseSchema)
+		}
+		if _, ok := req.Tools["set_model_response"]; !ok {
+			t.Errorf("request %d: set_model_response tool missing", i)
+		}
+	}
+
+	final := events[len(events)-1]
+	if !final.IsFinalResponse() {
+		t.Fatalf("last event is not a final response: %+v", final)
+	}
+	dec := json.NewDecoder(strings.NewReader(final.Content.Parts[0].Text))
+	dec.DisallowUnknownFields()
+	var got weatherReport
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("final output %s does not match the schema: %v", final.Content.Parts[0].Text, err)
+	}
+	if want := (weatherReport{City: "Paris", Temperature: 25}); got != want {
+		t.Errorf("final output = %+v, want %+v", got, want)
+	}
+	if final.Actions.StateDelta["report"] != final.Content.Parts[0].Text {
+		t.Errorf("state[report] = %v, want the final output", final.Actions.StateDelta["report"])
+	}
+}
+
+func TestDescribeToolOutputSchemas(t *testing.T) {
+	t.Parallel()
+
+	type weather struct {
+		Temperature float64 `json:"temperature" jsonschema:"the temperature in Celsius"`
+		Conditions  string  `json:"conditions,omitempty"`
+	}
+	type cityArgs struct {
+		City string `json:"city"`
+	}
+	newWeatherTool := func(name string) tool.Tool {
+		t.Helper()
+		tl, err := functiontool.New(functiontool.Config{Name: name, Description: name}, func(tool.Context, cityArgs) (weather, error) {
+			return weather{}, nil
+		})
+		if err != nil {
+			t.Fatalf("failed to create tool: %v", err)
+		}
+		return tl
+	}
+	echo, err := functiontool.New(functiontool.Config{Name: "echo", Description: "echo"}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return args, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+	tools := []tool.Tool{newWeatherTool("get_weather"), newWeatherTool("get_forecast"), echo}
+
+	const want = "The tools return results with the following structure:\n" +
+		"- get_weather, get_forecast return an object with:\n" +
+		"  - conditions: a string\n" +
+		"  - temperature (required): a number, the temperature in Celsius"
+
+	for _, tc := range []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "enabled", enabled: true},
+		{name: "disabled"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &testutil.MockModel{Responses: []*genai.Content{
+				genai.NewContentFromText("sunny", genai.RoleModel),
+			}}
+			a, err := llmagent.New(llmagent.Config{
+				Name:                      "weather_agent",
+				Model:                     model,
+				Tools:                     tools,
+				DescribeToolOutputSchemas: tc.enabled,
+			})
+			if err != nil {
+				t.Fatalf("failed to create LLM Agent: %v", err)
+			}
+			if _, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "weather in Paris?")); err != nil {
+				t.Fatalf("agent run failed: %v", err)
+			}
+
+			var instructions []string
+			if si := model.Requests[0].Config.SystemInstruction; si != nil {
+				for _, p := range si.Parts {
+					instructions = append(instructions, p.Text)
+				}
+			}
+			if got := slices.Contains(instructions, want); got != tc.enabled {
+				t.Errorf("system instruction %q contains the output schemas: %v, want %v", instructions, got, tc.enabled)
+			}
+		})
+	}
+}
+
+func TestAfterModelCallbackChain(t *testing.T) {
+	t.Parallel()
+
+	const refusal = "I can't share that."
+	newCallbacks := func(audited *[]string) []llmagent.AfterModelCallback {
+		return []llmagent.AfterModelCallback{
+			// Continues the chain with the response modified in place.
+			func(ctx agent.CallbackContext, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+				for _, p := range resp.Content.Parts {
+					p.Text = strings.TrimSpace(p.Text)
+				}
+				return nil, nil
+			},
+			// Halts the chain with a replacement response.
+			func(ctx agent.CallbackContext, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+				for _, p := range resp.Content.Parts {
+					if strings.Contains(p.Text, "password") {
+						return &model.LLMResponse{Content: genai.NewContentFromText(refusal, genai.RoleModel)}, nil
+					}
+				}
+				return nil, nil
+			},
+			func(ctx agent.CallbackContext, resp *model.LLMResponse, err error) (*model.LLMResponse, error) {
+				*audited = append(*audited, resp.Content.Parts[0].Text)
+				return nil, nil
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		response    string
+		wantTexts   []string
+		wantAudited []string
+	}{
+		{
+			name:        "continue",
+			response:    "  hello  ",
+			wantTexts:   []string{"hello"},
+			wantAudited: []string{"hello"},
+		},
+		{
+			name:      "halt",
+			response:  " the password is 1234 ",
+			wantTexts: []string{refusal},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var audited []string
+			a, err := llmagent.New(llmagent.Config{
+				Name:                "guarded_agent",
+				Model:               &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText(tc.response, genai.RoleModel)}},
+				AfterModelCallbacks: newCallbacks(&audited),
+			})
+			if err != nil {
+				t.Fatalf("failed to create llm agent: %v", err)
+			}
+			texts, err := testutil.CollectTextParts(testutil.NewTestAgentRunner(t, a).Run(t, "test_session", "hi"))
+			if err != nil {
+				t.Fatalf("agent run failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantTexts, texts); diff != "" {
+				t.Errorf("texts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAudited, audited); diff != "" {
+				t.Errorf("audited responses mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateContentConfigPrecedence(t *testing.T) {
+	t.Parallel()
+
+	agentConfig := &genai.GenerateContentConfig{
+		Temperature:     genai.Ptr[float32](0.2),
+		MaxOutputTokens: 100,
+	}
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("hi", genai.RoleModel),
+		genai.NewContentFromText("hi again", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                  "test_agent",
+		Model:                 mockModel,
+		GenerateContentConfig: agentConfig,
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	cfg := agent.RunConfig{GenerateContentConfig: &genai.GenerateContentConfig{
+		Temperature:       genai.Ptr[float32](0.9),
+		SystemInstruction: genai.NewContentFromText("ignored", genai.RoleUser),
+	}}
+	if _, err := testutil.CollectEvents(testRunner.RunContentWithConfig(t, "session", genai.NewContentFromText("hello", genai.RoleUser), cfg)); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "hello")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		req             *model.LLMRequest
+		wantTemperature float32
+	}{
+		{name: "invocation override", req: mockModel.Requests[0], wantTemperature: 0.9},
+		{name: "agent default", req: mockModel.Requests[1], wantTemperature: 0.2},
+	}
+	for _, tc := range tests {
+		got := tc.req.Config
+		if got.Temperature == nil || *got.Temperature != tc.wantTemperature {
+			t.Errorf("%s: Temperature = %v, want %v", tc.name, got.Temperature, tc.wantTemperature)
+		}
+		if got.MaxOutputTokens != 100 {
+			t.Errorf("%s: MaxOutputTokens = %d, want the agent's 100", tc.name, got.MaxOutputTokens)
+		}
+		if got.TopK != nil {
+			t.Errorf("%s: TopK = %v, want it left to the model", tc.name, *got.TopK)
+		}
+		if got.SystemInstruction != nil {
+			t.Errorf("%s: SystemInstruction = %v, want none", tc.name, got.SystemInstruction)
+		}
+	}
+	if *agentConfig.Temperature != 0.2 {
+		t.Errorf("agent GenerateContentConfig was modified: Temperature = %v", *agentConfig.Temperature)
+	}
+}
+
+func TestSetInstruction(t *testing.T) {
+	t.Parallel()
+
+	setPersona, err := functiontool.New(functiontool.Config{
+		Name:        "set_persona",
+		Description: "sets the persona of the assistant",
+	}, func(ctx tool.Context, args map[string]any) (map[string]any, error) {
+		tool.SetInstruction(ctx, "persona", fmt.Sprintf("Talk like a %v.", args["persona"]))
+		return map[string]any{"status": "ok"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("set_persona", map[string]any{"persona": "pirate"}, genai.RoleModel),
+		genai.NewContentFromText("Ahoy!", genai.RoleModel),
+		genai.NewContentFromText("Hello.", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:        "test_agent",
+		Model:       llm,
+		Instruction: "You are an assistant.",
+		Tools:       []tool.Tool{setPersona},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+
+	testRunner := testutil.NewTestAgentRunner(t, a)
+	for _, input := range []string{"be a pirate", "hi"} {
+		if _, err := testutil.CollectEvents(testRunner.Run(t, "session", input)); err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+	}
+
+	var got []string
+	for _, req := range llm.Requests {
+		var texts []string
+		for _, p := range req.Config.SystemInstruction.Parts {
+			texts = append(texts, p.Text)
+		}
+		got = append(got, strings.Join(texts, "\n\n"))
+	}
+	want := []string{
+		"You are an assistant.",
+		"You are an assistant.\n\nTalk like a pirate.",
+		// The section is scoped to the invocation of the tool call.
+		"You are an assistant.",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("system instructions mismatch (-want +got):\n%s", diff)
+	}
+}
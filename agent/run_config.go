@@ -14,6 +14,13 @@
 
 package agent
 
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genai"
+)
+
 // StreamingMode defines the streaming mode for agent execution.
 type StreamingMode string
 
@@ -25,11 +32,60 @@ const (
 	StreamingModeSSE StreamingMode = "sse"
 )
 
+// ErrMaxLLMCallsExceeded is returned when an invocation makes more LLM calls
+// than allowed by RunConfig.MaxLLMCalls.
+var ErrMaxLLMCallsExceeded = errors.New("max LLM calls exceeded")
+
 // RunConfig controls runtime behavior of an agent.
+//
+// It collects the options of a single invocation. The zero value is a valid
+// configuration that uses the defaults.
 type RunConfig struct {
 	// StreamingMode defines the streaming mode for an agent.
 	StreamingMode StreamingMode
+	// MaxLLMCalls limits the total number of LLM calls made in an invocation,
+	// across all the agents it runs. It guards against agents looping
+	// endlessly, e.g. calling tools over and over. When the limit is exceeded,
+	// the invocation fails with ErrMaxLLMCallsExceeded.
+	//
+	// Zero or a negative value means no limit.
+	MaxLLMCalls int
 	// If true, ADK runner will save each part of the user input that is a blob
 	// (e.g., images, files) as an artifact.
 	SaveInputBlobsAsArtifacts bool
+	// PartialResponsePersistInterval, if positive, makes the runner save the
+	// text streamed so far to the session state at most once per interval
+	// while a response is being streamed, so that a partial answer can be
+	// recovered with runner.LoadPartialResponse if the process crashes before
+	// the response completes. Only used with StreamingModeSSE.
+	PartialResponsePersistInterval time.Duration
+	// StatusEvents makes LLM agents emit events of session.EventKindStatus
+	// when they enter a new phase: planning, calling tools and finalizing.
+	// The status events are streamed to the caller, but not saved to the
+	// session.
+	StatusEvents bool
+	// EphemeralContents are added to each LLM request of the invocation,
+	// right before the user content, but never saved to the session. Use
+	// them for context that goes stale, e.g. retrieved documents or the
+	// current weather, so that every turn is grounded on fresh context
+	// without bloating the session history.
+	EphemeralContents []*genai.Content
+	// GenerateContentConfig overrides the generation parameters of the LLM
+	// agents for this invocation, e.g. the temperature. Its set fields take
+	// precedence over the llmagent.Config.GenerateContentConfig of the
+	// agents, whose set fields take precedence over the model's defaults.
+	// Its SystemInstruction and Tools are ignored, they come from the
+	// agents' configuration.
+	GenerateContentConfig *genai.GenerateContentConfig
+	// Locale is the language of the conversation, as a BCP 47 language
+	// tag, e.g. "fr" or "pt-BR". LLM agents send the descriptions of their
+	// tools translated to it, if their llmagent.Config has translations.
+	Locale string
+	// StreamThoughts makes LLM agents ask the models for their thoughts,
+	// setting the IncludeThoughts of the thinking configuration of the
+	// requests. With StreamingModeSSE, the thoughts are streamed as partial
+	// events of session.EventCategoryThinking, apart from the partial
+	// events of the answer, e.g. to show the reasoning of the model in a
+	// collapsible panel.
+	StreamThoughts bool
 }
@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/usage"
+	"google.golang.org/adk/model"
+)
+
+// InvocationUsage returns the token usage summed over all the model calls
+// made so far in the invocation of ctx, including the calls following tool
+// calls and the calls of agents run as tools. ctx is any context derived from
+// the invocation, such as an InvocationContext, a CallbackContext or a
+// tool.Context.
+//
+// Combined with the model pricing, it gives the running cost of the
+// invocation, e.g. for a cost meter updated from an AfterModelCallback.
+//
+// It returns nil if ctx does not belong to an invocation started by a runner.
+func InvocationUsage(ctx context.Context) *genai.GenerateContentResponseUsageMetadata {
+	a := usage.FromContext(ctx)
+	if a == nil {
+		return nil
+	}
+	return a.Total()
+}
+
+// InvocationCost returns the cost of the model calls made so far in the
+// invocation of ctx, priced with prices. Calls to models missing from prices
+// are not counted.
+//
+// It returns 0 if ctx does not belong to an invocation started by a runner.
+func InvocationCost(ctx context.Context, prices model.PricingTable) float64 {
+	a := usage.FromContext(ctx)
+	if a == nil {
+		return 0
+	}
+	var cost float64
+	for modelName, u := range a.ByModel() {
+		cost += prices.CostOf(u, modelName)
+	}
+	return cost
+}
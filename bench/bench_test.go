@@ -0,0 +1,252 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/internal/typeutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type address struct {
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Country string `json:"country,omitempty"`
+}
+
+type searchArgs struct {
+	Query    string   `json:"query" jsonschema:"the search query"`
+	Limit    int      `json:"limit,omitempty" jsonschema:"the maximum number of results"`
+	Tags     []string `json:"tags,omitempty"`
+	Near     *address `json:"near,omitempty"`
+	Verified bool     `json:"verified,omitempty"`
+}
+
+type searchResult struct {
+	Titles []string `json:"titles"`
+}
+
+func search(_ tool.Context, args searchArgs) (searchResult, error) {
+	return searchResult{Titles: []string{args.Query}}, nil
+}
+
+func newTools(b *testing.B, n int) []tool.Tool {
+	b.Helper()
+	tools := make([]tool.Tool, n)
+	for i := range tools {
+		t, err := functiontool.New(functiontool.Config{
+			Name:        fmt.Sprintf("search_%d", i),
+			Description: "Searches the documents.",
+		}, search)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tools[i] = t
+	}
+	return tools
+}
+
+func BenchmarkAppendTools(b *testing.B) {
+	for _, n := range []int{1, 10, 100, 200} {
+		b.Run(fmt.Sprintf("tools=%d", n), func(b *testing.B) {
+			tools := newTools(b, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				// As done by the flow for each model call.
+				req := &model.LLMRequest{}
+				done := toolutils.Reserve(req, len(tools))
+				for _, t := range tools {
+					if err := t.(toolinternal.RequestProcessor).ProcessRequest(nil, req); err != nil {
+						b.Fatal(err)
+					}
+				}
+				done()
+				if err := toolutils.CheckDeclarations(req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSchemaInference(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := functiontool.New(functiontool.Config{Name: "search", Description: "Searches the documents."}, search); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkConvertToWithJSONSchema(b *testing.B) {
+	schema, err := jsonschema.For[searchArgs](nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	args := map[string]any{
+		"query": "weather",
+		"limit": 10,
+		"tags":  []any{"news", "local"},
+		"near":  map[string]any{"street": "1 Main St", "city": "Springfield"},
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := typeutil.ConvertToWithJSONSchema[map[string]any, searchArgs](args, resolved); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEstimateTokens(b *testing.B) {
+	req := &model.LLMRequest{Config: &genai.GenerateContentConfig{
+		SystemInstruction: genai.NewContentFromText(strings.Repeat("You are a helpful assistant. ", 50), genai.RoleUser),
+	}}
+	for _, t := range newTools(b, 10) {
+		if err := t.(toolinternal.RequestProcessor).ProcessRequest(nil, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := range 20 {
+		req.Contents = append(req.Contents,
+			genai.NewContentFromText(fmt.Sprintf("Question %d: what is the weather like?", i), genai.RoleUser),
+			genai.NewContentFromFunctionCall("search_0", map[string]any{"query": "weather"}, genai.RoleModel),
+			genai.NewContentFromFunctionResponse("search_0", map[string]any{"titles": []any{"Sunny", "Warm"}}, genai.RoleUser),
+			genai.NewContentFromText("It is sunny and warm.", genai.RoleModel),
+		)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		model.EstimateTokens(req)
+	}
+}
+
+// scriptedModel calls the first tool of the request, then answers with a
+// text once it gets the function response, so that each run of an agent
+// goes through one tool call.
+type scriptedModel struct{}
+
+func (scriptedModel) Name() string {
+	return "scripted"
+}
+
+func (scriptedModel) GenerateContent(_ context.Context, req *model.LLMRequest, _ bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		content := genai.NewContentFromText("It is sunny.", genai.RoleModel)
+		if last := req.Contents[len(req.Contents)-1]; last.Parts[0].FunctionResponse == nil {
+			content = genai.NewContentFromFunctionCall(req.ToolNames()[0], map[string]any{"query": "weather"}, genai.RoleModel)
+		}
+		yield(&model.LLMResponse{Content: content, TurnComplete: true}, nil)
+	}
+}
+
+func BenchmarkAgentLoop(b *testing.B) {
+	for _, n := range []int{1, 20} {
+		b.Run(fmt.Sprintf("tools=%d", n), func(b *testing.B) {
+			a, err := llmagent.New(llmagent.Config{
+				Name:        "assistant",
+				Model:       scriptedModel{},
+				Instruction: "You are a helpful assistant.",
+				Tools:       newTools(b, n),
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			run := newRunFunc(b, a)
+			b.ReportAllocs()
+			for b.Loop() {
+				run("What is the weather like?")
+			}
+		})
+	}
+}
+
+// prompts is the fixed prompt set of BenchmarkGeminiPrompts.
+var prompts = []string{
+	"What is the capital of France? Answer with one word.",
+	"Summarize in one sentence: the quick brown fox jumps over the lazy dog.",
+	"Write a haiku about benchmarks.",
+	"List three prime numbers greater than 100.",
+}
+
+func BenchmarkGeminiPrompts(b *testing.B) {
+	modelName := os.Getenv("ADK_BENCH_MODEL")
+	if modelName == "" {
+		b.Skip("ADK_BENCH_MODEL is not set")
+	}
+	llm, err := gemini.NewModel(b.Context(), modelName, &genai.ClientConfig{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:        "assistant",
+		Model:       llm,
+		Instruction: "You are a helpful assistant. Keep your answers short.",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	run := newRunFunc(b, a)
+	for i, prompt := range prompts {
+		b.Run(fmt.Sprintf("prompt=%d", i), func(b *testing.B) {
+			for b.Loop() {
+				run(prompt)
+			}
+		})
+	}
+}
+
+// newRunFunc returns a function running a in a new session with the given
+// message, and failing b on errors.
+func newRunFunc(b *testing.B, a agent.Agent) func(msg string) {
+	b.Helper()
+	sessions := session.InMemoryService()
+	r, err := runner.New(runner.Config{AppName: "bench", Agent: a, SessionService: sessions})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return func(msg string) {
+		resp, err := sessions.Create(b.Context(), &session.CreateRequest{AppName: "bench", UserID: "user"})
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, err := range r.Run(b.Context(), "user", resp.Session.ID(), genai.NewContentFromText(msg, genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bench holds the benchmarks of the hot paths of the ADK: the
+// assembly of the model requests (tool declarations, schema inference and
+// validation, token estimation) and the agent loop, run against a scripted
+// model.
+//
+// Run them with:
+//
+//	go test ./bench -run '^$' -bench . -benchmem
+//
+// and compare runs with benchstat to detect regressions.
+//
+// BenchmarkGeminiPrompts measures the latency of a real model on a fixed set
+// of prompts. It is skipped unless ADK_BENCH_MODEL names the model, e.g.
+// gemini-2.5-flash, with the credentials of the Gemini API client set in the
+// environment, e.g. GOOGLE_API_KEY:
+//
+//	ADK_BENCH_MODEL=gemini-2.5-flash go test ./bench -run '^$' -bench Gemini -benchtime 3x
+package bench
@@ -14,7 +14,12 @@
 
 package runconfig
 
-import "context"
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/adk/model"
+)
 
 type StreamingMode string
 
@@ -26,6 +31,18 @@ const (
 
 type RunConfig struct {
 	StreamingMode StreamingMode
+	MaxLLMCalls   int
+	// OnRequest, if set, is called with each request right before it is
+	// sent to the model.
+	OnRequest func(*model.LLMRequest)
+
+	llmCalls atomic.Int64
+}
+
+// AddLLMCall records an LLM call made in the invocation and returns the
+// number of calls made so far.
+func (c *RunConfig) AddLLMCall() int {
+	return int(c.llmCalls.Add(1))
 }
 
 func ToContext(ctx context.Context, cfg *RunConfig) context.Context {
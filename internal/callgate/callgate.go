@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package callgate bounds the number of model and tool calls in flight
+// across all the agents of an invocation, e.g. the sub-agents of nested
+// parallel agents.
+package callgate
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+type ctxKey int
+
+const (
+	semaphoreCtxKey ctxKey = iota
+	heldCtxKey
+)
+
+// ToContext returns a context whose calls are bounded by sem.
+func ToContext(ctx context.Context, sem *semaphore.Weighted) context.Context {
+	return context.WithValue(ctx, semaphoreCtxKey, sem)
+}
+
+// FromContext returns the semaphore bounding the calls of ctx, or nil.
+func FromContext(ctx context.Context) *semaphore.Weighted {
+	sem, _ := ctx.Value(semaphoreCtxKey).(*semaphore.Weighted)
+	return sem
+}
+
+// Acquire waits for a slot of the semaphore of ctx and returns the context
+// to make the call with and the function releasing the slot, which may be
+// called several times.
+//
+// Calls made within a call already holding a slot, e.g. the model calls of
+// an agent run as a tool, do not acquire another one: waiting for it could
+// deadlock once all the slots are held by such calls.
+func Acquire(ctx context.Context) (context.Context, func(), error) {
+	sem := FromContext(ctx)
+	if sem == nil || ctx.Value(heldCtxKey) != nil {
+		return ctx, func() {}, nil
+	}
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return ctx, nil, err
+	}
+	var once sync.Once
+	release := func() { once.Do(func() { sem.Release(1) }) }
+	return context.WithValue(ctx, heldCtxKey, true), release, nil
+}
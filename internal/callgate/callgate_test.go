@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callgate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/sync/semaphore"
+)
+
+func TestAcquire(t *testing.T) {
+	if _, release, err := Acquire(t.Context()); err != nil {
+		t.Fatalf("Acquire() without semaphore failed: %v", err)
+	} else {
+		release()
+	}
+
+	sem := semaphore.NewWeighted(1)
+	ctx := ToContext(t.Context(), sem)
+	callCtx, release, err := Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire() failed: %v", err)
+	}
+
+	// Calls nested in the call holding the slot do not wait for another.
+	_, nestedRelease, err := Acquire(callCtx)
+	if err != nil {
+		t.Fatalf("nested Acquire() failed: %v", err)
+	}
+	nestedRelease()
+	if sem.TryAcquire(1) {
+		t.Fatal("nested release freed the slot of the outer call")
+	}
+
+	// Other calls wait for the slot.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := Acquire(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() with all slots held = %v, want %v", err, context.Canceled)
+	}
+
+	release()
+	release()
+	if !sem.TryAcquire(1) {
+		t.Fatal("release did not free the slot")
+	}
+	if sem.TryAcquire(1) {
+		t.Error("releasing twice freed two slots")
+	}
+}
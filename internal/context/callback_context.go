@@ -17,6 +17,7 @@ package context
 import (
 	"context"
 	"iter"
+	"sync"
 
 	"google.golang.org/genai"
 
@@ -74,6 +75,10 @@ type callbackContext struct {
 	artifacts     *internalArtifacts
 	invocationCtx agent.InvocationContext
 	eventActions  *session.EventActions
+
+	// stateMu serializes the state accesses of the context, so that a
+	// tool may read and write the state from several goroutines.
+	stateMu sync.Mutex
 }
 
 func (c *callbackContext) Artifacts() agent.Artifacts {
@@ -105,8 +110,13 @@ type callbackContextState struct {
 }
 
 func (c *callbackContextState) Get(key string) (any, error) {
+	c.ctx.stateMu.Lock()
+	defer c.ctx.stateMu.Unlock()
 	if c.ctx.eventActions != nil && c.ctx.eventActions.StateDelta != nil {
 		if val, ok := c.ctx.eventActions.StateDelta[key]; ok {
+			if val == nil {
+				return nil, session.ErrStateKeyNotExist
+			}
 			return val, nil
 		}
 	}
@@ -114,12 +124,23 @@ func (c *callbackContextState) Get(key string) (any, error) {
 }
 
 func (c *callbackContextState) Set(key string, val any) error {
+	c.ctx.stateMu.Lock()
+	defer c.ctx.stateMu.Unlock()
 	if c.ctx.eventActions != nil && c.ctx.eventActions.StateDelta != nil {
 		c.ctx.eventActions.StateDelta[key] = val
 	}
 	return c.ctx.invocationCtx.Session().State().Set(key, val)
 }
 
+func (c *callbackContextState) Delete(key string) error {
+	c.ctx.stateMu.Lock()
+	defer c.ctx.stateMu.Unlock()
+	if c.ctx.eventActions != nil && c.ctx.eventActions.StateDelta != nil {
+		c.ctx.eventActions.StateDelta[key] = nil
+	}
+	return c.ctx.invocationCtx.Session().State().Delete(key)
+}
+
 func (c *callbackContextState) All() iter.Seq2[string, any] {
 	return c.ctx.invocationCtx.Session().State().All()
 }
@@ -16,12 +16,14 @@ package context
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
 )
 
 func TestReadonlyContext(t *testing.T) {
@@ -45,6 +47,34 @@ func TestCallbackContext(t *testing.T) {
 	}
 }
 
+func TestCallbackContextStateDelete(t *testing.T) {
+	resp, err := session.InMemoryService().Create(t.Context(), &session.CreateRequest{
+		AppName: "app", UserID: "user", State: map[string]any{"k1": "v1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv := NewInvocationContext(t.Context(), InvocationContextParams{Session: resp.Session})
+	delta := make(map[string]any)
+	state := NewCallbackContextWithDelta(inv, delta).State()
+
+	if err := state.Set("k2", "v2"); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"k1", "k2"} {
+		if err := state.Delete(key); err != nil {
+			t.Fatalf("Delete(%q) failed: %v", key, err)
+		}
+		if _, err := state.Get(key); !errors.Is(err, session.ErrStateKeyNotExist) {
+			t.Errorf("Get(%q) error = %v, want %v", key, err, session.ErrStateKeyNotExist)
+		}
+	}
+	// The deletions are recorded in the state delta of the event.
+	if diff := cmp.Diff(map[string]any{"k1": nil, "k2": nil}, delta); diff != "" {
+		t.Errorf("state delta mismatch (-want +got):\n%s", diff)
+	}
+}
+
 type testKey struct{}
 
 func TestWithContext(t *testing.T) {
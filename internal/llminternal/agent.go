@@ -15,6 +15,8 @@
 package llminternal
 
 import (
+	"time"
+
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
@@ -32,6 +34,16 @@ type State struct {
 
 	Tools    []tool.Tool
 	Toolsets []tool.Toolset
+	// ToolDescriptions overrides the descriptions of the tools' function
+	// declarations by tool name.
+	ToolDescriptions map[string]string
+	// LocalizedToolDescriptions overrides the descriptions of the tools'
+	// function declarations by tool name and language, for the locale of
+	// the invocation.
+	LocalizedToolDescriptions map[string]map[string]string
+	// DescribeToolOutputSchemas adds a description of the tools' output
+	// schemas to the system instruction.
+	DescribeToolOutputSchemas bool
 
 	IncludeContents string
 
@@ -49,6 +61,20 @@ type State struct {
 	OutputSchema *genai.Schema
 
 	OutputKey string
+
+	EmptyResponsePolicy     EmptyResponsePolicy
+	EmptyResponseMaxRetries int
+
+	ToolDeclarationsOnFirstTurnOnly bool
+	ToolDependencies                map[string][]string
+
+	ToolResultSummaryModel       model.LLM
+	MaxToolResultSize            int
+	ToolResultSummaryInstruction string
+
+	CurrentTime func() time.Time
+
+	RequestLayout []RequestSection
 }
 
 type InstructionProvider func(ctx agent.ReadonlyContext) (string, error)
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -68,7 +69,7 @@ func AgentTransferRequestProcessor(ctx agent.InvocationContext, req *model.LLMRe
 	return func(yield func(*session.Event, error) bool) {
 		// TODO: support agent types other than LLMAgent, that have parent/subagents?
 		agent := ctx.Agent()
-		if !shouldUseAutoFlow(agent) {
+		if !shouldUseAutoFlow(agent) || hasTransferTool(agent) {
 			return
 		}
 
@@ -182,6 +183,19 @@ func transferTargets(agent, parent agent.Agent) []agent.Agent {
 	return targets
 }
 
+// hasTransferTool reports whether the agent was given its own transfer tool,
+// e.g. by the transfertool package, replacing the one added for its
+// sub-agents, parent and peers.
+func hasTransferTool(agent agent.Agent) bool {
+	llmAgent := asLLMAgent(agent)
+	if llmAgent == nil {
+		return false
+	}
+	return slices.ContainsFunc(llmAgent.internal().Tools, func(t tool.Tool) bool {
+		return t != nil && t.Name() == "transfer_to_agent"
+	})
+}
+
 func asLLMAgent(agent agent.Agent) Agent {
 	if agent == nil {
 		return nil
@@ -200,7 +214,8 @@ func shouldUseAutoFlow(agent agent.Agent) bool {
 	return len(agent.SubAgents()) != 0 || !a.internal().DisallowTransferToParent || !a.internal().DisallowTransferToPeers
 }
 
-// AppendTools appends the tools to the request.
+// appendTools appends the tools to the request, their function declarations
+// grouped with the other ones in a single genai.Tool.
 // Appending duplicate tools or nameless tools is an error.
 func appendTools(r *model.LLMRequest, tools ...tool.Tool) error {
 	if r.Tools == nil {
@@ -221,7 +236,6 @@ func appendTools(r *model.LLMRequest, tools ...tool.Tool) error {
 
 		if fnTool, ok := tool.(toolinternal.FunctionTool); ok {
 			if decl := fnTool.Declaration(); decl != nil {
-				// TODO: verify for duplicates.
 				declarations = append(declarations, decl)
 			}
 		}
@@ -229,25 +243,7 @@ func appendTools(r *model.LLMRequest, tools ...tool.Tool) error {
 	if len(declarations) == 0 {
 		return nil
 	}
-	if r.Config == nil {
-		r.Config = &genai.GenerateContentConfig{}
-	}
-	// Find an existing genai.Tool with FunctionDeclarations
-	var funcTool *genai.Tool
-	for _, gt := range r.Config.Tools {
-		if gt.FunctionDeclarations != nil {
-			funcTool = gt
-			break
-		}
-	}
-	if funcTool != nil {
-		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, declarations...)
-	} else {
-		r.Config.Tools = append(r.Config.Tools, &genai.Tool{
-			FunctionDeclarations: declarations,
-		})
-	}
-	return nil
+	return toolutils.AppendDeclarations(r, declarations...)
 }
 
 var transferToAgentPromptTmpl = template.Must(
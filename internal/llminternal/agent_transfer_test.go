@@ -448,6 +448,47 @@ func TestAgentTransfer_ProcessRequest(t *testing.T) {
 	}
 }
 
+func TestAgentTransfer_ProcessRequestGroupsDeclarations(t *testing.T) {
+	identityTool, err := functiontool.New(functiontool.Config{
+		Name:        "identity",
+		Description: "returns the input value",
+	}, func(ctx tool.Context, input map[string]any) (map[string]any, error) {
+		return input, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	req := model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{nil, search}}}
+	if err := identityTool.(toolinternal.RequestProcessor).ProcessRequest(nil, &req); err != nil {
+		t.Fatalf("identityTool.ProcessRequest failed: %v", err)
+	}
+	if err := (&llminternal.TransferToAgentTool{}).ProcessRequest(nil, &req); err != nil {
+		t.Fatalf("transferToAgentTool.ProcessRequest failed: %v", err)
+	}
+
+	// The server-side tool is kept apart from the function declarations.
+	if len(req.Config.Tools) != 3 || req.Config.Tools[1] != search {
+		t.Fatalf("got tools %+v, want the search tool followed by the function declarations", req.Config.Tools)
+	}
+	var names []string
+	for _, decl := range req.Config.Tools[2].FunctionDeclarations {
+		names = append(names, decl.Name)
+	}
+	if diff := cmp.Diff([]string{"identity", "transfer_to_agent"}, names); diff != "" {
+		t.Errorf("function declarations mismatch (-want +got):\n%s", diff)
+	}
+
+	// A declaration added without the tool is still a duplicate.
+	req = model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{{
+		FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "transfer_to_agent"}},
+	}}}}
+	err = (&llminternal.TransferToAgentTool{}).ProcessRequest(nil, &req)
+	if err == nil || !strings.Contains(err.Error(), "duplicate function declaration") {
+		t.Errorf("ProcessRequest() error = %v, want a duplicate function declaration error", err)
+	}
+}
+
 func TestTransferToAgentToolRun(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		curTool := &llminternal.TransferToAgentTool{}
@@ -19,7 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"log"
 	"maps"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -28,10 +30,13 @@ import (
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/agent/runconfig"
+	"google.golang.org/adk/internal/callgate"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/plugininternal/plugincontext"
 	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/internal/usage"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -65,6 +70,31 @@ type Flow struct {
 	BeforeToolCallbacks   []BeforeToolCallback
 	AfterToolCallbacks    []AfterToolCallback
 	OnToolErrorCallbacks  []OnToolErrorCallback
+
+	// EmptyResponsePolicy defines how responses without content are handled.
+	EmptyResponsePolicy EmptyResponsePolicy
+	// EmptyResponseMaxRetries is the number of times the model is called
+	// again after an empty response, with EmptyResponseRetry.
+	EmptyResponseMaxRetries int
+
+	// ToolDeclarationsOnFirstTurnOnly sends the function declarations only
+	// with the first request of the run, unless the model loses track of
+	// the tools.
+	ToolDeclarationsOnFirstTurnOnly bool
+	// ToolDependencies orders the execution of the function calls of a
+	// response: each tool runs after the tools it depends on.
+	ToolDependencies map[string][]string
+	// ToolResultSummaryModel summarizes the tool results larger than
+	// MaxToolResultSize bytes, with ToolResultSummaryInstruction.
+	ToolResultSummaryModel       model.LLM
+	MaxToolResultSize            int
+	ToolResultSummaryInstruction string
+	// RequestLayout orders the sections of the requests. Nil keeps the
+	// default layout.
+	RequestLayout []RequestSection
+
+	toolDeclarationsSent   bool
+	resendToolDeclarations bool
 }
 
 var (
@@ -105,6 +135,9 @@ func (f *Flow) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error]
 				if !yield(ev, nil) {
 					return
 				}
+				if ev.Kind == session.EventKindStatus {
+					continue
+				}
 				lastEvent = ev
 			}
 			if lastEvent == nil || lastEvent.IsFinalResponse() {
@@ -146,11 +179,20 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 		if ctx.Ended() {
 			return
 		}
+		f.applyToolDeclarationsPolicy(req)
+		if ev := statusEvent(ctx, session.AgentStatusPlanning); ev != nil {
+			if !yield(ev, nil) {
+				return
+			}
+		}
 		spans := telemetry.StartTrace(ctx, "call_llm")
 		// Create event to pass to callback state delta
 		stateDelta := make(map[string]any)
+		// The text streamed before the function calls, emitted as its own
+		// event by the stream aggregator.
+		var rationale string
 		// Calls the LLM.
-		for resp, err := range f.callLLM(ctx, req, stateDelta) {
+		for resp, err := range f.callLLMHandlingEmptyResponses(ctx, req, stateDelta) {
 			if err != nil {
 				yield(nil, err)
 				return
@@ -159,6 +201,7 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 				yield(nil, err)
 				return
 			}
+			f.checkToolDeclarationsLost(req, resp)
 			// Skip the model response event if there is no content and no error code.
 			// This is needed for the code executor to trigger another loop according to
 			// adk-python src/google/adk/flows/llm_flows/base_llm_flow.py BaseLlmFlow._postprocess_async.
@@ -180,10 +223,31 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 
 			// Build the event and yield.
 			modelResponseEvent := f.finalizeModelResponseEvent(ctx, resp, tools, stateDelta)
+			rationale = attachCallRationale(modelResponseEvent, rationale)
 			telemetry.TraceLLMCall(spans, ctx, req, modelResponseEvent)
+			if modelResponseEvent.IsFinalResponse() {
+				if ev := statusEvent(ctx, session.AgentStatusFinalizing); ev != nil {
+					if !yield(ev, nil) {
+						return
+					}
+				}
+			}
 			if !yield(modelResponseEvent, nil) {
 				return
 			}
+			if resp.Partial && len(utils.FunctionCalls(resp.Content)) > 0 {
+				// The arguments are still streamed, the call runs once
+				// complete.
+				receivePartialArgs(ctx, tools, resp)
+				continue
+			}
+			if len(utils.FunctionCalls(resp.Content)) > 0 && !resp.Partial {
+				if ev := statusEvent(ctx, session.AgentStatusCallingTools); ev != nil {
+					if !yield(ev, nil) {
+						return
+					}
+				}
+			}
 			// TODO: generate and yield an auth event if needed.
 
 			// Handle function calls.
@@ -198,7 +262,7 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 				continue
 			}
 
-			toolConfirmationEvent := generateRequestConfirmationEvent(ctx, modelResponseEvent, ev)
+			toolConfirmationEvent := generateRequestConfirmationEvent(ctx, modelResponseEvent, ev, tools)
 			if toolConfirmationEvent != nil {
 				if !yield(toolConfirmationEvent, nil) {
 					return
@@ -257,11 +321,19 @@ func (f *Flow) preprocess(ctx agent.InvocationContext, req *model.LLMRequest) it
 			}
 		}
 
+		var toolContextStart int
+		if req.Config != nil && req.Config.SystemInstruction != nil {
+			toolContextStart = len(req.Config.SystemInstruction.Parts)
+		}
 		if f.Tools != nil {
 			if err := toolPreprocess(ctx, req, f.Tools); err != nil {
 				yield(nil, err)
+				return
 			}
+			overrideToolDescriptions(ctx, req)
+			describeToolOutputSchemas(ctx, req)
 		}
+		f.applyRequestLayout(req, toolContextStart)
 	}
 }
 
@@ -269,6 +341,8 @@ func (f *Flow) preprocess(ctx agent.InvocationContext, req *model.LLMRequest) it
 // If a tool set is encountered, it's expanded recursively in DFS fashion.
 // TODO: check need/feasibility of running this concurrently.
 func toolPreprocess(ctx agent.InvocationContext, req *model.LLMRequest, tools []tool.Tool) error {
+	done := toolutils.Reserve(req, len(tools))
+	defer done()
 	for _, t := range tools {
 		requestProcessor, ok := t.(toolinternal.RequestProcessor)
 		if !ok {
@@ -280,7 +354,7 @@ func toolPreprocess(ctx agent.InvocationContext, req *model.LLMRequest, tools []
 			return err
 		}
 	}
-	return nil
+	return toolutils.CheckDeclarations(req)
 }
 
 func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, stateDelta map[string]any) iter.Seq2[*model.LLMResponse, error] {
@@ -308,10 +382,38 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 		// TODO: Set _ADK_AGENT_NAME_LABEL_KEY in req.GenerateConfig.Labels
 		// to help with slicing the billing reports on a per-agent basis.
 
+		cfg := runconfig.FromContext(ctx)
+		if cfg.MaxLLMCalls > 0 && cfg.AddLLMCall() > cfg.MaxLLMCalls {
+			yield(nil, fmt.Errorf("%w: the limit is %d", agent.ErrMaxLLMCallsExceeded, cfg.MaxLLMCalls))
+			return
+		}
+
 		// TODO: RunLive mode when invocation_context.run_config.support_cfc is true.
-		useStream := runconfig.FromContext(ctx).StreamingMode == runconfig.StreamingModeSSE
+		useStream := cfg.StreamingMode == runconfig.StreamingModeSSE
+
+		if cfg.OnRequest != nil {
+			cfg.OnRequest(req)
+		}
+
+		callCtx, release, err := callgate.Acquire(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer release()
 
-		for resp, err := range f.Model.GenerateContent(ctx, req, useStream) {
+		usageAccumulator := usage.FromContext(ctx)
+		for resp, err := range f.Model.GenerateContent(callCtx, req, useStream) {
+			if err != nil || !resp.Partial {
+				// The model call is over. Release the slot before the
+				// response is handled, as handling it runs the tools.
+				release()
+			}
+			// Partial responses are aggregated in the final one, only count
+			// the latter.
+			if err == nil && usageAccumulator != nil && resp != nil && !resp.Partial {
+				usageAccumulator.Add(req.Model, resp.UsageMetadata)
+			}
 			if err != nil {
 				cbResp, cbErr := f.runOnModelErrorCallbacks(ctx, req, stateDelta, err)
 				if cbErr != nil {
@@ -417,6 +519,23 @@ func (f *Flow) agentToRun(ctx agent.InvocationContext, agentName string) agent.A
 			return agent
 		}
 	}
+	if hasTransferTool(ctx.Agent()) {
+		// The agents of a transfer tool given to the agent may be anywhere
+		// in the agent tree.
+		return findAgent(parents.RootAgent(ctx.Agent()), agentName)
+	}
+	return nil
+}
+
+func findAgent(cur agent.Agent, name string) agent.Agent {
+	if cur == nil || cur.Name() == name {
+		return cur
+	}
+	for _, subAgent := range cur.SubAgents() {
+		if found := findAgent(subAgent, name); found != nil {
+			return found
+		}
+	}
 	return nil
 }
 
@@ -481,22 +600,31 @@ Suggested fixes:
   - Check for typos in function name`, toolName, joinedTools)
 }
 
-// handleFunctionCalls calls the functions and returns the function response event.
+// handleFunctionCalls runs the tools called in resp and returns the event
+// with their responses.
 //
 // TODO: accept filters to include/exclude function calls.
 // TODO: check feasibility of running tool.Run concurrently.
 func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[string]tool.Tool, resp *model.LLMResponse, toolConfirmations map[string]*toolconfirmation.ToolConfirmation) (*session.Event, error) {
 	var fnResponseEvents []*session.Event
 
-	fnCalls := utils.FunctionCalls(resp.Content)
+	fnCalls := orderFunctionCalls(utils.FunctionCalls(resp.Content), f.ToolDependencies)
 	toolNames := slices.Collect(maps.Keys(toolsDict))
+	var events session.Events
+	if ctx.Session() != nil {
+		events = ctx.Session().Events()
+	}
 	var result map[string]any
 	for _, fnCall := range fnCalls {
 		var confirmation *toolconfirmation.ToolConfirmation
 		if toolConfirmations != nil {
 			confirmation = toolConfirmations[fnCall.ID]
 		}
-		toolCtx := toolinternal.NewToolContext(ctx, fnCall.ID, &session.EventActions{StateDelta: make(map[string]any)}, confirmation)
+		callCtx, release, err := callgate.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		toolCtx := toolinternal.NewCallToolContext(ctx.WithContext(callCtx), fnCall, &session.EventActions{StateDelta: make(map[string]any)}, confirmation)
 
 		spans := telemetry.StartTrace(ctx, "execute_tool "+fnCall.Name)
 		curTool, found := toolsDict[fnCall.Name]
@@ -514,6 +642,7 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 			}
 		} else {
 			result = f.callTool(toolCtx, funcTool, fnCall.Args)
+			result = f.summarizeLargeResult(toolCtx, fnCall, result)
 		}
 
 		// TODO: handle long-running tool.
@@ -522,16 +651,11 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 			Content: &genai.Content{
 				Role: "user",
 				Parts: []*genai.Part{
-					{
-						FunctionResponse: &genai.FunctionResponse{
-							ID:       fnCall.ID,
-							Name:     fnCall.Name,
-							Response: result,
-						},
-					},
+					{FunctionResponse: buildFunctionResponse(toolCtx, events, fnCall, result)},
 				},
 			},
 		}
+		release()
 		ev.Author = ctx.Agent().Name()
 		ev.Branch = ctx.Branch()
 		ev.Actions = *toolCtx.Actions()
@@ -607,6 +731,9 @@ func (f *Flow) callTool(toolCtx tool.Context, tool toolinternal.FunctionTool, fA
 	}
 
 	if err != nil {
+		if formatter, ok := tool.(toolinternal.ErrorFormatter); ok {
+			return map[string]any{"error": formatter.FormatError(err)}
+		}
 		return map[string]any{"error": err.Error()}
 	}
 	return response
@@ -659,6 +786,11 @@ func (f *Flow) invokeOnToolErrorCallbacks(toolCtx tool.Context, tool tool.Tool,
 	return nil, fErr
 }
 
+// mergeParallelFunctionResponseEvents merges the responses of the function
+// calls of one turn into a single event. Each tool call accumulates its own
+// state delta, and the deltas are merged in the order of the calls: when
+// several calls write the same key, the last one wins and the conflict is
+// logged.
 func mergeParallelFunctionResponseEvents(events []*session.Event) (*session.Event, error) {
 	switch len(events) {
 	case 0:
@@ -668,11 +800,18 @@ func mergeParallelFunctionResponseEvents(events []*session.Event) (*session.Even
 	}
 	var parts []*genai.Part
 	var actions *session.EventActions
+	writers := map[string]string{}
 	for _, ev := range events {
 		if ev == nil || ev.LLMResponse.Content == nil {
 			continue
 		}
 		parts = append(parts, ev.LLMResponse.Content.Parts...)
+		if actions != nil {
+			logStateDeltaConflicts(writers, actions.StateDelta, ev)
+		}
+		for key := range ev.Actions.StateDelta {
+			writers[key] = functionResponseName(ev)
+		}
 		actions = mergeEventActions(actions, &ev.Actions)
 	}
 	// reuse events[0]
@@ -687,6 +826,34 @@ func mergeParallelFunctionResponseEvents(events []*session.Event) (*session.Even
 	return ev, nil
 }
 
+// logStateDeltaConflicts logs the keys of the state delta of the event
+// already set to a different value by the earlier function calls, whose
+// names are in writers.
+func logStateDeltaConflicts(writers map[string]string, merged map[string]any, ev *session.Event) {
+	for key, value := range ev.Actions.StateDelta {
+		prev, ok := merged[key]
+		if !ok || reflect.DeepEqual(prev, value) {
+			continue
+		}
+		_, prevIsMap := prev.(map[string]any)
+		_, isMap := value.(map[string]any)
+		if prevIsMap && isMap {
+			// Nested maps are merged.
+			continue
+		}
+		log.Printf("State key %q is set by parallel calls to tools %q and %q, keeping the last value.", key, writers[key], functionResponseName(ev))
+	}
+}
+
+func functionResponseName(ev *session.Event) string {
+	for _, p := range ev.LLMResponse.Content.Parts {
+		if p.FunctionResponse != nil {
+			return p.FunctionResponse.Name
+		}
+	}
+	return ""
+}
+
 func mergeEventActions(base, other *session.EventActions) *session.EventActions {
 	// flows/llm_flows/functions.py merge_parallel_function_response_events
 	if other == nil {
@@ -708,6 +875,12 @@ func mergeEventActions(base, other *session.EventActions) *session.EventActions
 		base.StateDelta = deepMergeMap(base.StateDelta, other.StateDelta)
 	}
 	// TODO add similar logic for state
+	if other.InstructionUpdates != nil {
+		if base.InstructionUpdates == nil {
+			base.InstructionUpdates = make(map[string]string)
+		}
+		maps.Copy(base.InstructionUpdates, other.InstructionUpdates)
+	}
 	if other.RequestedToolConfirmations != nil {
 		if base.RequestedToolConfirmations == nil {
 			base.RequestedToolConfirmations = make(map[string]toolconfirmation.ToolConfirmation)
@@ -749,3 +922,38 @@ type pluginManager interface {
 	RunAfterToolCallback(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error)
 	RunOnToolErrorCallback(ctx tool.Context, t tool.Tool, args map[string]any, err error) (map[string]any, error)
 }
+
+// attachCallRationale records the text preceding the function calls of ev
+// in its custom metadata, for Event.CallRationale, when the model streamed
+// it in an event of its own. It returns the text to attach to the next
+// calls: the one of ev if it is a complete text response.
+func attachCallRationale(ev *session.Event, rationale string) string {
+	if ev.Partial {
+		return rationale
+	}
+	if len(utils.FunctionCalls(ev.Content)) == 0 {
+		return strings.Join(utils.TextParts(ev.Content), "")
+	}
+	if rationale = strings.TrimSpace(rationale); rationale != "" {
+		ev.CustomMetadata = maps.Clone(ev.CustomMetadata)
+		if ev.CustomMetadata == nil {
+			ev.CustomMetadata = make(map[string]any)
+		}
+		ev.CustomMetadata[session.CallRationaleKey] = rationale
+	}
+	return ""
+}
+
+// statusEvent returns an event reporting that the agent entered the given
+// phase, or nil if status events are not enabled in the run config.
+func statusEvent(ctx agent.InvocationContext, status session.AgentStatus) *session.Event {
+	if cfg := ctx.RunConfig(); cfg == nil || !cfg.StatusEvents {
+		return nil
+	}
+	ev := session.NewEvent(ctx.InvocationID())
+	ev.Author = ctx.Agent().Name()
+	ev.Branch = ctx.Branch()
+	ev.Kind = session.EventKindStatus
+	ev.Status = status
+	return ev
+}
@@ -16,12 +16,15 @@ package llminternal
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genai"
 
 	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/sessioninternal"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -575,3 +578,69 @@ func TestMergeEventActions(t *testing.T) {
 		})
 	}
 }
+
+func TestConcurrentToolStateDeltas(t *testing.T) {
+	sessionService := session.InMemoryService()
+	created, err := sessionService.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Session: sessioninternal.NewMutableSession(sessionService, created.Session),
+	})
+
+	// Two tools of the same turn run concurrently, each writing from
+	// several goroutines to an overlapping key and to a key of its own. Run
+	// with -race to check the state accesses.
+	names := []string{"first", "second"}
+	events := make([]*session.Event, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		toolCtx := toolinternal.NewToolContext(ctx, fmt.Sprintf("call-%d", i), nil, nil)
+		for j := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, key := range []string{"shared", name} {
+					if err := toolCtx.State().Set(key, name); err != nil {
+						t.Error(err)
+					}
+					if _, err := toolCtx.State().Get(key); err != nil {
+						t.Error(err)
+					}
+				}
+				if err := toolCtx.State().Set(fmt.Sprintf("%s_%d", name, j), j); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		ev := session.NewEvent(ctx.InvocationID())
+		ev.LLMResponse = model.LLMResponse{Content: &genai.Content{
+			Role:  "user",
+			Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{ID: toolCtx.FunctionCallID(), Name: name}}},
+		}}
+		ev.Actions = *toolCtx.Actions()
+		events[i] = ev
+	}
+	wg.Wait()
+
+	merged, err := mergeParallelFunctionResponseEvents(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta := merged.Actions.StateDelta
+	// The deltas are merged in the order of the calls.
+	if got := delta["shared"]; got != "second" {
+		t.Errorf("merged delta[shared] = %v, want the value of the last call", got)
+	}
+	for _, name := range names {
+		if got := delta[name]; got != name {
+			t.Errorf("merged delta[%s] = %v, want %q", name, got, name)
+		}
+		for j := range 10 {
+			if got := delta[fmt.Sprintf("%s_%d", name, j)]; got != j {
+				t.Errorf("merged delta[%s_%d] = %v, want %d", name, j, got, j)
+			}
+		}
+	}
+}
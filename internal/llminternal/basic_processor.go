@@ -50,11 +50,40 @@ func basicRequestProcessor(ctx agent.InvocationContext, req *model.LLMRequest, f
 			req.Config.ResponseMIMEType = "application/json"
 		}
 
+		// The parameters of the invocation take precedence over the
+		// agent's, which take precedence over the model's defaults.
+		if cfg := ctx.RunConfig(); cfg != nil && cfg.GenerateContentConfig != nil {
+			overrideGenerateContentConfig(req.Config, cfg.GenerateContentConfig)
+		}
+		if cfg := ctx.RunConfig(); cfg != nil && cfg.StreamThoughts {
+			if req.Config.ThinkingConfig == nil {
+				req.Config.ThinkingConfig = &genai.ThinkingConfig{}
+			}
+			req.Config.ThinkingConfig.IncludeThoughts = true
+		}
+
 		// TODO: missing features
 		//  populate LLMRequest LiveConnectConfig setting
 	}
 }
 
+// overrideGenerateContentConfig sets the fields of dst that are set in
+// override, except the system instruction and the tools, which come from the
+// agent.
+func overrideGenerateContentConfig(dst, override *genai.GenerateContentConfig) {
+	src := reflect.ValueOf(clone(override)).Elem()
+	dstVal := reflect.ValueOf(dst).Elem()
+	for i := range src.NumField() {
+		switch src.Type().Field(i).Name {
+		case "SystemInstruction", "Tools":
+			continue
+		}
+		if f := src.Field(i); !f.IsZero() {
+			dstVal.Field(i).Set(f)
+		}
+	}
+}
+
 // clone returns a deep copy of the src.
 // NOTE: this does not work for types with unexported fields.
 func clone[M any](src M) M {
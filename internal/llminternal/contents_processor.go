@@ -57,6 +57,11 @@ func ContentsRequestProcessor(ctx agent.InvocationContext, req *model.LLMRequest
 			yield(nil, err)
 			return
 		}
+		if cfg := ctx.RunConfig(); cfg != nil && len(cfg.EphemeralContents) > 0 {
+			// Ephemeral contents are not part of the session events, so
+			// they are only ever seen by the requests of this invocation.
+			contents = slices.Insert(contents, currentTurnStart(contents), cfg.EphemeralContents...)
+		}
 		req.Contents = append(req.Contents, contents...)
 	}
 }
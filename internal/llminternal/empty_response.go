@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+)
+
+// ErrEmptyResponse is returned when the model returns a response without
+// content and the agent is configured with EmptyResponseError.
+var ErrEmptyResponse = errors.New("model returned an empty response")
+
+// EmptyResponseErrorCode is the error code of the event reporting an empty
+// model response, when the response has no error code of its own.
+const EmptyResponseErrorCode = "EMPTY_RESPONSE"
+
+// EmptyResponsePolicy defines how the flow handles model responses without
+// content.
+type EmptyResponsePolicy string
+
+const (
+	// EmptyResponseIgnore keeps the default behavior: responses without
+	// content and error code are skipped, the others are yielded as is.
+	EmptyResponseIgnore EmptyResponsePolicy = ""
+	// EmptyResponseRetry calls the model again, up to the configured number of
+	// retries, and then reports the empty response as with EmptyResponseEvent.
+	EmptyResponseRetry EmptyResponsePolicy = "retry"
+	// EmptyResponseEvent yields an event with an error code and a message
+	// including the finish reason.
+	EmptyResponseEvent EmptyResponsePolicy = "event"
+	// EmptyResponseError fails the invocation with ErrEmptyResponse.
+	EmptyResponseError EmptyResponsePolicy = "error"
+)
+
+// callLLMHandlingEmptyResponses calls the model like callLLM, and applies the
+// flow's EmptyResponsePolicy to the final responses without content.
+func (f *Flow) callLLMHandlingEmptyResponses(ctx agent.InvocationContext, req *model.LLMRequest, stateDelta map[string]any) iter.Seq2[*model.LLMResponse, error] {
+	if f.EmptyResponsePolicy == EmptyResponseIgnore {
+		return f.callLLM(ctx, req, stateDelta)
+	}
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for attempt := 0; ; attempt++ {
+			retry := false
+			for resp, err := range f.callLLM(ctx, req, stateDelta) {
+				if err != nil || !isEmptyResponse(resp) {
+					if !yield(resp, err) {
+						return
+					}
+					continue
+				}
+				switch {
+				case f.EmptyResponsePolicy == EmptyResponseRetry && attempt < f.EmptyResponseMaxRetries:
+					retry = true
+				case f.EmptyResponsePolicy == EmptyResponseError:
+					yield(nil, fmt.Errorf("agent %q: %w (%s)", ctx.Agent().Name(), ErrEmptyResponse, emptyResponseDiagnostic(resp)))
+					return
+				default:
+					if !yield(emptyResponseEvent(resp), nil) {
+						return
+					}
+				}
+				if retry {
+					break
+				}
+			}
+			if !retry {
+				return
+			}
+		}
+	}
+}
+
+// isEmptyResponse reports whether resp is a final response without any
+// content, e.g. because the candidate was empty or blocked.
+func isEmptyResponse(resp *model.LLMResponse) bool {
+	if resp == nil {
+		return true
+	}
+	if resp.Partial || resp.Interrupted {
+		return false
+	}
+	if resp.Content == nil {
+		return true
+	}
+	for _, p := range resp.Content.Parts {
+		if p == nil {
+			continue
+		}
+		if p.Text != "" || p.FunctionCall != nil || p.FunctionResponse != nil || p.InlineData != nil ||
+			p.FileData != nil || p.ExecutableCode != nil || p.CodeExecutionResult != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func emptyResponseDiagnostic(resp *model.LLMResponse) string {
+	if resp == nil {
+		return "no response"
+	}
+	reason := string(resp.FinishReason)
+	if reason == "" {
+		reason = resp.ErrorCode
+	}
+	if reason == "" {
+		reason = "unspecified"
+	}
+	msg := "finish reason: " + reason
+	if resp.ErrorMessage != "" {
+		msg += ", " + resp.ErrorMessage
+	}
+	return msg
+}
+
+func emptyResponseEvent(resp *model.LLMResponse) *model.LLMResponse {
+	ev := &model.LLMResponse{
+		ErrorCode:    EmptyResponseErrorCode,
+		ErrorMessage: fmt.Sprintf("%v (%s)", ErrEmptyResponse, emptyResponseDiagnostic(resp)),
+	}
+	if resp != nil {
+		ev.FinishReason = resp.FinishReason
+		ev.UsageMetadata = resp.UsageMetadata
+		if resp.ErrorCode != "" {
+			ev.ErrorCode = resp.ErrorCode
+		}
+	}
+	return ev
+}
@@ -15,14 +15,17 @@
 package llminternal
 
 import (
+	"maps"
 	"time"
 
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/toolconfirmation"
 )
 
@@ -35,6 +38,7 @@ func generateRequestConfirmationEvent(
 	invocationContext agent.InvocationContext,
 	functionCallEvent *session.Event,
 	functionResponseEvent *session.Event,
+	tools map[string]tool.Tool,
 ) *session.Event {
 	if functionResponseEvent == nil || len(functionResponseEvent.Actions.RequestedToolConfirmations) == 0 {
 		return nil
@@ -56,6 +60,8 @@ func generateRequestConfirmationEvent(
 			continue
 		}
 
+		originalFunctionCall = withSchemaVersion(originalFunctionCall, tools[originalFunctionCall.Name])
+
 		// Prepare arguments for the adk_request_confirmation call
 		args := map[string]any{
 			"originalFunctionCall": originalFunctionCall,
@@ -95,3 +101,20 @@ func generateRequestConfirmationEvent(
 		Actions:            session.EventActions{},
 	}
 }
+
+// withSchemaVersion returns a copy of call with the input schema version of
+// t recorded in its arguments, so that the call can be migrated if it is run
+// after the schema of the tool has changed.
+func withSchemaVersion(call *genai.FunctionCall, t tool.Tool) *genai.FunctionCall {
+	versioned, ok := t.(toolinternal.SchemaVersioned)
+	if !ok || versioned.SchemaVersion() == 0 {
+		return call
+	}
+	copied := *call
+	copied.Args = maps.Clone(call.Args)
+	if copied.Args == nil {
+		copied.Args = make(map[string]any)
+	}
+	copied.Args[toolinternal.SchemaVersionKey] = versioned.SchemaVersion()
+	return &copied
+}
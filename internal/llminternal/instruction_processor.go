@@ -17,9 +17,11 @@ package llminternal
 import (
 	"fmt"
 	"iter"
+	"maps"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
 
 	"google.golang.org/adk/agent"
@@ -63,7 +65,42 @@ func instructionsRequestProcessor(ctx agent.InvocationContext, req *model.LLMReq
 			yield(nil, fmt.Errorf("failed to append instructions: %w", err))
 			return
 		}
+
+		// The request is built for each model call, so the time stays
+		// current during long invocations.
+		if now := llmAgent.internal().CurrentTime; now != nil {
+			utils.AppendInstructions(req, currentTimeInstruction(now()))
+		}
+
+		utils.AppendInstructions(req, instructionUpdates(ctx)...)
+	}
+}
+
+// instructionUpdates returns the sections of the instruction set by the
+// tools of the agent in the current invocation, sorted by key.
+func instructionUpdates(ctx agent.InvocationContext) []string {
+	sections := make(map[string]string)
+	for ev := range ctx.Session().Events().All() {
+		if ev.InvocationID != ctx.InvocationID() || ev.Author != ctx.Agent().Name() {
+			continue
+		}
+		for key, text := range ev.Actions.InstructionUpdates {
+			if text == "" {
+				delete(sections, key)
+				continue
+			}
+			sections[key] = text
+		}
 	}
+	var texts []string
+	for _, key := range slices.Sorted(maps.Keys(sections)) {
+		texts = append(texts, sections[key])
+	}
+	return texts
+}
+
+func currentTimeInstruction(t time.Time) string {
+	return "The current date and time is " + t.Format("Monday, January 2, 2006 15:04 MST (UTC-07:00)") + "."
 }
 
 // The regex to find placeholders like {variable} or {artifact.file_name}.
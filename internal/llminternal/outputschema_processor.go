@@ -85,6 +85,11 @@ func retrieveStructuredModelResponse(ev *session.Event) (string, error) {
 
 	for _, part := range ev.LLMResponse.Content.Parts {
 		if part.FunctionResponse != nil && part.FunctionResponse.Name == "set_model_response" {
+			if isErrorResponse(part.FunctionResponse.Response) {
+				// The response does not match the schema, the model gets
+				// another turn to fix it.
+				continue
+			}
 			bytes, err := json.Marshal(part.FunctionResponse.Response)
 			if err != nil {
 				return "", fmt.Errorf("failed to marshal set_model_response: %w", err)
@@ -96,6 +101,16 @@ func retrieveStructuredModelResponse(ev *session.Event) (string, error) {
 	return "", nil
 }
 
+// isErrorResponse reports whether response is the error of a failed tool
+// call rather than a result.
+func isErrorResponse(response map[string]any) bool {
+	if len(response) != 1 {
+		return false
+	}
+	_, ok := response["error"].(string)
+	return ok
+}
+
 func needOutputSchemaProcessor(state *State) bool {
 	if state == nil || state.Model == nil {
 		return false
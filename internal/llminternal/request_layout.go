@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// RequestSection is a component of the model request, placed by a
+// request layout.
+type RequestSection string
+
+const (
+	// RequestSectionSystemInstruction is the global and agent instructions.
+	RequestSectionSystemInstruction RequestSection = "system_instruction"
+	// RequestSectionToolContext is the instructions added by tools.
+	RequestSectionToolContext RequestSection = "tool_context"
+	// RequestSectionHistory is the conversation before the current turn.
+	RequestSectionHistory RequestSection = "history"
+	// RequestSectionCurrentTurn is the last user message and the model
+	// and tool contents following it.
+	RequestSectionCurrentTurn RequestSection = "current_turn"
+)
+
+// ValidateRequestLayout checks that the layout places the history and the
+// current turn, and places each section at most once.
+func ValidateRequestLayout(layout []RequestSection) error {
+	if len(layout) == 0 {
+		return nil
+	}
+	seen := map[RequestSection]bool{}
+	for _, s := range layout {
+		switch s {
+		case RequestSectionSystemInstruction, RequestSectionToolContext, RequestSectionHistory, RequestSectionCurrentTurn:
+		default:
+			return fmt.Errorf("unknown request section %q", s)
+		}
+		if seen[s] {
+			return fmt.Errorf("request section %q is placed more than once", s)
+		}
+		seen[s] = true
+	}
+	for _, s := range []RequestSection{RequestSectionHistory, RequestSectionCurrentTurn} {
+		if !seen[s] {
+			return fmt.Errorf("request layout must place the %q section", s)
+		}
+	}
+	return nil
+}
+
+// applyRequestLayout reorders the contents of req according to
+// f.RequestLayout. The instruction sections placed by the layout are moved
+// from the system instruction to user contents; the others stay in the
+// system instruction. toolContextStart is the index of the first part of the
+// system instruction added by the tools.
+func (f *Flow) applyRequestLayout(req *model.LLMRequest, toolContextStart int) {
+	if len(f.RequestLayout) == 0 {
+		return
+	}
+	var instructionParts, toolContextParts []*genai.Part
+	role := genai.RoleUser
+	if req.Config != nil && req.Config.SystemInstruction != nil {
+		parts := req.Config.SystemInstruction.Parts
+		toolContextStart = min(toolContextStart, len(parts))
+		instructionParts, toolContextParts = parts[:toolContextStart], parts[toolContextStart:]
+		if req.Config.SystemInstruction.Role != "" {
+			role = req.Config.SystemInstruction.Role
+		}
+	}
+
+	start := currentTurnStart(req.Contents)
+	sections := map[RequestSection][]*genai.Content{
+		RequestSectionHistory:     req.Contents[:start],
+		RequestSectionCurrentTurn: req.Contents[start:],
+	}
+	for s, parts := range map[RequestSection][]*genai.Part{
+		RequestSectionSystemInstruction: instructionParts,
+		RequestSectionToolContext:       toolContextParts,
+	} {
+		if !slices.Contains(f.RequestLayout, s) {
+			continue
+		}
+		if len(parts) > 0 {
+			sections[s] = []*genai.Content{{Role: genai.RoleUser, Parts: parts}}
+		}
+	}
+	// Keep the unplaced instructions in the system instruction, in their
+	// original order.
+	var systemParts []*genai.Part
+	if !slices.Contains(f.RequestLayout, RequestSectionSystemInstruction) {
+		systemParts = append(systemParts, instructionParts...)
+	}
+	if !slices.Contains(f.RequestLayout, RequestSectionToolContext) {
+		systemParts = append(systemParts, toolContextParts...)
+	}
+
+	var contents []*genai.Content
+	for _, s := range f.RequestLayout {
+		contents = append(contents, sections[s]...)
+	}
+	req.Contents = contents
+	if req.Config != nil && req.Config.SystemInstruction != nil {
+		if len(systemParts) == 0 {
+			req.Config.SystemInstruction = nil
+		} else {
+			req.Config.SystemInstruction = &genai.Content{Role: role, Parts: systemParts}
+		}
+	}
+}
+
+// currentTurnStart returns the index of the last user message in contents,
+// i.e. the last user content that is not only function responses.
+func currentTurnStart(contents []*genai.Content) int {
+	for i := len(contents) - 1; i >= 0; i-- {
+		c := contents[i]
+		if c == nil || c.Role != genai.RoleUser {
+			continue
+		}
+		for _, p := range c.Parts {
+			if p.FunctionResponse == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
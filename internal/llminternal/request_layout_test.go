@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestApplyRequestLayout(t *testing.T) {
+	earlier := genai.NewContentFromText("hi", genai.RoleUser)
+	greeting := genai.NewContentFromText("hello", genai.RoleModel)
+	question := genai.NewContentFromText("weather?", genai.RoleUser)
+	call := genai.NewContentFromFunctionCall("weather", nil, genai.RoleModel)
+	result := genai.NewContentFromFunctionResponse("weather", map[string]any{"sky": "clear"}, genai.RoleUser)
+	instruction := genai.NewPartFromText("Be brief.")
+	toolContext := genai.NewPartFromText("Artifacts: a.txt")
+
+	newRequest := func() *model.LLMRequest {
+		return &model.LLMRequest{
+			Contents: []*genai.Content{earlier, greeting, question, call, result},
+			Config: &genai.GenerateContentConfig{
+				SystemInstruction: &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{instruction, toolContext}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		layout     []RequestSection
+		wantSystem *genai.Content
+		want       []*genai.Content
+	}{
+		{
+			name:       "default",
+			wantSystem: &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{instruction, toolContext}},
+			want:       []*genai.Content{earlier, greeting, question, call, result},
+		},
+		{
+			name:       "instructions before the current turn",
+			layout:     []RequestSection{RequestSectionHistory, RequestSectionSystemInstruction, RequestSectionCurrentTurn},
+			wantSystem: &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{toolContext}},
+			want: []*genai.Content{
+				earlier, greeting,
+				{Role: genai.RoleUser, Parts: []*genai.Part{instruction}},
+				question, call, result,
+			},
+		},
+		{
+			name:   "all sections in contents",
+			layout: []RequestSection{RequestSectionToolContext, RequestSectionSystemInstruction, RequestSectionHistory, RequestSectionCurrentTurn},
+			want: []*genai.Content{
+				{Role: genai.RoleUser, Parts: []*genai.Part{toolContext}},
+				{Role: genai.RoleUser, Parts: []*genai.Part{instruction}},
+				earlier, greeting, question, call, result,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest()
+			f := &Flow{RequestLayout: tt.layout}
+			f.applyRequestLayout(req, 1)
+			if diff := cmp.Diff(tt.wantSystem, req.Config.SystemInstruction); diff != "" {
+				t.Errorf("system instruction mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.want, req.Contents); diff != "" {
+				t.Errorf("contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequestLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  []RequestSection
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "valid", layout: []RequestSection{RequestSectionSystemInstruction, RequestSectionHistory, RequestSectionCurrentTurn}},
+		{name: "missing current turn", layout: []RequestSection{RequestSectionHistory}, wantErr: true},
+		{name: "duplicate", layout: []RequestSection{RequestSectionHistory, RequestSectionCurrentTurn, RequestSectionHistory}, wantErr: true},
+		{name: "unknown", layout: []RequestSection{RequestSectionHistory, RequestSectionCurrentTurn, "examples"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRequestLayout(tt.layout); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequestLayout() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ type streamingResponseAggregator struct {
 	thoughtText string
 	response    *model.LLMResponse
 	role        string
+	call        *streamedCall
 }
 
 // NewStreamingResponseAggregator creates a new, initialized streamingResponseAggregator.
@@ -53,15 +54,37 @@ func (s *streamingResponseAggregator) ProcessResponse(ctx context.Context, genRe
 		candidate := genResp.Candidates[0]
 		resp := converters.Genai2LLMResponse(genResp)
 		resp.TurnComplete = candidate.FinishReason != ""
+		handled, complete, err := s.aggregateFunctionCall(resp)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if handled {
+			if !yield(resp, nil) {
+				return
+			}
+			if complete != nil {
+				yield(complete, nil)
+			}
+			return
+		}
+		if complete != nil {
+			if !yield(complete, nil) {
+				return
+			}
+		}
 		// Aggregate the response and check if an intermediate event to yield was created
 		if aggrResp := s.aggregateResponse(resp); aggrResp != nil {
 			if !yield(aggrResp, nil) {
 				return // Consumer stopped
 			}
 		}
-		// Yield the processed response
-		if !yield(resp, nil) {
-			return // Consumer stopped
+		// Yield the processed response, the thoughts of a partial response
+		// apart from its text.
+		for _, r := range splitThoughts(resp) {
+			if !yield(r, nil) {
+				return // Consumer stopped
+			}
 		}
 	}
 }
@@ -77,7 +100,22 @@ func (s *streamingResponseAggregator) aggregateResponse(llmResponse *model.LLMRe
 		s.role = llmResponse.Content.Role
 	}
 
-	// If part is text append it
+	// If the parts are text append the first thought and the first answer
+	// text, the chunks of thinking models mixing both.
+	if textOnly(llmResponse.Content) {
+		var gotThought, gotText bool
+		for _, part := range llmResponse.Content.Parts {
+			if part.Thought && !gotThought {
+				gotThought = true
+				s.thoughtText += part.Text
+			} else if !part.Thought && !gotText {
+				gotText = true
+				s.text += part.Text
+			}
+		}
+		llmResponse.Partial = true
+		return nil
+	}
 	if part0 != nil && part0.Text != "" {
 		if part0.Thought {
 			s.thoughtText += part0.Text
@@ -109,6 +147,14 @@ func (s *streamingResponseAggregator) aggregateResponse(llmResponse *model.LLMRe
 // Close generates an aggregated response at the end, if needed,
 // this should be called after all the model responses are processed.
 func (s *streamingResponseAggregator) Close() *model.LLMResponse {
+	if s.call != nil {
+		// The stream ended without closing the call.
+		last := s.response
+		if last == nil {
+			last = &model.LLMResponse{}
+		}
+		return s.completeFunctionCall(last)
+	}
 	return s.createAggregateResponse()
 }
 
@@ -143,3 +189,40 @@ func (s *streamingResponseAggregator) clear() {
 	s.thoughtText = ""
 	s.role = ""
 }
+
+// textOnly reports whether c has parts, all of them text.
+func textOnly(c *genai.Content) bool {
+	if c == nil || len(c.Parts) == 0 {
+		return false
+	}
+	for _, part := range c.Parts {
+		if part == nil || part.Text == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitThoughts returns resp, split in a response with its thoughts and a
+// response with its other parts if it is a partial response mixing both,
+// so that the thoughts are streamed as events of their own.
+func splitThoughts(resp *model.LLMResponse) []*model.LLMResponse {
+	if !resp.Partial || resp.Content == nil {
+		return []*model.LLMResponse{resp}
+	}
+	var thoughts, others []*genai.Part
+	for _, part := range resp.Content.Parts {
+		if part != nil && part.Thought {
+			thoughts = append(thoughts, part)
+		} else {
+			others = append(others, part)
+		}
+	}
+	if len(thoughts) == 0 || len(others) == 0 {
+		return []*model.LLMResponse{resp}
+	}
+	thinking, answer := *resp, *resp
+	thinking.Content = &genai.Content{Role: resp.Content.Role, Parts: thoughts}
+	answer.Content = &genai.Content{Role: resp.Content.Role, Parts: others}
+	return []*model.LLMResponse{&thinking, &answer}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/internal/llminternal"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/model"
 )
@@ -167,6 +168,22 @@ func TestStreamAggregator(t *testing.T) {
 				false, false, false,
 			},
 		},
+		{
+			name: "thoughts streamed apart from the text",
+			initialResponses: []*genai.Content{
+				genai.NewContentFromParts([]*genai.Part{{Text: "thinking", Thought: true}, {Text: "response1"}}, "model"),
+				genai.NewContentFromText("response2", "model"),
+			},
+			numberOfStreamCalls:  1,
+			streamResponsesCount: 2,
+			want: []*genai.Content{
+				genai.NewContentFromParts([]*genai.Part{{Text: "thinking", Thought: true}}, "model"),
+				genai.NewContentFromText("response1", "model"),
+				genai.NewContentFromText("response2", "model"),
+				genai.NewContentFromParts([]*genai.Part{{Text: "thinking", Thought: true}, {Text: "response1response2"}}, "model"),
+			},
+			wantPartial: []bool{true, true, true, false},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -205,3 +222,82 @@ func TestStreamAggregator(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamAggregator_StreamedFunctionCall(t *testing.T) {
+	continues := genai.Ptr(true)
+	limit := 3.0
+	chunks := []*genai.Content{
+		{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "search", WillContinue: continues}}}},
+		{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+			PartialArgs:  []*genai.PartialArg{{JsonPath: "$.query", StringValue: "wea", WillContinue: continues}},
+			WillContinue: continues,
+		}}}},
+		{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+			PartialArgs: []*genai.PartialArg{
+				{JsonPath: "$.query", StringValue: "ther"},
+				{JsonPath: "$.limit", NumberValue: &limit},
+				{JsonPath: "$.filters['lang']", StringValue: "en"},
+				{JsonPath: "$.tags[1]", StringValue: "b"},
+			},
+			WillContinue: continues,
+		}}}},
+		{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{}}}},
+	}
+
+	aggregator := llminternal.NewStreamingResponseAggregator()
+	var got []*model.LLMResponse
+	for _, chunk := range chunks {
+		resp := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: chunk}}}
+		for r, err := range aggregator.ProcessResponse(t.Context(), resp) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, r)
+		}
+	}
+	if r := aggregator.Close(); r != nil {
+		t.Errorf("Close() = %v, want nil", r)
+	}
+
+	if len(got) != 5 {
+		t.Fatalf("got %d responses, want 4 partial and 1 complete", len(got))
+	}
+	id := got[0].Content.Parts[0].FunctionCall.ID
+	if id == "" {
+		t.Error("streamed call has no ID")
+	}
+	wantArgs := []map[string]any{
+		{},
+		{"query": "wea"},
+		{"query": "weather", "limit": 3.0, "filters": map[string]any{"lang": "en"}, "tags": []any{nil, "b"}},
+		{"query": "weather", "limit": 3.0, "filters": map[string]any{"lang": "en"}, "tags": []any{nil, "b"}},
+		{"query": "weather", "limit": 3.0, "filters": map[string]any{"lang": "en"}, "tags": []any{nil, "b"}},
+	}
+	for i, r := range got {
+		fc := r.Content.Parts[0].FunctionCall
+		if wantPartial := i < 4; r.Partial != wantPartial {
+			t.Errorf("response %d: Partial = %v, want %v", i, r.Partial, wantPartial)
+		}
+		if fc.ID != id || fc.Name != "search" {
+			t.Errorf("response %d: got call %q (ID %q), want search (ID %q)", i, fc.Name, fc.ID, id)
+		}
+		if diff := cmp.Diff(wantArgs[i], fc.Args); diff != "" {
+			t.Errorf("response %d: args mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestStreamAggregator_StreamedFunctionCallInvalidPath(t *testing.T) {
+	chunk := &genai.Content{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+		Name:         "search",
+		PartialArgs:  []*genai.PartialArg{{JsonPath: "query", StringValue: "x"}},
+		WillContinue: genai.Ptr(true),
+	}}}}
+	aggregator := llminternal.NewStreamingResponseAggregator()
+	resp := &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: chunk}}}
+	for _, err := range aggregator.ProcessResponse(t.Context(), resp) {
+		if err == nil {
+			t.Fatal("ProcessResponse() succeeded, want an invalid JSON path error")
+		}
+	}
+}
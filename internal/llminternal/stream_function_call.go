@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// streamedCall is a function call whose arguments are streamed by the
+// model in several chunks, with FunctionCall.WillContinue set on all but
+// the last one.
+type streamedCall struct {
+	call *genai.FunctionCall
+	args map[string]any
+	// openStrings holds the string values, by JSON path, whose next piece
+	// is still to come.
+	openStrings map[string]string
+}
+
+// aggregateFunctionCall assembles the arguments of a streamed function
+// call. It reports whether resp is a chunk of a streamed call, in which case
+// resp is marked partial and carries the arguments received so far. Once
+// the call is complete, the response with the complete call is returned.
+func (s *streamingResponseAggregator) aggregateFunctionCall(resp *model.LLMResponse) (handled bool, complete *model.LLMResponse, err error) {
+	var fc *genai.FunctionCall
+	if resp.Content != nil && len(resp.Content.Parts) > 0 {
+		fc = resp.Content.Parts[0].FunctionCall
+	}
+	if s.call == nil {
+		if fc == nil || (!willContinue(fc.WillContinue) && len(fc.PartialArgs) == 0) {
+			return false, nil, nil
+		}
+		call := &genai.FunctionCall{ID: fc.ID, Name: fc.Name}
+		utils.PopulateClientFunctionCallID(&genai.Content{Parts: []*genai.Part{{FunctionCall: call}}})
+		s.call = &streamedCall{call: call, args: map[string]any{}, openStrings: map[string]string{}}
+		s.role = resp.Content.Role
+	}
+	if fc == nil {
+		// The model moved on without closing the call.
+		return false, s.completeFunctionCall(resp), nil
+	}
+
+	if fc.Name != "" {
+		s.call.call.Name = fc.Name
+	}
+	maps.Copy(s.call.args, fc.Args)
+	for _, arg := range fc.PartialArgs {
+		if err := s.call.apply(arg); err != nil {
+			s.call = nil
+			return true, nil, fmt.Errorf("invalid streamed arguments of function call %q: %w", fc.Name, err)
+		}
+	}
+	resp.Partial = true
+	resp.Content.Parts[0] = &genai.Part{FunctionCall: &genai.FunctionCall{
+		ID:           s.call.call.ID,
+		Name:         s.call.call.Name,
+		Args:         cloneValue(s.call.args).(map[string]any),
+		PartialArgs:  fc.PartialArgs,
+		WillContinue: fc.WillContinue,
+	}}
+	if willContinue(fc.WillContinue) {
+		return true, nil, nil
+	}
+	return true, s.completeFunctionCall(resp), nil
+}
+
+// completeFunctionCall returns the response with the complete streamed
+// call, preceded by the text aggregated before it.
+func (s *streamingResponseAggregator) completeFunctionCall(last *model.LLMResponse) *model.LLMResponse {
+	var parts []*genai.Part
+	if s.thoughtText != "" {
+		parts = append(parts, &genai.Part{Text: s.thoughtText, Thought: true})
+	}
+	if s.text != "" {
+		parts = append(parts, &genai.Part{Text: s.text})
+	}
+	call := s.call.call
+	call.Args = s.call.args
+	parts = append(parts, &genai.Part{FunctionCall: call})
+	complete := &model.LLMResponse{
+		Content:           &genai.Content{Role: s.role, Parts: parts},
+		ErrorCode:         last.ErrorCode,
+		ErrorMessage:      last.ErrorMessage,
+		UsageMetadata:     last.UsageMetadata,
+		GroundingMetadata: last.GroundingMetadata,
+		FinishReason:      last.FinishReason,
+		TurnComplete:      last.TurnComplete,
+	}
+	s.call = nil
+	s.clear()
+	return complete
+}
+
+func (c *streamedCall) apply(arg *genai.PartialArg) error {
+	path, err := parseJSONPath(arg.JsonPath)
+	if err != nil {
+		return err
+	}
+	var v any
+	switch {
+	case arg.NumberValue != nil:
+		v = *arg.NumberValue
+	case arg.BoolValue != nil:
+		v = *arg.BoolValue
+	case arg.NULLValue != "":
+		v = nil
+	default:
+		s := c.openStrings[arg.JsonPath] + arg.StringValue
+		if willContinue(arg.WillContinue) {
+			c.openStrings[arg.JsonPath] = s
+		} else {
+			delete(c.openStrings, arg.JsonPath)
+		}
+		v = s
+	}
+	if len(path) == 0 {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("arguments must be an object, got %T", v)
+		}
+		c.args = m
+		return nil
+	}
+	root, err := setPath(c.args, path, v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", arg.JsonPath, err)
+	}
+	c.args = root.(map[string]any)
+	return nil
+}
+
+func willContinue(b *bool) bool {
+	return b != nil && *b
+}
+
+// parseJSONPath parses the JSON paths of streamed arguments, made of
+// object keys and array indexes, e.g. "$.items[0].name" or "$['a b']".
+// Keys are returned as strings and indexes as ints.
+func parseJSONPath(p string) ([]any, error) {
+	rest, ok := strings.CutPrefix(p, "$")
+	if !ok {
+		return nil, fmt.Errorf("invalid JSON path %q", p)
+	}
+	var path []any
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", p)
+			}
+			path = append(path, rest[:end])
+			rest = rest[end:]
+		case strings.HasPrefix(rest, "['") || strings.HasPrefix(rest, `["`):
+			quote := rest[1:2]
+			end := strings.Index(rest[2:], quote+"]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", p)
+			}
+			path = append(path, rest[2:2+end])
+			rest = rest[2+end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", p)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid index in JSON path %q", p)
+			}
+			path = append(path, i)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q", p)
+		}
+	}
+	return path, nil
+}
+
+// setPath sets v at path in container, creating the intermediate objects
+// and arrays, and returns the updated container.
+func setPath(container any, path []any, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	switch elem := path[0].(type) {
+	case string:
+		if container == nil {
+			container = map[string]any{}
+		}
+		m, ok := container.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot set key %q of %T", elem, container)
+		}
+		child, err := setPath(m[elem], path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		m[elem] = child
+		return m, nil
+	default:
+		i := elem.(int)
+		if container == nil {
+			container = []any{}
+		}
+		s, ok := container.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot set index %d of %T", i, container)
+		}
+		for len(s) <= i {
+			s = append(s, nil)
+		}
+		child, err := setPath(s[i], path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		s[i] = child
+		return s, nil
+	}
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = cloneValue(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = cloneValue(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// receivePartialArgs passes the arguments received so far of the streamed
+// calls of resp to the tools implementing tool.PartialArgsReceiver.
+func receivePartialArgs(ctx context.Context, tools map[string]tool.Tool, resp *model.LLMResponse) {
+	for _, fc := range utils.FunctionCalls(resp.Content) {
+		if r, ok := tools[fc.Name].(tool.PartialArgsReceiver); ok {
+			r.ReceivePartialArgs(ctx, fc.ID, fc.Args)
+		}
+	}
+}
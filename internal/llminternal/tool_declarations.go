@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"reflect"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+)
+
+// applyToolDeclarationsPolicy removes the function declarations from req if
+// they were already sent to the model in this run of the flow, with
+// ToolDeclarationsOnFirstTurnOnly. The tools stay registered in req.Tools, so
+// that the function calls of the model can still be handled.
+func (f *Flow) applyToolDeclarationsPolicy(req *model.LLMRequest) {
+	if !f.ToolDeclarationsOnFirstTurnOnly || req.Config == nil || len(req.Config.Tools) == 0 {
+		return
+	}
+	if !f.toolDeclarationsSent || f.resendToolDeclarations {
+		f.toolDeclarationsSent = true
+		f.resendToolDeclarations = false
+		return
+	}
+	var tools []*genai.Tool
+	for _, t := range req.Config.Tools {
+		if t == nil {
+			continue
+		}
+		if len(t.FunctionDeclarations) == 0 {
+			tools = append(tools, t)
+			continue
+		}
+		// Keep the built-in tools, e.g. Google Search, that share the entry.
+		copied := *t
+		copied.FunctionDeclarations = nil
+		if !reflect.ValueOf(copied).IsZero() {
+			tools = append(tools, &copied)
+		}
+	}
+	req.Config.Tools = tools
+}
+
+// checkToolDeclarationsLost makes the next request include the function
+// declarations again if resp suggests that the model lost track of the
+// tools: it called a function that is not available or failed to produce a
+// valid function call.
+func (f *Flow) checkToolDeclarationsLost(req *model.LLMRequest, resp *model.LLMResponse) {
+	if !f.ToolDeclarationsOnFirstTurnOnly {
+		return
+	}
+	if resp.FinishReason == genai.FinishReasonMalformedFunctionCall {
+		f.resendToolDeclarations = true
+		return
+	}
+	for _, fnCall := range utils.FunctionCalls(resp.Content) {
+		if _, ok := req.Tools[fnCall.Name]; !ok {
+			f.resendToolDeclarations = true
+			return
+		}
+	}
+}
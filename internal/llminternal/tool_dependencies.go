@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// ValidateToolDependencies returns an error if the tool dependencies, keyed
+// by the name of the dependent tool, contain a cycle.
+func ValidateToolDependencies(deps map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			cycle := append(path[slices.Index(path, name):], name)
+			return fmt.Errorf("cyclic tool dependencies: %s", strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	// Visit in a deterministic order, so that the same cycle is reported.
+	for _, name := range slices.Sorted(maps.Keys(deps)) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderFunctionCalls returns the calls ordered so that each call runs after
+// the calls of the tools it depends on, directly or transitively. Calls
+// without ordering constraints keep the order of the model. deps must not
+// contain cycles.
+func orderFunctionCalls(calls []*genai.FunctionCall, deps map[string][]string) []*genai.FunctionCall {
+	if len(deps) == 0 || len(calls) < 2 {
+		return calls
+	}
+	// after[i] are the indices of the calls that must run before call i.
+	after := make([][]int, len(calls))
+	for i, call := range calls {
+		required := transitiveDependencies(call.Name, deps)
+		for j, other := range calls {
+			if i != j && required[other.Name] {
+				after[i] = append(after[i], j)
+			}
+		}
+	}
+
+	ordered := make([]*genai.FunctionCall, 0, len(calls))
+	done := make([]bool, len(calls))
+	for len(ordered) < len(calls) {
+		// Pick the first call, in model order, whose dependencies ran.
+		next := -1
+		for i := range calls {
+			if !done[i] && !slices.ContainsFunc(after[i], func(j int) bool { return !done[j] }) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Unreachable with validated dependencies.
+			return calls
+		}
+		done[next] = true
+		ordered = append(ordered, calls[next])
+	}
+	return ordered
+}
+
+func transitiveDependencies(name string, deps map[string][]string) map[string]bool {
+	required := make(map[string]bool)
+	stack := slices.Clone(deps[name])
+	for len(stack) > 0 {
+		dep := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if required[dep] {
+			continue
+		}
+		required[dep] = true
+		stack = append(stack, deps[dep]...)
+	}
+	return required
+}
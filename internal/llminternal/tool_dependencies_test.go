@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestValidateToolDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		deps      map[string][]string
+		wantCycle string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "acyclic",
+			deps: map[string][]string{
+				"query":        {"authenticate"},
+				"report":       {"query", "authenticate"},
+				"authenticate": {"connect"},
+			},
+		},
+		{
+			name:      "self dependency",
+			deps:      map[string][]string{"query": {"query"}},
+			wantCycle: "query -> query",
+		},
+		{
+			name: "cycle",
+			deps: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+			wantCycle: "a -> b -> c -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToolDependencies(tt.deps)
+			if tt.wantCycle == "" {
+				if err != nil {
+					t.Errorf("ValidateToolDependencies() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantCycle) {
+				t.Errorf("ValidateToolDependencies() = %v, want cycle %q", err, tt.wantCycle)
+			}
+		})
+	}
+}
+
+func TestOrderFunctionCalls(t *testing.T) {
+	deps := map[string][]string{
+		"query":        {"authenticate"},
+		"authenticate": {"connect"},
+	}
+	tests := []struct {
+		name  string
+		calls []string
+		want  []string
+	}{
+		{
+			name:  "already ordered",
+			calls: []string{"authenticate", "query"},
+			want:  []string{"authenticate", "query"},
+		},
+		{
+			name:  "reordered",
+			calls: []string{"query", "weather", "authenticate"},
+			want:  []string{"weather", "authenticate", "query"},
+		},
+		{
+			name:  "transitive",
+			calls: []string{"query", "connect"},
+			want:  []string{"connect", "query"},
+		},
+		{
+			name:  "independent calls keep model order",
+			calls: []string{"weather", "time", "query"},
+			want:  []string{"weather", "time", "query"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []*genai.FunctionCall
+			for _, name := range tt.calls {
+				calls = append(calls, &genai.FunctionCall{Name: name})
+			}
+			var got []string
+			for _, call := range orderFunctionCalls(calls, deps) {
+				got = append(got, call.Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("orderFunctionCalls() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
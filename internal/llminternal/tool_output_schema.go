@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+)
+
+// maxOutputSchemaDepth bounds the nesting of the described output schemas.
+const maxOutputSchemaDepth = 4
+
+// describeToolOutputSchemas appends to the system instruction of req a
+// description of the output schemas of the function declarations in req, if
+// the agent's DescribeToolOutputSchemas is set. The tools returning the same
+// structure are described together, and the tools without a structured
+// output are left out.
+func describeToolOutputSchemas(ctx agent.InvocationContext, req *model.LLMRequest) {
+	llmAgent, ok := ctx.Agent().(Agent)
+	if !ok || !Reveal(llmAgent).DescribeToolOutputSchemas || req.Config == nil {
+		return
+	}
+
+	var descriptions []string
+	toolNames := make(map[string][]string)
+	for _, t := range req.Config.Tools {
+		if t == nil {
+			continue
+		}
+		for _, decl := range t.FunctionDeclarations {
+			if decl == nil {
+				continue
+			}
+			desc := describeOutputSchema(decl)
+			if desc == "" {
+				continue
+			}
+			names, seen := toolNames[desc]
+			if !seen {
+				descriptions = append(descriptions, desc)
+			}
+			if !slices.Contains(names, decl.Name) {
+				toolNames[desc] = append(names, decl.Name)
+			}
+		}
+	}
+	if len(descriptions) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The tools return results with the following structure:")
+	for _, desc := range descriptions {
+		names := toolNames[desc]
+		verb := "returns"
+		if len(names) > 1 {
+			verb = "return"
+		}
+		fmt.Fprintf(&sb, "\n- %s %s %s", strings.Join(names, ", "), verb, desc)
+	}
+	utils.AppendInstructions(req, sb.String())
+}
+
+// describeOutputSchema returns a human-readable description of the output
+// schema of decl, or "" if it has no structured output.
+func describeOutputSchema(decl *genai.FunctionDeclaration) string {
+	var schema any
+	switch {
+	case decl.ResponseJsonSchema != nil:
+		schema = decl.ResponseJsonSchema
+	case decl.Response != nil:
+		schema = decl.Response
+	default:
+		return ""
+	}
+	// Both the JSON schemas and the genai schemas are described from their
+	// JSON encoding, which use the same keywords.
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return ""
+	}
+	if len(schemaMap(m, "properties")) == 0 && schemaMap(m, "items") == nil {
+		// An untyped result or a free-form object, nothing to describe.
+		return ""
+	}
+	var sb strings.Builder
+	writeSchema(&sb, m, 1)
+	return sb.String()
+}
+
+// writeSchema writes the description of schema, its properties indented
+// at the given depth.
+func writeSchema(sb *strings.Builder, schema map[string]any, depth int) {
+	typ := schemaType(schema)
+	switch {
+	case typ == "array":
+		sb.WriteString("a list")
+		if items := schemaMap(schema, "items"); items != nil {
+			sb.WriteString(" of ")
+			writeSchema(sb, items, depth)
+		}
+		return
+	case typ == "object" || len(schemaMap(schema, "properties")) > 0:
+		sb.WriteString("an object")
+	case typ != "":
+		sb.WriteString("a " + typ)
+	default:
+		sb.WriteString("a value")
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		values := make([]string, len(enum))
+		for i, v := range enum {
+			values[i] = fmt.Sprint(v)
+		}
+		sb.WriteString(" (one of " + strings.Join(values, ", ") + ")")
+	}
+
+	properties := schemaMap(schema, "properties")
+	if len(properties) == 0 || depth > maxOutputSchemaDepth {
+		return
+	}
+	sb.WriteString(" with:")
+	var required []string
+	if r, ok := schema["required"].([]any); ok {
+		for _, name := range r {
+			if s, ok := name.(string); ok {
+				required = append(required, s)
+			}
+		}
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		property, _ := properties[name].(map[string]any)
+		fmt.Fprintf(sb, "\n%s- %s", strings.Repeat("  ", depth), name)
+		if slices.Contains(required, name) {
+			sb.WriteString(" (required)")
+		}
+		sb.WriteString(": ")
+		writeSchema(sb, property, depth+1)
+		if desc, ok := property["description"].(string); ok && desc != "" {
+			sb.WriteString(", " + desc)
+		}
+	}
+}
+
+// schemaType returns the lower-case type of schema. Nullable types, e.g.
+// ["string", "null"], are reported as their non-null type.
+func schemaType(schema map[string]any) string {
+	switch typ := schema["type"].(type) {
+	case string:
+		return strings.ToLower(typ)
+	case []any:
+		var types []string
+		for _, t := range typ {
+			if s, ok := t.(string); ok && s != "null" {
+				types = append(types, strings.ToLower(s))
+			}
+		}
+		return strings.Join(types, " or ")
+	}
+	return ""
+}
+
+func schemaMap(schema map[string]any, key string) map[string]any {
+	m, _ := schema[key].(map[string]any)
+	return m
+}
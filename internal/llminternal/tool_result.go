@@ -0,0 +1,197 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// buildFunctionResponse returns the function response of a tool result,
+// translating the parts stored under [tool.ResultPartsKey] into the
+// output text and the parts of the response. The events of the session
+// are used to resolve the references to earlier function responses.
+func buildFunctionResponse(ctx tool.Context, events session.Events, fnCall *genai.FunctionCall, result map[string]any) *genai.FunctionResponse {
+	fnResponse := &genai.FunctionResponse{
+		ID:       fnCall.ID,
+		Name:     fnCall.Name,
+		Response: result,
+	}
+	raw, ok := result[tool.ResultPartsKey]
+	if !ok {
+		return fnResponse
+	}
+	response, parts, err := translateResultParts(ctx, events, result, raw)
+	if err != nil {
+		fnResponse.Response = map[string]any{"error": err.Error()}
+		return fnResponse
+	}
+	fnResponse.Response = response
+	fnResponse.Parts = parts
+	return fnResponse
+}
+
+func translateResultParts(ctx tool.Context, events session.Events, result map[string]any, raw any) (map[string]any, []*genai.FunctionResponsePart, error) {
+	resultParts, err := decodeResultParts(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	response := maps.Clone(result)
+	delete(response, tool.ResultPartsKey)
+
+	var texts []string
+	var parts []*genai.FunctionResponsePart
+	for _, p := range resultParts {
+		switch {
+		case p.Text != "":
+			texts = append(texts, p.Text)
+		case p.InlineData != nil:
+			parts = append(parts, &genai.FunctionResponsePart{
+				InlineData: &genai.FunctionResponseBlob{
+					MIMEType:    p.InlineData.MIMEType,
+					Data:        p.InlineData.Data,
+					DisplayName: p.InlineData.DisplayName,
+				},
+			})
+		case p.Artifact != "":
+			text, part, err := loadArtifactPart(ctx, p.Artifact)
+			if err != nil {
+				return nil, nil, err
+			}
+			if text != "" {
+				texts = append(texts, text)
+			}
+			if part != nil {
+				parts = append(parts, part)
+			}
+		case p.Ref != nil:
+			text, err := resolveResultRef(ctx, events, p.Ref)
+			if err != nil {
+				return nil, nil, err
+			}
+			texts = append(texts, text)
+		}
+	}
+	if len(texts) > 0 {
+		if _, ok := response["output"]; !ok {
+			response["output"] = strings.Join(texts, "\n")
+		}
+	}
+	return response, parts, nil
+}
+
+// decodeResultParts accepts the parts as set by [tool.NewResult] or after
+// a JSON round trip, as done by function tools converting their results.
+func decodeResultParts(raw any) ([]tool.ResultPart, error) {
+	if parts, ok := raw.([]tool.ResultPart); ok {
+		return parts, nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool result parts: %w", err)
+	}
+	var parts []tool.ResultPart
+	if err := json.Unmarshal(b, &parts); err != nil {
+		return nil, fmt.Errorf("invalid tool result parts: %w", err)
+	}
+	return parts, nil
+}
+
+func loadArtifactPart(ctx tool.Context, name string) (string, *genai.FunctionResponsePart, error) {
+	if ctx.Artifacts() == nil {
+		return "", nil, fmt.Errorf("failed to load artifact %q: artifact service is not configured", name)
+	}
+	resp, err := ctx.Artifacts().Load(ctx, name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load artifact %q: %w", name, err)
+	}
+	part := resp.Part
+	switch {
+	case part == nil:
+		return "", nil, fmt.Errorf("artifact %q is empty", name)
+	case part.InlineData != nil:
+		return "", &genai.FunctionResponsePart{
+			InlineData: &genai.FunctionResponseBlob{
+				MIMEType:    part.InlineData.MIMEType,
+				Data:        part.InlineData.Data,
+				DisplayName: name,
+			},
+		}, nil
+	case part.FileData != nil:
+		return "", &genai.FunctionResponsePart{
+			FileData: &genai.FunctionResponseFileData{
+				FileURI:     part.FileData.FileURI,
+				MIMEType:    part.FileData.MIMEType,
+				DisplayName: name,
+			},
+		}, nil
+	default:
+		return part.Text, nil, nil
+	}
+}
+
+// resolveResultRef checks that the referenced content exists and returns
+// the text standing for it in the function response.
+func resolveResultRef(ctx tool.Context, events session.Events, ref *tool.ResultRef) (string, error) {
+	switch {
+	case ref.Artifact != "":
+		if ctx.Artifacts() == nil {
+			return "", fmt.Errorf("failed to resolve reference to artifact %q: artifact service is not configured", ref.Artifact)
+		}
+		resp, err := ctx.Artifacts().List(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve reference to artifact %q: %w", ref.Artifact, err)
+		}
+		if !slices.Contains(resp.FileNames, ref.Artifact) {
+			return "", fmt.Errorf("failed to resolve reference to artifact %q: artifact not found", ref.Artifact)
+		}
+		return fmt.Sprintf("[See artifact %q, already provided earlier.]", ref.Artifact), nil
+	case ref.FunctionCallID != "":
+		name, ok := findFunctionResponse(events, ref.FunctionCallID)
+		if !ok {
+			return "", fmt.Errorf("failed to resolve reference to function call %q: function response not found", ref.FunctionCallID)
+		}
+		return fmt.Sprintf("[See the response of the earlier %s call with ID %q.]", name, ref.FunctionCallID), nil
+	default:
+		return "", fmt.Errorf("invalid tool result reference: neither artifact nor function call ID is set")
+	}
+}
+
+// findFunctionResponse returns the name of the function whose response
+// to the call with the given ID is in the events.
+func findFunctionResponse(events session.Events, functionCallID string) (string, bool) {
+	if events == nil {
+		return "", false
+	}
+	for ev := range events.All() {
+		if ev.Content == nil {
+			continue
+		}
+		for _, p := range ev.Content.Parts {
+			if p.FunctionResponse != nil && p.FunctionResponse.ID == functionCallID {
+				return p.FunctionResponse.Name, true
+			}
+		}
+	}
+	return "", false
+}
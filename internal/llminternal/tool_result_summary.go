@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/usage"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// DefaultToolResultSummaryInstruction is the instruction given to the
+// summarizer model when none is configured.
+const DefaultToolResultSummaryInstruction = "Summarize the following tool result. Keep every fact, identifier and number that may be needed to answer the user, drop repetitions and formatting."
+
+// summarizeLargeResult replaces a tool result whose JSON encoding is larger
+// than f.MaxToolResultSize with a summary by f.ToolResultSummaryModel. The
+// original result is saved as an artifact, if an artifact service is
+// configured, and its name is returned along with the summary.
+func (f *Flow) summarizeLargeResult(ctx tool.Context, fnCall *genai.FunctionCall, result map[string]any) map[string]any {
+	if f.ToolResultSummaryModel == nil || f.MaxToolResultSize <= 0 {
+		return result
+	}
+	if _, ok := result["error"]; ok {
+		return result
+	}
+	if _, ok := result[tool.ResultPartsKey]; ok {
+		// Binary parts are sent to the model as such, not as text.
+		return result
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil || len(encoded) <= f.MaxToolResultSize {
+		return result
+	}
+
+	summarized := map[string]any{}
+	if ctx.Artifacts() != nil {
+		name := fmt.Sprintf("tool_result_%s_%s.json", fnCall.Name, fnCall.ID)
+		if _, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(encoded, "application/json")); err != nil {
+			return map[string]any{"error": fmt.Sprintf("failed to save the result of tool %q as artifact: %v", fnCall.Name, err)}
+		}
+		summarized["artifact"] = name
+	}
+	summary, err := f.summarize(ctx, fnCall.Name, encoded)
+	if err != nil {
+		summarized["error"] = fmt.Sprintf("the result of tool %q is too large and could not be summarized: %v", fnCall.Name, err)
+		return summarized
+	}
+	summarized["summary"] = summary
+	return summarized
+}
+
+func (f *Flow) summarize(ctx tool.Context, toolName string, encoded []byte) (string, error) {
+	instruction := f.ToolResultSummaryInstruction
+	if instruction == "" {
+		instruction = DefaultToolResultSummaryInstruction
+	}
+	req := &model.LLMRequest{
+		Model: f.ToolResultSummaryModel.Name(),
+		Contents: []*genai.Content{
+			genai.NewContentFromText(fmt.Sprintf("%s\n\nTool: %s\nResult:\n%s", instruction, toolName, encoded), genai.RoleUser),
+		},
+	}
+	var summary strings.Builder
+	for resp, err := range f.ToolResultSummaryModel.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return "", err
+		}
+		if resp == nil || resp.Partial || resp.Content == nil {
+			continue
+		}
+		if acc := usage.FromContext(ctx); acc != nil {
+			acc.Add(req.Model, resp.UsageMetadata)
+		}
+		for _, p := range resp.Content.Parts {
+			if p.Text != "" && !p.Thought {
+				summary.WriteString(p.Text)
+			}
+		}
+	}
+	if summary.Len() == 0 {
+		return "", fmt.Errorf("empty summary")
+	}
+	return summary.String(), nil
+}
@@ -17,6 +17,10 @@ package llminternal
 import (
 	"fmt"
 	"iter"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	icontext "google.golang.org/adk/internal/context"
@@ -49,3 +53,78 @@ func toolProcessor(ctx agent.InvocationContext, req *model.LLMRequest, f *Flow)
 		f.Tools = tools
 	}
 }
+
+// overrideToolDescriptions replaces the descriptions of the function
+// declarations in req with the agent's LocalizedToolDescriptions for the
+// locale of the invocation, or its ToolDescriptions. The declarations are
+// copied, since they may be shared with the tool instances.
+func overrideToolDescriptions(ctx agent.InvocationContext, req *model.LLMRequest) {
+	llmAgent, ok := ctx.Agent().(Agent)
+	if !ok {
+		return
+	}
+	state := Reveal(llmAgent)
+	descriptions, localized := state.ToolDescriptions, state.LocalizedToolDescriptions
+	if (len(descriptions) == 0 && len(localized) == 0) || req.Config == nil {
+		return
+	}
+	var locale string
+	if cfg := ctx.RunConfig(); cfg != nil {
+		locale = cfg.Locale
+	}
+	for i, t := range req.Config.Tools {
+		if t == nil || len(t.FunctionDeclarations) == 0 {
+			continue
+		}
+		var decls []*genai.FunctionDeclaration
+		for j, decl := range t.FunctionDeclarations {
+			if decl == nil {
+				continue
+			}
+			desc, ok := localizedDescription(localized[decl.Name], locale)
+			if !ok {
+				desc, ok = descriptions[decl.Name]
+			}
+			if !ok {
+				continue
+			}
+			if decls == nil {
+				decls = slices.Clone(t.FunctionDeclarations)
+			}
+			overridden := *decl
+			overridden.Description = desc
+			decls[j] = &overridden
+		}
+		if decls != nil {
+			copied := *t
+			copied.FunctionDeclarations = decls
+			req.Config.Tools[i] = &copied
+		}
+	}
+}
+
+// localizedDescription returns the description of translations for the
+// locale, e.g. "pt-BR", falling back to the one of its base language, e.g.
+// "pt". Language tags are compared case-insensitively, "_" and "-" being
+// equivalent.
+func localizedDescription(translations map[string]string, locale string) (string, bool) {
+	if locale == "" || len(translations) == 0 {
+		return "", false
+	}
+	normalize := func(tag string) string {
+		return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	}
+	locale = normalize(locale)
+	base, _, _ := strings.Cut(locale, "-")
+	var fallback string
+	found := false
+	for tag, desc := range translations {
+		switch normalize(tag) {
+		case locale:
+			return desc, true
+		case base:
+			fallback, found = desc, true
+		}
+	}
+	return fallback, found
+}
@@ -82,3 +82,14 @@ func (s *MutableSession) Set(key string, value any) error {
 	}
 	return nil
 }
+
+func (s *MutableSession) Delete(key string) error {
+	mutableState, ok := s.storedSession.State().(MutableState)
+	if !ok {
+		return fmt.Errorf("this session state is not mutable")
+	}
+	if err := mutableState.Delete(key); err != nil {
+		return fmt.Errorf("failed to delete key %q from state: %w", key, err)
+	}
+	return nil
+}
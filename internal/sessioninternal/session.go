@@ -17,4 +17,5 @@ package sessioninternal
 
 type MutableState interface {
 	Set(string, any) error
+	Delete(string) error
 }
@@ -53,6 +53,18 @@ func ExtractStateDeltas(delta map[string]any) (
 	return appStateDelta, userStateDelta, sessionStateDelta
 }
 
+// ApplyStateDelta applies the state delta to state, the keys with a nil
+// value being deleted.
+func ApplyStateDelta(state, delta map[string]any) {
+	for key, value := range delta {
+		if value == nil {
+			delete(state, key)
+			continue
+		}
+		state[key] = value
+	}
+}
+
 // MergeStates combines app, user, and session state maps into a single map
 // for client-side responses, adding the appropriate prefixes back.
 func MergeStates(appState, userState, sessionState map[string]any) map[string]any {
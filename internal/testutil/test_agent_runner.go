@@ -178,6 +178,7 @@ func (m *MockModel) Generate(ctx context.Context, req *model.LLMRequest) (*model
 func (m *MockModel) GenerateStream(ctx context.Context, req *model.LLMRequest) iter.Seq2[*model.LLMResponse, error] {
 	aggregator := llminternal.NewStreamingResponseAggregator()
 	return func(yield func(*model.LLMResponse, error) bool) {
+		m.Requests = append(m.Requests, req)
 		streamResponsesCount := m.StreamResponsesCount
 		if streamResponsesCount == 0 {
 			streamResponsesCount = 1
@@ -17,6 +17,7 @@ package toolinternal
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/google/uuid"
 	"google.golang.org/genai"
@@ -50,6 +51,14 @@ func (ia *internalArtifacts) Save(ctx context.Context, name string, data *genai.
 	return resp, nil
 }
 
+// NewCallToolContext is like NewToolContext, for the execution of fnCall:
+// the context carries its ID and a copy of its arguments.
+func NewCallToolContext(ctx agent.InvocationContext, fnCall *genai.FunctionCall, actions *session.EventActions, confirmation *toolconfirmation.ToolConfirmation) tool.Context {
+	tc := NewToolContext(ctx, fnCall.ID, actions, confirmation).(*toolContext)
+	tc.rawArgs = maps.Clone(fnCall.Args)
+	return tc
+}
+
 func NewToolContext(ctx agent.InvocationContext, functionCallID string, actions *session.EventActions, confirmation *toolconfirmation.ToolConfirmation) tool.Context {
 	if functionCallID == "" {
 		functionCallID = uuid.NewString()
@@ -79,6 +88,7 @@ type toolContext struct {
 	agent.CallbackContext
 	invocationContext agent.InvocationContext
 	functionCallID    string
+	rawArgs           map[string]any
 	eventActions      *session.EventActions
 	artifacts         *internalArtifacts
 	toolConfirmation  *toolconfirmation.ToolConfirmation
@@ -92,6 +102,10 @@ func (c *toolContext) FunctionCallID() string {
 	return c.functionCallID
 }
 
+func (c *toolContext) RawArgs() map[string]any {
+	return c.rawArgs
+}
+
 func (c *toolContext) Actions() *session.EventActions {
 	return c.eventActions
 }
@@ -17,6 +17,9 @@ package toolinternal
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
 	"google.golang.org/adk/agent"
 	contextinternal "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/session"
@@ -33,3 +36,25 @@ func TestToolContext(t *testing.T) {
 		t.Errorf("ToolContext(%+T) is unexpectedly not a CallbackContext", toolCtx)
 	}
 }
+
+func TestCallToolContext(t *testing.T) {
+	inv := contextinternal.NewInvocationContext(t.Context(), contextinternal.InvocationContextParams{})
+	fnCall := &genai.FunctionCall{ID: "fn1", Name: "weather", Args: map[string]any{"city": "Paris"}}
+	toolCtx := NewCallToolContext(inv, fnCall, nil, nil)
+
+	if got := toolCtx.FunctionCallID(); got != "fn1" {
+		t.Errorf("FunctionCallID() = %q, want %q", got, "fn1")
+	}
+	want := map[string]any{"city": "Paris"}
+	if diff := cmp.Diff(want, toolCtx.RawArgs()); diff != "" {
+		t.Errorf("RawArgs() mismatch (-want +got):\n%s", diff)
+	}
+	toolCtx.RawArgs()["city"] = "Rome"
+	if fnCall.Args["city"] != "Paris" {
+		t.Errorf("modifying RawArgs() modified the function call args: %v", fnCall.Args)
+	}
+
+	if got := NewToolContext(inv, "fn2", nil, nil).RawArgs(); got != nil {
+		t.Errorf("RawArgs() = %v outside a function call, want nil", got)
+	}
+}
@@ -31,3 +31,19 @@ type FunctionTool interface {
 type RequestProcessor interface {
 	ProcessRequest(ctx tool.Context, req *model.LLMRequest) error
 }
+
+// ErrorFormatter is implemented by tools that convert their errors into
+// the messages sent to the model.
+type ErrorFormatter interface {
+	FormatError(err error) string
+}
+
+// SchemaVersionKey is the key of the argument that records the input schema
+// version a function call was made against, in the copies of the calls kept
+// to be run later, e.g. after a confirmation.
+const SchemaVersionKey = "_schema_version"
+
+// SchemaVersioned is implemented by tools with a versioned input schema.
+type SchemaVersioned interface {
+	SchemaVersion() int
+}
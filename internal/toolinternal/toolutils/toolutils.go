@@ -17,6 +17,7 @@ package toolutils
 
 import (
 	"fmt"
+	"slices"
 
 	"google.golang.org/genai"
 
@@ -32,6 +33,10 @@ type Tool interface {
 // all of them are consolidated into one genai tool that has all the function declarations
 // provided by the tools. So, if there is already a tool with a function declaration,
 // it appends another to it; otherwise, it creates a new genai tool.
+//
+// Only the tool name is checked for duplicates, so that packing many tools
+// stays linear. The flow checks the assembled declarations once with
+// CheckDeclarations.
 func PackTool(req *model.LLMRequest, tool Tool) error {
 	if req.Tools == nil {
 		req.Tools = make(map[string]any)
@@ -44,26 +49,104 @@ func PackTool(req *model.LLMRequest, tool Tool) error {
 	}
 	req.Tools[name] = tool
 
-	if req.Config == nil {
-		req.Config = &genai.GenerateContentConfig{}
-	}
-	if decl := tool.Declaration(); decl == nil {
+	decl := tool.Declaration()
+	if decl == nil {
+		if req.Config == nil {
+			req.Config = &genai.GenerateContentConfig{}
+		}
 		return nil
 	}
-	// Find an existing genai.Tool with FunctionDeclarations
-	var funcTool *genai.Tool
-	for _, tool := range req.Config.Tools {
-		if tool != nil && tool.FunctionDeclarations != nil {
-			funcTool = tool
-			break
+	funcTool := functionsTool(req, 1)
+	funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, decl)
+	return nil
+}
+
+// AppendDeclarations adds the function declarations to the genai.Tool of the
+// request holding the function declarations, creating it if needed, so that
+// they are all grouped in a single genai.Tool as some backends expect. The
+// server-side tools, e.g. Google Search, are left in their own genai.Tool.
+// A declaration with the name of a declaration already in the request is
+// an error.
+func AppendDeclarations(req *model.LLMRequest, decls ...*genai.FunctionDeclaration) error {
+	funcTool := functionsTool(req, len(decls))
+	names := make(map[string]bool, len(funcTool.FunctionDeclarations)+len(decls))
+	for _, d := range funcTool.FunctionDeclarations {
+		if d != nil {
+			names[d.Name] = true
+		}
+	}
+	for _, decl := range decls {
+		if names[decl.Name] {
+			return fmt.Errorf("duplicate function declaration: %q", decl.Name)
 		}
+		names[decl.Name] = true
+		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, decl)
+	}
+	return nil
+}
+
+// Reserve makes room in req for n more tools, to avoid growing the tools
+// map and the function declarations while packing them. It returns a
+// function to call once the tools are packed, which removes the genai.Tool
+// created for the declarations if no declaration was added.
+func Reserve(req *model.LLMRequest, n int) (done func()) {
+	if req.Tools == nil {
+		req.Tools = make(map[string]any, n)
 	}
-	if funcTool == nil {
-		req.Config.Tools = append(req.Config.Tools, &genai.Tool{
-			FunctionDeclarations: []*genai.FunctionDeclaration{tool.Declaration()},
+	funcTool := functionsTool(req, n)
+	return func() {
+		if len(funcTool.FunctionDeclarations) > 0 {
+			return
+		}
+		req.Config.Tools = slices.DeleteFunc(req.Config.Tools, func(t *genai.Tool) bool {
+			return t == funcTool
 		})
-	} else {
-		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, tool.Declaration())
+		if len(req.Config.Tools) == 0 {
+			req.Config.Tools = nil
+		}
+	}
+}
+
+// CheckDeclarations returns an error if a function is declared more than
+// once in req.
+func CheckDeclarations(req *model.LLMRequest) error {
+	if req.Config == nil {
+		return nil
+	}
+	var names map[string]bool
+	for _, t := range req.Config.Tools {
+		if t == nil {
+			continue
+		}
+		for _, d := range t.FunctionDeclarations {
+			if d == nil {
+				continue
+			}
+			if names == nil {
+				names = make(map[string]bool)
+			}
+			if names[d.Name] {
+				return fmt.Errorf("duplicate function declaration: %q", d.Name)
+			}
+			names[d.Name] = true
+		}
 	}
 	return nil
 }
+
+// functionsTool returns the genai.Tool of req holding the function
+// declarations, with room for n more, creating it if needed.
+func functionsTool(req *model.LLMRequest, n int) *genai.Tool {
+	if req.Config == nil {
+		req.Config = &genai.GenerateContentConfig{}
+	}
+	for _, t := range req.Config.Tools {
+		if t != nil && t.FunctionDeclarations != nil {
+			t.FunctionDeclarations = slices.Grow(t.FunctionDeclarations, n)
+			return t
+		}
+	}
+	t := &genai.Tool{FunctionDeclarations: make([]*genai.FunctionDeclaration, 0, n)}
+	req.Config.Tools = append(req.Config.Tools, t)
+	return t
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolutils_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+)
+
+type declaredTool struct {
+	name string
+}
+
+func (d declaredTool) Name() string {
+	return d.name
+}
+
+func (d declaredTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{Name: d.name}
+}
+
+func TestReserve(t *testing.T) {
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+
+	req := &model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{search}}}
+	done := toolutils.Reserve(req, 2)
+	for _, name := range []string{"sum", "product"} {
+		if err := toolutils.PackTool(req, declaredTool{name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	done()
+	if len(req.Config.Tools) != 2 || len(req.Config.Tools[1].FunctionDeclarations) != 2 {
+		t.Errorf("got tools %+v, want the search tool and the two declarations", req.Config.Tools)
+	}
+
+	// Without declarations, the genai.Tool reserved for them is removed.
+	req = &model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{search}}}
+	toolutils.Reserve(req, 2)()
+	if len(req.Config.Tools) != 1 || req.Config.Tools[0] != search {
+		t.Errorf("got tools %+v, want only the search tool", req.Config.Tools)
+	}
+}
+
+func TestCheckDeclarations(t *testing.T) {
+	req := &model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{{
+		FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "sum"}},
+	}}}}
+	if err := toolutils.PackTool(req, declaredTool{"product"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := toolutils.CheckDeclarations(req); err != nil {
+		t.Errorf("CheckDeclarations() error = %v, want nil", err)
+	}
+
+	// PackTool only checks the tool names, the declaration added without
+	// its tool is caught by CheckDeclarations.
+	if err := toolutils.PackTool(req, declaredTool{"sum"}); err != nil {
+		t.Fatal(err)
+	}
+	err := toolutils.CheckDeclarations(req)
+	if err == nil || !strings.Contains(err.Error(), `duplicate function declaration: "sum"`) {
+		t.Errorf("CheckDeclarations() error = %v, want a duplicate function declaration error", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package usage accumulates the token usage of the model calls of an
+// invocation.
+package usage
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+// Accumulator sums the usage metadata of model responses. It is safe for
+// concurrent use, e.g. by the sub-agents of a parallel agent.
+type Accumulator struct {
+	mu      sync.Mutex
+	total   genai.GenerateContentResponseUsageMetadata
+	byModel map[string]*genai.GenerateContentResponseUsageMetadata
+}
+
+// Add adds u, reported by the model, to the running total.
+func (a *Accumulator) Add(modelName string, u *genai.GenerateContentResponseUsageMetadata) {
+	if u == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	add(&a.total, u)
+	if a.byModel == nil {
+		a.byModel = make(map[string]*genai.GenerateContentResponseUsageMetadata)
+	}
+	m, ok := a.byModel[modelName]
+	if !ok {
+		m = &genai.GenerateContentResponseUsageMetadata{}
+		a.byModel[modelName] = m
+	}
+	add(m, u)
+}
+
+func add(dst, u *genai.GenerateContentResponseUsageMetadata) {
+	dst.PromptTokenCount += u.PromptTokenCount
+	dst.CandidatesTokenCount += u.CandidatesTokenCount
+	dst.CachedContentTokenCount += u.CachedContentTokenCount
+	dst.ThoughtsTokenCount += u.ThoughtsTokenCount
+	dst.ToolUsePromptTokenCount += u.ToolUsePromptTokenCount
+	dst.TotalTokenCount += u.TotalTokenCount
+}
+
+// Total returns a copy of the running total.
+func (a *Accumulator) Total() *genai.GenerateContentResponseUsageMetadata {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	total := a.total
+	return &total
+}
+
+// ByModel returns a copy of the running totals of each model.
+func (a *Accumulator) ByModel() map[string]*genai.GenerateContentResponseUsageMetadata {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	byModel := make(map[string]*genai.GenerateContentResponseUsageMetadata, len(a.byModel))
+	for name, u := range a.byModel {
+		copied := *u
+		byModel[name] = &copied
+	}
+	return byModel
+}
+
+func ToContext(ctx context.Context, a *Accumulator) context.Context {
+	return context.WithValue(ctx, accumulatorCtxKey, a)
+}
+
+func FromContext(ctx context.Context) *Accumulator {
+	a, ok := ctx.Value(accumulatorCtxKey).(*Accumulator)
+	if !ok {
+		return nil
+	}
+	return a
+}
+
+type ctxKey int
+
+const accumulatorCtxKey ctxKey = 0
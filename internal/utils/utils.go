@@ -126,6 +126,9 @@ func Must[T agent.Agent](a T, err error) T {
 	return a
 }
 
+// AppendInstructions appends the instructions, joined, to the system
+// instruction of r as a new text part. The parts already in the system
+// instruction, whatever their number and kind, are kept.
 func AppendInstructions(r *model.LLMRequest, instructions ...string) {
 	if len(instructions) == 0 {
 		return
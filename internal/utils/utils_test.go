@@ -14,8 +14,62 @@
 
 package utils_test
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+)
 
 func TestNothing(t *testing.T) {
 	// To make it buildable.
 }
+
+func TestAppendInstructions(t *testing.T) {
+	image := &genai.Part{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte("logo")}}
+	req := &model.LLMRequest{Config: &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{
+			genai.NewPartFromText("You are a helpful assistant."),
+			image,
+			genai.NewPartFromText("Answer in English."),
+		}},
+	}}
+
+	utils.AppendInstructions(req, "Be concise.", "Cite your sources.")
+
+	want := &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{
+		genai.NewPartFromText("You are a helpful assistant."),
+		image,
+		genai.NewPartFromText("Answer in English."),
+		genai.NewPartFromText("Be concise.\n\nCite your sources."),
+	}}
+	if diff := cmp.Diff(want, req.Config.SystemInstruction); diff != "" {
+		t.Errorf("system instruction mismatch (-want +got):\n%s", diff)
+	}
+
+	// An instruction starting with a non-text part is kept too.
+	req = &model.LLMRequest{Config: &genai.GenerateContentConfig{
+		SystemInstruction: &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{
+			image,
+			genai.NewPartFromText("This is the logo of the company."),
+		}},
+	}}
+	utils.AppendInstructions(req, "Be concise.")
+	want = &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{
+		image,
+		genai.NewPartFromText("This is the logo of the company."),
+		genai.NewPartFromText("Be concise."),
+	}}
+	if diff := cmp.Diff(want, req.Config.SystemInstruction); diff != "" {
+		t.Errorf("system instruction mismatch (-want +got):\n%s", diff)
+	}
+
+	req = &model.LLMRequest{}
+	utils.AppendInstructions(req, "Be concise.")
+	if diff := cmp.Diff(genai.NewContentFromText("Be concise.", genai.RoleUser), req.Config.SystemInstruction); diff != "" {
+		t.Errorf("system instruction mismatch (-want +got):\n%s", diff)
+	}
+}
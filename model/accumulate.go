@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"iter"
+	"reflect"
+
+	"google.golang.org/genai"
+)
+
+// Accumulate returns the first complete message of a streamed response, as
+// returned by LLM.GenerateContent, assembled from its responses. See
+// Coalesce for how they are merged. It stops reading the stream once the
+// turn of the model is complete, and returns the first error of the stream,
+// e.g. the error of a cancelled context. It returns nil if the stream ends
+// without any response.
+func Accumulate(stream iter.Seq2[*LLMResponse, error]) (*LLMResponse, error) {
+	for resp, err := range Coalesce(stream) {
+		return resp, err
+	}
+	return nil, nil
+}
+
+// Coalesce returns a stream yielding only the complete messages of stream,
+// one per turn of the model, each assembled from the responses of the turn.
+//
+// A turn ends with a response setting TurnComplete, or with the stream. Its
+// responses are merged in order: a non-partial response with content, as
+// the final response of models repeating the text of the chunks before it,
+// replaces these chunks, and is followed by the next ones, e.g. the
+// function call the Gemini models stream after their text. The parts are
+// appended in order, the consecutive text parts being joined, thoughts
+// apart. The metadata of the message, e.g. GroundingMetadata or
+// UsageMetadata, is the last one set by a response of the turn.
+//
+// An error of stream is passed through and ends the returned stream.
+func Coalesce(stream iter.Seq2[*LLMResponse, error]) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		// msg is the message of the turn, chunks the partial responses
+		// not yet part of it.
+		var msg, chunks *LLMResponse
+		for resp, err := range stream {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if resp == nil {
+				continue
+			}
+			switch {
+			case resp.Partial:
+				chunks = appendChunk(chunks, resp)
+			case resp.Content != nil:
+				msg = appendChunk(msg, withMetadataOf(chunks, resp))
+				chunks = nil
+			case msg != nil || chunks != nil || resp.ErrorCode != "":
+				msg = appendChunk(msg, appendChunk(chunks, resp))
+				chunks = nil
+			}
+			if !resp.TurnComplete {
+				continue
+			}
+			if chunks != nil {
+				msg = appendChunk(msg, chunks)
+			}
+			turn := msg
+			msg, chunks = nil, nil
+			if turn != nil {
+				turn.Partial = false
+				if !yield(turn, nil) {
+					return
+				}
+			}
+		}
+		if chunks != nil {
+			msg = appendChunk(msg, chunks)
+		}
+		if msg != nil {
+			msg.Partial = false
+			yield(msg, nil)
+		}
+	}
+}
+
+// appendChunk returns the message acc with the parts of resp appended, and
+// the metadata of resp replacing its own. resp is not modified.
+func appendChunk(acc, resp *LLMResponse) *LLMResponse {
+	if acc == nil {
+		acc = &LLMResponse{}
+	}
+	if resp.Content != nil {
+		if acc.Content == nil {
+			acc.Content = &genai.Content{Role: resp.Content.Role}
+		}
+		for _, p := range resp.Content.Parts {
+			if p == nil {
+				continue
+			}
+			if n := len(acc.Content.Parts); n > 0 && isPlainText(p) && isPlainText(acc.Content.Parts[n-1]) && acc.Content.Parts[n-1].Thought == p.Thought {
+				acc.Content.Parts[n-1] = &genai.Part{Text: acc.Content.Parts[n-1].Text + p.Text, Thought: p.Thought}
+				continue
+			}
+			acc.Content.Parts = append(acc.Content.Parts, p)
+		}
+	}
+	msg := withMetadataOf(acc, resp)
+	msg.Content = acc.Content
+	return msg
+}
+
+// withMetadataOf returns a copy of resp with the metadata it does not set
+// taken from from.
+func withMetadataOf(from, resp *LLMResponse) *LLMResponse {
+	r := *resp
+	if from == nil {
+		return &r
+	}
+	if r.CitationMetadata == nil {
+		r.CitationMetadata = from.CitationMetadata
+	}
+	if r.GroundingMetadata == nil {
+		r.GroundingMetadata = from.GroundingMetadata
+	}
+	if r.UsageMetadata == nil {
+		r.UsageMetadata = from.UsageMetadata
+	}
+	if r.CustomMetadata == nil {
+		r.CustomMetadata = from.CustomMetadata
+	}
+	if r.LogprobsResult == nil {
+		r.LogprobsResult = from.LogprobsResult
+	}
+	if r.FinishReason == "" {
+		r.FinishReason = from.FinishReason
+	}
+	if r.AvgLogprobs == 0 {
+		r.AvgLogprobs = from.AvgLogprobs
+	}
+	if r.ErrorCode == "" {
+		r.ErrorCode, r.ErrorMessage = from.ErrorCode, from.ErrorMessage
+	}
+	r.TurnComplete = r.TurnComplete || from.TurnComplete
+	r.Interrupted = r.Interrupted || from.Interrupted
+	return &r
+}
+
+// isPlainText reports whether p only holds text, possibly a thought.
+func isPlainText(p *genai.Part) bool {
+	q := *p
+	q.Text, q.Thought = "", false
+	return p.Text != "" && reflect.ValueOf(q).IsZero()
+}
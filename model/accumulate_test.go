@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func streamOf(resps []*model.LLMResponse, err error) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for _, resp := range resps {
+			if !yield(resp, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func chunk(parts ...*genai.Part) *model.LLMResponse {
+	return &model.LLMResponse{Partial: true, Content: &genai.Content{Role: genai.RoleModel, Parts: parts}}
+}
+
+func TestCoalesce(t *testing.T) {
+	grounding := &genai.GroundingMetadata{WebSearchQueries: []string{"weather paris"}}
+	withGrounding := chunk(genai.NewPartFromText("sunny."))
+	withGrounding.GroundingMetadata = grounding
+	call := genai.NewPartFromFunctionCall("lookup", nil)
+	final := &model.LLMResponse{Content: genai.NewContentFromText("Bye.", genai.RoleModel), TurnComplete: true}
+
+	stream := streamOf([]*model.LLMResponse{
+		chunk(&genai.Part{Text: "Checking ", Thought: true}, &genai.Part{Text: "the sky.", Thought: true}),
+		chunk(genai.NewPartFromText("It is ")),
+		withGrounding,
+		chunk(call),
+		{TurnComplete: true, FinishReason: genai.FinishReasonStop},
+		// A bare marker completes no message.
+		{TurnComplete: true},
+		chunk(genai.NewPartFromText("By")),
+		chunk(genai.NewPartFromText("e.")),
+		final,
+		chunk(genai.NewPartFromText("Trailing")),
+	}, nil)
+
+	var got []*model.LLMResponse
+	for resp, err := range model.Coalesce(stream) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, resp)
+	}
+	want := []*model.LLMResponse{
+		{
+			Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+				{Text: "Checking the sky.", Thought: true},
+				{Text: "It is sunny."},
+				call,
+			}},
+			GroundingMetadata: grounding,
+			TurnComplete:      true,
+			FinishReason:      genai.FinishReasonStop,
+		},
+		final,
+		{Content: genai.NewContentFromText("Trailing", genai.RoleModel)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Coalesce() mismatch (-want +got):\n%s", diff)
+	}
+	if withGrounding.Content.Parts[0].Text != "sunny." {
+		t.Error("Coalesce() modified a chunk")
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	stream := streamOf([]*model.LLMResponse{
+		chunk(genai.NewPartFromText("Hello, ")),
+		chunk(genai.NewPartFromText("world.")),
+		{TurnComplete: true},
+		chunk(genai.NewPartFromText("Never read.")),
+	}, nil)
+	got, err := model.Accumulate(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &model.LLMResponse{Content: genai.NewContentFromText("Hello, world.", genai.RoleModel), TurnComplete: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Accumulate() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The text and the function call of the Gemini models come as
+	// separate complete responses.
+	call := genai.NewPartFromFunctionCall("lookup", map[string]any{"city": "Paris"})
+	usage := &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 42}
+	stream = streamOf([]*model.LLMResponse{
+		chunk(genai.NewPartFromText("I need ")),
+		chunk(genai.NewPartFromText("the weather.")),
+		{Content: genai.NewContentFromText("I need the weather.", genai.RoleModel)},
+		{Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{call}}, UsageMetadata: usage, FinishReason: genai.FinishReasonStop},
+	}, nil)
+	got, err = model.Accumulate(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &model.LLMResponse{
+		Content:       &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{genai.NewPartFromText("I need the weather."), call}},
+		UsageMetadata: usage,
+		FinishReason:  genai.FinishReasonStop,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Accumulate() of text followed by a function call mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, err := model.Accumulate(streamOf(nil, nil)); got != nil || err != nil {
+		t.Errorf("Accumulate() of an empty stream = %v, %v, want nil, nil", got, err)
+	}
+
+	_, err = model.Accumulate(streamOf([]*model.LLMResponse{chunk(genai.NewPartFromText("Hel"))}, context.Canceled))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Accumulate() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditmodel provides a model wrapper that records every model call
+// to an audit sink.
+//
+// Unlike debug logging, auditing is fail-closed: a call whose record cannot be
+// written fails, so that no model interaction goes unrecorded.
+package auditmodel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+)
+
+// Record is the audit record of a model call.
+//
+// The request and responses are normalized to their JSON representation, so
+// that they can be redacted and stored without referencing the values used
+// by the caller.
+type Record struct {
+	// Timestamp is the time the call started.
+	Timestamp time.Time `json:"timestamp"`
+	// Latency is the time until the last response or error was received.
+	Latency time.Duration `json:"latency"`
+	// Model is the name of the requested model.
+	Model string `json:"model"`
+	// Stream reports whether the response was streamed.
+	Stream bool `json:"stream"`
+
+	// AppName, UserID, SessionID and InvocationID identify the invocation
+	// the call was made in. They are empty if the call was not made by an
+	// agent.
+	AppName      string `json:"app_name,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	SessionID    string `json:"session_id,omitempty"`
+	InvocationID string `json:"invocation_id,omitempty"`
+
+	// Request is the request sent to the model.
+	Request map[string]any `json:"request"`
+	// Responses are the responses received from the model, including the
+	// partial ones of a streamed call.
+	Responses []map[string]any `json:"responses,omitempty"`
+	// Error is the error of a failed call.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink stores audit records.
+//
+// Write is called once per model call, before the last response or the error
+// is returned to the caller. It may be called concurrently.
+type Sink interface {
+	Write(ctx context.Context, r *Record) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, r *Record) error
+
+// Write implements Sink.
+func (f SinkFunc) Write(ctx context.Context, r *Record) error {
+	return f(ctx, r)
+}
+
+// NewJSONLSink returns a Sink that writes each record to w as a line of
+// JSON.
+func NewJSONLSink(w io.Writer) Sink {
+	return &jsonlSink{enc: json.NewEncoder(w)}
+}
+
+type jsonlSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (s *jsonlSink) Write(_ context.Context, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(r)
+}
+
+// Config is the configuration of the audit model.
+type Config struct {
+	// Sink stores the records. Required.
+	Sink Sink
+	// Redact is called on each record before it is written, e.g. to remove
+	// personal data from the request and responses. It may modify the
+	// record, which is not shared with the caller.
+	Redact func(r *Record)
+}
+
+// New returns an LLM that forwards requests to llm and writes a Record of
+// every call, successful or not, to the configured sink.
+//
+// Errors of llm are returned unchanged. If the record cannot be written, the
+// call fails with an error wrapping the sink's error instead of returning the
+// last response.
+func New(llm model.LLM, cfg Config) (model.LLM, error) {
+	if llm == nil {
+		return nil, errors.New("model is required")
+	}
+	if cfg.Sink == nil {
+		return nil, errors.New("audit sink is required")
+	}
+	return &auditModel{llm: llm, cfg: cfg}, nil
+}
+
+type auditModel struct {
+	llm model.LLM
+	cfg Config
+}
+
+func (m *auditModel) Name() string {
+	return m.llm.Name()
+}
+
+func (m *auditModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		rec := newRecord(ctx, req, stream)
+		if rec.Model == "" {
+			rec.Model = m.llm.Name()
+		}
+		start := time.Now()
+
+		// Each response is held back until the next one is received, so that
+		// the record is written before the last one is returned.
+		var pending *model.LLMResponse
+		for resp, err := range m.llm.GenerateContent(ctx, req, stream) {
+			if err != nil {
+				rec.Error = err.Error()
+				if pending != nil && !yield(pending, nil) {
+					_ = m.write(ctx, rec, start)
+					return
+				}
+				if werr := m.write(ctx, rec, start); werr != nil {
+					err = errors.Join(err, werr)
+				}
+				yield(nil, err)
+				return
+			}
+			rec.Responses = append(rec.Responses, normalize(resp))
+			if pending != nil && !yield(pending, nil) {
+				// The caller stopped early, there is no one left to
+				// report a write error to.
+				_ = m.write(ctx, rec, start)
+				return
+			}
+			pending = resp
+		}
+		if err := m.write(ctx, rec, start); err != nil {
+			yield(nil, err)
+			return
+		}
+		if pending != nil {
+			yield(pending, nil)
+		}
+	}
+}
+
+func (m *auditModel) write(ctx context.Context, rec *Record, start time.Time) error {
+	rec.Latency = time.Since(start)
+	if m.cfg.Redact != nil {
+		m.cfg.Redact(rec)
+	}
+	if err := m.cfg.Sink.Write(ctx, rec); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+func newRecord(ctx context.Context, req *model.LLMRequest, stream bool) *Record {
+	rec := &Record{
+		Timestamp: time.Now().UTC(),
+		Stream:    stream,
+		Request:   normalize(req),
+	}
+	if req != nil {
+		rec.Model = req.Model
+	}
+	if ictx, ok := ctx.(agent.InvocationContext); ok {
+		rec.InvocationID = ictx.InvocationID()
+		if s := ictx.Session(); s != nil {
+			rec.AppName = s.AppName()
+			rec.UserID = s.UserID()
+			rec.SessionID = s.ID()
+		}
+	}
+	return rec
+}
+
+// normalize returns the JSON representation of v as a map.
+func normalize(v any) map[string]any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return map[string]any{"error": fmt.Sprintf("failed to marshal: %v", err)}
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return map[string]any{"error": fmt.Sprintf("failed to unmarshal: %v", err)}
+	}
+	return m
+}
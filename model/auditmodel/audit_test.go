@@ -0,0 +1,192 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditmodel_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/auditmodel"
+)
+
+type fakeModel struct {
+	responses []*model.LLMResponse
+	err       error
+}
+
+func (m *fakeModel) Name() string { return "fake-model" }
+
+func (m *fakeModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for _, resp := range m.responses {
+			if !yield(resp, nil) {
+				return
+			}
+		}
+		if m.err != nil {
+			yield(nil, m.err)
+		}
+	}
+}
+
+type recordingSink struct {
+	records []*auditmodel.Record
+	err     error
+}
+
+func (s *recordingSink) Write(_ context.Context, r *auditmodel.Record) error {
+	s.records = append(s.records, r)
+	return s.err
+}
+
+func newRequest(text string) *model.LLMRequest {
+	return &model.LLMRequest{
+		Model:    "gemini-2.5-flash",
+		Contents: []*genai.Content{genai.NewContentFromText(text, genai.RoleUser)},
+	}
+}
+
+func TestAuditModel(t *testing.T) {
+	inner := &fakeModel{responses: []*model.LLMResponse{
+		{Content: genai.NewContentFromText("Hel", genai.RoleModel), Partial: true},
+		{Content: genai.NewContentFromText("Hello", genai.RoleModel)},
+	}}
+	sink := &recordingSink{}
+	returned := 0
+	llm, err := auditmodel.New(inner, auditmodel.Config{
+		Sink: auditmodel.SinkFunc(func(ctx context.Context, r *auditmodel.Record) error {
+			if returned != 1 {
+				t.Errorf("record written after %d responses were returned, want 1", returned)
+			}
+			return sink.Write(ctx, r)
+		}),
+		Redact: func(r *auditmodel.Record) {
+			r.Request["Contents"] = "[redacted]"
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	req := newRequest("my secret")
+	for resp, err := range llm.GenerateContent(t.Context(), req, true) {
+		if err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+		if resp == nil {
+			t.Fatal("GenerateContent() returned a nil response")
+		}
+		returned++
+	}
+	if returned != 2 {
+		t.Errorf("got %d responses, want 2", returned)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(sink.records))
+	}
+	rec := sink.records[0]
+	if rec.Model != "gemini-2.5-flash" || !rec.Stream || rec.Error != "" {
+		t.Errorf("got record %+v, want a successful streamed call to gemini-2.5-flash", rec)
+	}
+	if len(rec.Responses) != 2 {
+		t.Errorf("got %d recorded responses, want 2", len(rec.Responses))
+	}
+	if rec.Request["Contents"] != "[redacted]" {
+		t.Errorf("got request contents %v, want them redacted", rec.Request["Contents"])
+	}
+	if req.Contents[0].Parts[0].Text != "my secret" {
+		t.Error("redaction modified the caller's request")
+	}
+	if rec.Timestamp.IsZero() || rec.Latency < 0 {
+		t.Errorf("got timestamp %v and latency %v, want them set", rec.Timestamp, rec.Latency)
+	}
+}
+
+func TestAuditModel_RecordsFailedCalls(t *testing.T) {
+	modelErr := errors.New("quota exceeded")
+	sink := &recordingSink{}
+	llm, err := auditmodel.New(&fakeModel{err: modelErr}, auditmodel.Config{Sink: sink})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var gotErr error
+	for _, err := range llm.GenerateContent(t.Context(), newRequest("hi"), false) {
+		gotErr = err
+	}
+	if !errors.Is(gotErr, modelErr) {
+		t.Errorf("GenerateContent() error = %v, want %v", gotErr, modelErr)
+	}
+	if len(sink.records) != 1 || sink.records[0].Error != modelErr.Error() {
+		t.Errorf("got records %+v, want one record of the failed call", sink.records)
+	}
+}
+
+func TestAuditModel_SinkError(t *testing.T) {
+	sinkErr := errors.New("audit store unavailable")
+	inner := &fakeModel{responses: []*model.LLMResponse{
+		{Content: genai.NewContentFromText("Hello", genai.RoleModel)},
+	}}
+	llm, err := auditmodel.New(inner, auditmodel.Config{Sink: &recordingSink{err: sinkErr}})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var responses int
+	var gotErr error
+	for resp, err := range llm.GenerateContent(t.Context(), newRequest("hi"), false) {
+		if resp != nil {
+			responses++
+		}
+		gotErr = err
+	}
+	if !errors.Is(gotErr, sinkErr) {
+		t.Errorf("GenerateContent() error = %v, want %v", gotErr, sinkErr)
+	}
+	if responses != 0 {
+		t.Errorf("got %d responses, want none when the record cannot be written", responses)
+	}
+}
+
+func TestNewJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := auditmodel.NewJSONLSink(&buf)
+	for _, id := range []string{"inv-1", "inv-2"} {
+		if err := sink.Write(t.Context(), &auditmodel.Record{InvocationID: id, Model: "gemini"}); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var rec auditmodel.Record
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+		t.Fatalf("line is not a JSON record: %v", err)
+	}
+	if rec.InvocationID != "inv-2" {
+		t.Errorf("got invocation ID %q, want %q", rec.InvocationID, "inv-2")
+	}
+}
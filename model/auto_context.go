@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+
+	"google.golang.org/genai"
+)
+
+// charsPerToken is the average number of characters per token used by
+// EstimateTokens.
+const charsPerToken = 4
+
+// tokensPerBlob is the number of tokens EstimateTokens counts for each inline
+// or file blob, e.g. an image.
+const tokensPerBlob = 258
+
+// EstimateTokens returns a rough estimate of the number of input tokens of
+// req, without calling the model.
+//
+// The estimate counts about one token per 4 characters of text, function
+// call and response payloads, system instruction and tool declarations, and a
+// fixed amount per blob. It is meant for cheap decisions such as model
+// selection, use the model's token counting endpoint when an exact count is
+// needed.
+func EstimateTokens(req *LLMRequest) int {
+	if req == nil {
+		return 0
+	}
+	chars, blobs := 0, 0
+	countContent := func(c *genai.Content) {
+		if c == nil {
+			return
+		}
+		for _, p := range c.Parts {
+			if p == nil {
+				continue
+			}
+			chars += len(p.Text)
+			if p.FunctionCall != nil {
+				chars += len(p.FunctionCall.Name) + jsonLen(p.FunctionCall.Args)
+			}
+			if p.FunctionResponse != nil {
+				chars += len(p.FunctionResponse.Name) + jsonLen(p.FunctionResponse.Response)
+			}
+			if p.InlineData != nil || p.FileData != nil {
+				blobs++
+			}
+		}
+	}
+	for _, c := range req.Contents {
+		countContent(c)
+	}
+	if req.Config != nil {
+		countContent(req.Config.SystemInstruction)
+		if len(req.Config.Tools) > 0 {
+			chars += jsonLen(req.Config.Tools)
+		}
+	}
+	return (chars+charsPerToken-1)/charsPerToken + blobs*tokensPerBlob
+}
+
+func jsonLen(v any) int {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// ErrContextWindowExceeded is returned by the model created with
+// NewAutoContextModel when the request does not fit in the context window of
+// any of its models.
+var ErrContextWindowExceeded = errors.New("request does not fit in the context window of any model")
+
+// ContextModel is a model candidate of NewAutoContextModel.
+type ContextModel struct {
+	// LLM is the model.
+	LLM LLM
+	// ContextWindow is the maximum number of tokens of the model, input and
+	// output combined.
+	ContextWindow int
+	// Cost is the relative cost of the model, e.g. its price per million
+	// input tokens. Only the order of the costs matters.
+	Cost float64
+}
+
+// NewAutoContextModel returns an LLM that sends each request to the cheapest
+// of the given models whose context window fits it.
+//
+// The size of the request is estimated with EstimateTokens, plus the
+// MaxOutputTokens of its config, if set. Models with the same cost are
+// preferred in the given order. If no model fits, GenerateContent fails with
+// ErrContextWindowExceeded.
+func NewAutoContextModel(name string, models ...ContextModel) (LLM, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("at least one model is required")
+	}
+	for i, m := range models {
+		if m.LLM == nil {
+			return nil, fmt.Errorf("model %d is nil", i)
+		}
+		if m.ContextWindow <= 0 {
+			return nil, fmt.Errorf("model %q has no context window", m.LLM.Name())
+		}
+	}
+	return &autoContextModel{name: name, models: models}, nil
+}
+
+type autoContextModel struct {
+	name   string
+	models []ContextModel
+}
+
+func (m *autoContextModel) Name() string {
+	return m.name
+}
+
+func (m *autoContextModel) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	tokens := EstimateTokens(req)
+	if req.Config != nil {
+		tokens += int(req.Config.MaxOutputTokens)
+	}
+
+	var selected *ContextModel
+	for i, candidate := range m.models {
+		if candidate.ContextWindow < tokens {
+			continue
+		}
+		if selected == nil || candidate.Cost < selected.Cost {
+			selected = &m.models[i]
+		}
+	}
+	if selected == nil {
+		return func(yield func(*LLMResponse, error) bool) {
+			yield(nil, fmt.Errorf("%w: estimated %d tokens", ErrContextWindowExceeded, tokens))
+		}
+	}
+
+	r := *req
+	r.Model = selected.LLM.Name()
+	return selected.LLM.GenerateContent(ctx, &r, stream)
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *model.LLMRequest
+		want int
+	}{
+		{
+			name: "nil",
+			want: 0,
+		},
+		{
+			name: "text",
+			req: &model.LLMRequest{
+				Contents: []*genai.Content{
+					genai.NewContentFromText(strings.Repeat("a", 40), genai.RoleUser),
+					genai.NewContentFromText(strings.Repeat("b", 2), genai.RoleModel),
+				},
+			},
+			want: 11,
+		},
+		{
+			name: "system instruction and blob",
+			req: &model.LLMRequest{
+				Contents: []*genai.Content{
+					genai.NewContentFromBytes([]byte("image"), "image/png", genai.RoleUser),
+				},
+				Config: &genai.GenerateContentConfig{
+					SystemInstruction: genai.NewContentFromText(strings.Repeat("a", 8), genai.RoleUser),
+				},
+			},
+			want: 2 + 258,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.EstimateTokens(tt.req); got != tt.want {
+				t.Errorf("EstimateTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+type namedLLM struct {
+	name   string
+	called bool
+}
+
+func (m *namedLLM) Name() string { return m.name }
+
+func (m *namedLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	m.called = true
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(req.Model, genai.RoleModel)}, nil)
+	}
+}
+
+func TestAutoContextModel(t *testing.T) {
+	tests := []struct {
+		name      string
+		textLen   int
+		maxOutput int32
+		want      string
+		wantErr   error
+	}{
+		{
+			name:    "small request goes to the cheapest model",
+			textLen: 40,
+			want:    "flash-lite",
+		},
+		{
+			name:    "medium request",
+			textLen: 400,
+			want:    "flash",
+		},
+		{
+			name:      "output tokens are reserved",
+			textLen:   40,
+			maxOutput: 50,
+			want:      "flash",
+		},
+		{
+			name:    "large request",
+			textLen: 4000,
+			want:    "pro",
+		},
+		{
+			name:    "request too large",
+			textLen: 40000,
+			wantErr: model.ErrContextWindowExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llm, err := model.NewAutoContextModel("auto",
+				model.ContextModel{LLM: &namedLLM{name: "pro"}, ContextWindow: 1000, Cost: 10},
+				model.ContextModel{LLM: &namedLLM{name: "flash"}, ContextWindow: 200, Cost: 1},
+				model.ContextModel{LLM: &namedLLM{name: "flash-lite"}, ContextWindow: 50, Cost: 0.5},
+			)
+			if err != nil {
+				t.Fatalf("NewAutoContextModel() failed: %v", err)
+			}
+			if got := llm.Name(); got != "auto" {
+				t.Errorf("Name() = %q, want %q", got, "auto")
+			}
+
+			req := &model.LLMRequest{
+				Model:    "auto",
+				Contents: []*genai.Content{genai.NewContentFromText(strings.Repeat("a", tt.textLen), genai.RoleUser)},
+			}
+			if tt.maxOutput > 0 {
+				req.Config = &genai.GenerateContentConfig{MaxOutputTokens: tt.maxOutput}
+			}
+			for resp, err := range llm.GenerateContent(t.Context(), req, false) {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GenerateContent() error = %v, want %v", err, tt.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if got := resp.Content.Parts[0].Text; got != tt.want {
+					t.Errorf("request routed to %q, want %q", got, tt.want)
+				}
+			}
+			if req.Model != "auto" {
+				t.Errorf("caller request was modified, Model = %q", req.Model)
+			}
+		})
+	}
+}
+
+func TestNewAutoContextModel_Errors(t *testing.T) {
+	if _, err := model.NewAutoContextModel("auto"); err == nil {
+		t.Error("NewAutoContextModel() without models succeeded unexpectedly")
+	}
+	if _, err := model.NewAutoContextModel("auto", model.ContextModel{LLM: &namedLLM{name: "m"}}); err == nil {
+		t.Error("NewAutoContextModel() without context window succeeded unexpectedly")
+	}
+}
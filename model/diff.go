@@ -0,0 +1,265 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// DiffRequests returns a human-readable, line-based diff between two
+// requests, or an empty string if they are equivalent.
+//
+// The requests are compared section by section: model, contents, system
+// instruction, tool declarations and the remaining generate content config.
+// Only the sections that differ are reported, as unified diff hunks: lines
+// removed from a are prefixed with "-", lines added in b with "+", and up to
+// three unchanged context lines around them with a space.
+//
+// The output is meant for debugging and its format may change.
+func DiffRequests(a, b *LLMRequest) string {
+	sa, sb := requestSections(a), requestSections(b)
+
+	var out strings.Builder
+	for i := range sa {
+		if sa[i].text == sb[i].text {
+			continue
+		}
+		fmt.Fprintf(&out, "--- %s\n", sa[i].name)
+		diffLines(&out, splitLines(sa[i].text), splitLines(sb[i].text))
+	}
+	return out.String()
+}
+
+type requestSection struct {
+	name string
+	text string
+}
+
+// requestSections renders r as a fixed list of named sections, so that
+// sections of two requests can be compared by index.
+func requestSections(r *LLMRequest) []requestSection {
+	if r == nil {
+		r = &LLMRequest{}
+	}
+	var (
+		systemInstruction *genai.Content
+		tools             []*genai.Tool
+		config            *genai.GenerateContentConfig
+	)
+	if r.Config != nil {
+		systemInstruction = r.Config.SystemInstruction
+		tools = r.Config.Tools
+		// Shallow copy, so that the fields reported in their own sections can
+		// be cleared without modifying r.
+		c := *r.Config
+		c.SystemInstruction = nil
+		c.Tools = nil
+		config = &c
+	}
+	return []requestSection{
+		{name: "model", text: r.Model},
+		{name: "contents", text: toIndentedJSON(r.Contents)},
+		{name: "system instruction", text: toIndentedJSON(systemInstruction)},
+		{name: "tools", text: toIndentedJSON(tools)},
+		{name: "config", text: toIndentedJSON(config)},
+	}
+}
+
+func toIndentedJSON(v any) string {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("<failed to marshal: %v>", err)
+	}
+	if string(b) == "null" {
+		return ""
+	}
+	return string(b)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffContext is the number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// diffLines writes the diff of the lines x and y to w, as unified hunks.
+func diffLines(w *strings.Builder, x, y []string) {
+	ops := diffOps(nil, x, y)
+	// Each hunk spans the ops from the first to the last of a run of
+	// changes separated by at most 2*diffContext unchanged lines, plus the
+	// context around it.
+	for start := 0; start < len(ops); {
+		first := start
+		for first < len(ops) && ops[first] == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			return
+		}
+		last, unchanged := first, 0
+		for i := first + 1; i < len(ops) && unchanged <= 2*diffContext; i++ {
+			if ops[i] == ' ' {
+				unchanged++
+			} else {
+				last, unchanged = i, 0
+			}
+		}
+		from, to := max(first-diffContext, start), min(last+diffContext+1, len(ops))
+		writeHunk(w, x, y, ops, from, to)
+		start = to
+	}
+}
+
+// writeHunk writes the hunk of ops[from:to] to w.
+func writeHunk(w *strings.Builder, x, y []string, ops []byte, from, to int) {
+	// i and j are the indexes in x and y of the first line of the hunk.
+	i, j := 0, 0
+	for _, op := range ops[:from] {
+		if op != '+' {
+			i++
+		}
+		if op != '-' {
+			j++
+		}
+	}
+	var lines strings.Builder
+	xLen, yLen := 0, 0
+	for _, op := range ops[from:to] {
+		switch op {
+		case ' ':
+			fmt.Fprintf(&lines, " %s\n", x[i+xLen])
+			xLen++
+			yLen++
+		case '-':
+			fmt.Fprintf(&lines, "-%s\n", x[i+xLen])
+			xLen++
+		case '+':
+			fmt.Fprintf(&lines, "+%s\n", y[j+yLen])
+			yLen++
+		}
+	}
+	fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(i, xLen), hunkRange(j, yLen))
+	w.WriteString(lines.String())
+}
+
+// hunkRange formats the range of n lines starting at the index start, as
+// in the header of a unified diff hunk.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprint(start + 1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
+
+// diffOps appends to ops the edit script turning x into y: ' ' for a line
+// kept, '-' for a line removed from x and '+' for a line added from y. It
+// uses the linear space variant of the Myers algorithm, splitting the
+// problem at the middle snake of a shortest edit script.
+func diffOps(ops []byte, x, y []string) []byte {
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+	ops = appendOps(ops, ' ', prefix)
+	x, y = x[prefix:len(x)-suffix], y[prefix:len(y)-suffix]
+	switch {
+	case len(x) == 0:
+		ops = appendOps(ops, '+', len(y))
+	case len(y) == 0:
+		ops = appendOps(ops, '-', len(x))
+	default:
+		// Without common prefix and suffix, the edit script has at least
+		// two edits, so both halves are smaller than the problem.
+		x0, y0, x1, y1 := middleSnake(x, y)
+		ops = diffOps(ops, x[:x0], y[:y0])
+		ops = appendOps(ops, ' ', x1-x0)
+		ops = diffOps(ops, x[x1:], y[y1:])
+	}
+	return appendOps(ops, ' ', suffix)
+}
+
+func appendOps(ops []byte, op byte, n int) []byte {
+	for range n {
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// middleSnake returns the start and end of the middle snake of a shortest
+// edit script of x and y, found by searching from both ends at once. The
+// backward search runs on the reversed lines.
+func middleSnake(x, y []string) (x0, y0, x1, y1 int) {
+	n, m := len(x), len(y)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	// forward[off+k] is the furthest x reached on the diagonal k = x-y from
+	// the start, backward[off+k] the one reached on the diagonal k of the
+	// reversed lines from the end.
+	off := maxD + 1
+	forward := make([]int, 2*off+1)
+	backward := make([]int, 2*off+1)
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			sx, sy, ex, ey := furthest(forward, off, k, d, func(i, j int) bool { return x[i] == y[j] }, n, m)
+			// The diagonal k is the diagonal delta-k of the reversed lines.
+			if kb := delta - k; odd && kb >= -(d-1) && kb <= d-1 && ex >= n-backward[off+kb] {
+				return sx, sy, ex, ey
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			su, sv, eu, ev := furthest(backward, off, k, d, func(i, j int) bool { return x[n-1-i] == y[m-1-j] }, n, m)
+			if kf := delta - k; !odd && kf >= -d && kf <= d && forward[off+kf] >= n-eu {
+				return n - eu, m - ev, n - su, m - sv
+			}
+		}
+	}
+	// Unreachable: a path of at most n+m edits always exists.
+	return 0, 0, n, m
+}
+
+// furthest extends the furthest reaching path of d edits on the diagonal k,
+// records its end in v, and returns the start and end of its final snake.
+func furthest(v []int, off, k, d int, equal func(i, j int) bool, n, m int) (sx, sy, ex, ey int) {
+	if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+		sx = v[off+k+1]
+	} else {
+		sx = v[off+k-1] + 1
+	}
+	sy = sx - k
+	ex, ey = sx, sy
+	for ex < n && ey < m && equal(ex, ey) {
+		ex++
+		ey++
+	}
+	v[off+k] = ex
+	return sx, sy, ex, ey
+}
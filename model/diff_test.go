@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestDiffRequests(t *testing.T) {
+	base := func() *model.LLMRequest {
+		return &model.LLMRequest{
+			Model: "gemini-2.5-flash",
+			Contents: []*genai.Content{
+				genai.NewContentFromText("hello", genai.RoleUser),
+			},
+			Config: &genai.GenerateContentConfig{
+				SystemInstruction: genai.NewContentFromText("be helpful", genai.RoleUser),
+				Temperature:       genai.Ptr[float32](0.5),
+				Tools: []*genai.Tool{{
+					FunctionDeclarations: []*genai.FunctionDeclaration{{
+						Name:        "search",
+						Description: "searches the web",
+					}},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		modify       func(r *model.LLMRequest)
+		wantSections []string
+		wantLines    []string
+	}{
+		{
+			name:   "equal",
+			modify: func(r *model.LLMRequest) {},
+		},
+		{
+			name: "contents",
+			modify: func(r *model.LLMRequest) {
+				r.Contents = append(r.Contents, genai.NewContentFromText("hi there", genai.RoleModel))
+			},
+			wantSections: []string{"contents"},
+			wantLines:    []string{`+        "text": "hi there"`},
+		},
+		{
+			name: "system instruction",
+			modify: func(r *model.LLMRequest) {
+				r.Config.SystemInstruction = genai.NewContentFromText("be concise", genai.RoleUser)
+			},
+			wantSections: []string{"system instruction"},
+			wantLines: []string{
+				`-      "text": "be helpful"`,
+				`+      "text": "be concise"`,
+			},
+		},
+		{
+			name: "tool declarations",
+			modify: func(r *model.LLMRequest) {
+				r.Config.Tools[0].FunctionDeclarations[0].Description = "searches news"
+			},
+			wantSections: []string{"tools"},
+			wantLines: []string{
+				`-        "description": "searches the web",`,
+				`+        "description": "searches news",`,
+			},
+		},
+		{
+			name: "model and config",
+			modify: func(r *model.LLMRequest) {
+				r.Model = "gemini-2.5-pro"
+				r.Config.Temperature = genai.Ptr[float32](1)
+			},
+			wantSections: []string{"model", "config"},
+			wantLines: []string{
+				"-gemini-2.5-flash",
+				"+gemini-2.5-pro",
+				`-  "temperature": 0.5`,
+				`+  "temperature": 1`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := base(), base()
+			tt.modify(b)
+
+			got := model.DiffRequests(a, b)
+
+			if len(tt.wantSections) == 0 {
+				if got != "" {
+					t.Errorf("DiffRequests() = %q, want empty", got)
+				}
+				return
+			}
+			lines := strings.Split(got, "\n")
+			var gotSections []string
+			for _, l := range lines {
+				if name, ok := strings.CutPrefix(l, "--- "); ok {
+					gotSections = append(gotSections, name)
+				}
+			}
+			if strings.Join(gotSections, ",") != strings.Join(tt.wantSections, ",") {
+				t.Errorf("DiffRequests() sections = %v, want %v\n%s", gotSections, tt.wantSections, got)
+			}
+			for _, want := range tt.wantLines {
+				if !strings.Contains(got, want+"\n") {
+					t.Errorf("DiffRequests() missing line %q in:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffRequests_Nil(t *testing.T) {
+	if got := model.DiffRequests(nil, &model.LLMRequest{}); got != "" {
+		t.Errorf("DiffRequests(nil, empty) = %q, want empty", got)
+	}
+	got := model.DiffRequests(nil, &model.LLMRequest{Model: "m"})
+	if want := "--- model\n@@ -0,0 +1 @@\n+m\n"; got != want {
+		t.Errorf("DiffRequests(nil, req) = %q, want %q", got, want)
+	}
+}
+
+func TestDiffRequests_Hunks(t *testing.T) {
+	a, b := &model.LLMRequest{}, &model.LLMRequest{}
+	for i := range 10 {
+		a.Contents = append(a.Contents, genai.NewContentFromText(fmt.Sprint("message ", i), genai.RoleUser))
+		b.Contents = append(b.Contents, genai.NewContentFromText(fmt.Sprint("message ", i), genai.RoleUser))
+	}
+	b.Contents[1].Parts[0].Text = "edited 1"
+	b.Contents[8].Parts[0].Text = "edited 8"
+
+	want := `--- contents
+@@ -10,7 +10,7 @@
+   {
+     "parts": [
+       {
+-        "text": "message 1"
++        "text": "edited 1"
+       }
+     ],
+     "role": "user"
+@@ -66,7 +66,7 @@
+   {
+     "parts": [
+       {
+-        "text": "message 8"
++        "text": "edited 8"
+       }
+     ],
+     "role": "user"
+`
+	if got := model.DiffRequests(a, b); got != want {
+		t.Errorf("DiffRequests() = \n%s\nwant\n%s", got, want)
+	}
+}
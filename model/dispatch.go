@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/genai"
+)
+
+// ErrUnknownTool is returned by [LLMRequest.DispatchCall] when the request
+// has no tool with the name of the call.
+var ErrUnknownTool = errors.New("unknown tool")
+
+// DispatchCall runs the tool of r.Tools called by call, with the arguments of
+// the call, and returns its result. It is meant for code that drives the
+// model and tool loop itself instead of using an agent.
+//
+// tc is the tool.Context passed to the tool. It is untyped because the tool
+// package depends on this one. The tool must have a method
+// Run(tool.Context, any) (map[string]any, error), like function tools do.
+// Callbacks and plugins are not run.
+func (r *LLMRequest) DispatchCall(ctx context.Context, tc any, call *genai.FunctionCall) (map[string]any, error) {
+	if call == nil {
+		return nil, errors.New("function call is nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	t, ok := r.Tools[call.Name]
+	if !ok || t == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTool, call.Name)
+	}
+
+	run := reflect.ValueOf(t).MethodByName("Run")
+	if !run.IsValid() || !isRunSignature(run.Type()) {
+		return nil, fmt.Errorf("tool %q of type %T cannot be run", call.Name, t)
+	}
+	tcValue := reflect.ValueOf(tc)
+	if !tcValue.IsValid() || !tcValue.Type().AssignableTo(run.Type().In(0)) {
+		return nil, fmt.Errorf("tool %q: tool context of type %T is not a %v", call.Name, tc, run.Type().In(0))
+	}
+	args := call.Args
+	if args == nil {
+		args = map[string]any{}
+	}
+	out := run.Call([]reflect.Value{tcValue, reflect.ValueOf(args)})
+
+	result, _ := out[0].Interface().(map[string]any)
+	err, _ := out[1].Interface().(error)
+	return result, err
+}
+
+// isRunSignature reports whether t is the type of a method
+// Run(tool.Context, any) (map[string]any, error).
+func isRunSignature(t reflect.Type) bool {
+	return t.NumIn() == 2 && t.NumOut() == 2 &&
+		t.In(0).Kind() == reflect.Interface &&
+		t.In(1) == reflect.TypeFor[any]() &&
+		t.Out(0) == reflect.TypeFor[map[string]any]() &&
+		t.Out(1) == reflect.TypeFor[error]()
+}
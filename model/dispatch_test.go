@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestLLMRequest_DispatchCall(t *testing.T) {
+	type Args struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+	sum, err := functiontool.New(functiontool.Config{
+		Name: "sum",
+	}, func(_ tool.Context, args Args) (map[string]any, error) {
+		return map[string]any{"sum": args.A + args.B}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &model.LLMRequest{Tools: map[string]any{"sum": sum, "broken": "not a tool"}}
+	tc := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "call_1", &session.EventActions{}, nil)
+
+	got, err := req.DispatchCall(t.Context(), tc, &genai.FunctionCall{Name: "sum", Args: map[string]any{"a": 1, "b": 2}})
+	if err != nil {
+		t.Fatalf("DispatchCall() failed: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"sum": float64(3)}, got); diff != "" {
+		t.Errorf("DispatchCall() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := req.DispatchCall(t.Context(), tc, &genai.FunctionCall{Name: "sum", Args: map[string]any{"a": "one"}}); err == nil {
+		t.Error("DispatchCall() with invalid arguments succeeded, want the tool's error")
+	}
+	if _, err := req.DispatchCall(t.Context(), tc, &genai.FunctionCall{Name: "missing"}); !errors.Is(err, model.ErrUnknownTool) {
+		t.Errorf("DispatchCall() error = %v, want %v", err, model.ErrUnknownTool)
+	}
+	if _, err := req.DispatchCall(t.Context(), tc, &genai.FunctionCall{Name: "broken"}); err == nil {
+		t.Error("DispatchCall() of a value without Run method succeeded, want error")
+	}
+	if _, err := req.DispatchCall(t.Context(), "not a tool context", &genai.FunctionCall{Name: "sum"}); err == nil {
+		t.Error("DispatchCall() with an invalid tool context succeeded, want error")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// Exchange is a few-shot example: a user content and the model content
+// answering it.
+type Exchange struct {
+	User  *genai.Content
+	Model *genai.Content
+}
+
+// AppendFewShot adds the examples as prior user/model turns of the request.
+// They are inserted before the current turn: before the last user message
+// and the function calls and responses following it, e.g. in the middle of
+// a tool loop, so that the examples never separate a function call from its
+// response. After a completed model turn, they are appended to the
+// contents.
+//
+// The roles of the example contents are set to user and model respectively,
+// the given contents are not modified. Exchanges with a nil user or model
+// content are skipped.
+func (r *LLMRequest) AppendFewShot(examples []Exchange) {
+	var turns []*genai.Content
+	for _, ex := range examples {
+		if ex.User == nil || ex.Model == nil {
+			continue
+		}
+		turns = append(turns,
+			&genai.Content{Role: genai.RoleUser, Parts: ex.User.Parts},
+			&genai.Content{Role: genai.RoleModel, Parts: ex.Model.Parts},
+		)
+	}
+	if len(turns) == 0 {
+		return
+	}
+	pos := len(r.Contents)
+	for i := len(r.Contents) - 1; i >= 0; i-- {
+		c := r.Contents[i]
+		if c == nil {
+			continue
+		}
+		if hasFunctionParts(c) {
+			pos = i
+			continue
+		}
+		if normalizedRole(c.Role) == genai.RoleUser {
+			pos = i
+		}
+		break
+	}
+	r.Contents = slices.Insert(r.Contents, pos, turns...)
+}
+
+// hasFunctionParts reports whether the content holds function calls or
+// responses.
+func hasFunctionParts(c *genai.Content) bool {
+	return slices.ContainsFunc(c.Parts, func(p *genai.Part) bool {
+		return p != nil && (p.FunctionCall != nil || p.FunctionResponse != nil)
+	})
+}
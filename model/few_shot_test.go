@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestLLMRequest_AppendFewShot(t *testing.T) {
+	examples := []model.Exchange{
+		{
+			User:  genai.NewContentFromText("2+2", ""),
+			Model: genai.NewContentFromText("4", ""),
+		},
+		{
+			User:  genai.NewContentFromText("3*3", genai.RoleUser),
+			Model: genai.NewContentFromText("9", genai.RoleModel),
+		},
+		{User: genai.NewContentFromText("skipped", genai.RoleUser)},
+	}
+	wantExamples := []*genai.Content{
+		genai.NewContentFromText("2+2", genai.RoleUser),
+		genai.NewContentFromText("4", genai.RoleModel),
+		genai.NewContentFromText("3*3", genai.RoleUser),
+		genai.NewContentFromText("9", genai.RoleModel),
+	}
+	question := genai.NewContentFromText("5-1", genai.RoleUser)
+	answer := genai.NewContentFromText("4", genai.RoleModel)
+	call := genai.NewContentFromFunctionCall("calc", map[string]any{"expr": "5-1"}, genai.RoleModel)
+	response := genai.NewContentFromFunctionResponse("calc", map[string]any{"result": 4}, genai.RoleUser)
+
+	testCases := []struct {
+		name     string
+		contents []*genai.Content
+		want     []*genai.Content
+	}{
+		{
+			name: "empty request",
+			want: wantExamples,
+		},
+		{
+			name:     "before the user message",
+			contents: []*genai.Content{question},
+			want:     append(append([]*genai.Content{}, wantExamples...), question),
+		},
+		{
+			name:     "before the user message of a tool loop",
+			contents: []*genai.Content{question, call, response},
+			want:     append(append([]*genai.Content{}, wantExamples...), question, call, response),
+		},
+		{
+			name:     "after a model turn before a tool loop",
+			contents: []*genai.Content{question, answer, call, response},
+			want:     append(append([]*genai.Content{question, answer}, wantExamples...), call, response),
+		},
+		{
+			name:     "after a model turn",
+			contents: []*genai.Content{question, answer},
+			want:     append([]*genai.Content{question, answer}, wantExamples...),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &model.LLMRequest{Contents: tc.contents}
+			req.AppendFewShot(examples)
+			if diff := cmp.Diff(tc.want, req.Contents); diff != "" {
+				t.Errorf("Contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
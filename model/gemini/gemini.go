@@ -31,6 +31,11 @@ import (
 	"google.golang.org/adk/model"
 )
 
+// maxStopSequences is the maximum number of stop sequences accepted by the
+// Gemini API. The Gemini models have no default stop sequences: they call
+// functions natively, without a terminator to stop at.
+const maxStopSequences = 5
+
 // TODO: test coverage
 type geminiModel struct {
 	client             *genai.Client
@@ -79,6 +84,15 @@ func (m *geminiModel) GenerateContent(ctx context.Context, req *model.LLMRequest
 		req.Config.HTTPOptions.Headers = make(http.Header)
 	}
 	m.addHeaders(req.Config.HTTPOptions.Headers)
+	if len(req.Config.StopSequences) > 0 {
+		stopSequences, err := model.MergeStopSequences(req.Config.StopSequences, nil, maxStopSequences)
+		if err != nil {
+			return func(yield func(*model.LLMResponse, error) bool) {
+				yield(nil, err)
+			}
+		}
+		req.Config.StopSequences = stopSequences
+	}
 
 	if stream {
 		return m.generateStream(ctx, req)
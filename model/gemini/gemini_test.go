@@ -188,6 +188,30 @@ func TestModel_TrackingHeaders(t *testing.T) {
 	})
 }
 
+func TestModel_TooManyStopSequences(t *testing.T) {
+	cfg := &genai.ClientConfig{
+		HTTPClient: &http.Client{Transport: &headerInterceptor{
+			check: func(*http.Request) { t.Error("the request was sent to the model") },
+		}},
+		APIKey: "fakekey",
+	}
+	geminiModel, err := NewModel(t.Context(), "gemini-2.0-flash", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &model.LLMRequest{
+		Contents: genai.Text("ping"),
+		Config:   &genai.GenerateContentConfig{StopSequences: []string{"a", "b", "c", "d", "e", "f"}},
+	}
+	var gotErr error
+	for _, err := range geminiModel.GenerateContent(t.Context(), req, false) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Error("GenerateContent() with 6 stop sequences succeeded, want an error")
+	}
+}
+
 // newGeminiTestClientConfig returns the genai.ClientConfig configured for record and replay.
 func newGeminiTestClientConfig(t *testing.T, rrfile string) *genai.ClientConfig {
 	t.Helper()
@@ -17,7 +17,10 @@ package model
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
+	"slices"
 
 	"google.golang.org/genai"
 )
@@ -35,6 +38,70 @@ type LLMRequest struct {
 	Config   *genai.GenerateContentConfig
 
 	Tools map[string]any `json:"-"`
+
+	// RoleAlternation controls how AppendContent handles a content whose role
+	// matches the role of the last content in Contents.
+	RoleAlternation RoleAlternationPolicy `json:"-"`
+}
+
+// RoleAlternationPolicy defines how [LLMRequest.AppendContent] keeps the
+// user/model roles of the request contents alternating.
+type RoleAlternationPolicy int
+
+const (
+	// RoleAlternationMerge merges the parts of consecutive contents with the
+	// same role into a single content. Multiple function responses answering
+	// parallel function calls end up in one user content, as expected by
+	// Gemini.
+	RoleAlternationMerge RoleAlternationPolicy = iota
+	// RoleAlternationError makes AppendContent return ErrRolesNotAlternating
+	// instead of appending a content with the same role as the last one.
+	RoleAlternationError
+)
+
+// ErrRolesNotAlternating is returned by [LLMRequest.AppendContent] when the
+// appended content would break the user/model role alternation.
+var ErrRolesNotAlternating = errors.New("content roles are not alternating")
+
+// AppendContent appends c to the request contents, keeping the roles of the
+// contents alternating according to r.RoleAlternation.
+//
+// An empty role is treated as the user role. Contents already present in the
+// request are never modified in place, merging replaces the last content with
+// a new one.
+func (r *LLMRequest) AppendContent(c *genai.Content) error {
+	if c == nil {
+		return nil
+	}
+	if len(r.Contents) == 0 {
+		r.Contents = append(r.Contents, c)
+		return nil
+	}
+	last := r.Contents[len(r.Contents)-1]
+	if last == nil || normalizedRole(last.Role) != normalizedRole(c.Role) {
+		r.Contents = append(r.Contents, c)
+		return nil
+	}
+
+	switch r.RoleAlternation {
+	case RoleAlternationMerge:
+		r.Contents[len(r.Contents)-1] = &genai.Content{
+			Role:  last.Role,
+			Parts: slices.Concat(last.Parts, c.Parts),
+		}
+		return nil
+	case RoleAlternationError:
+		return fmt.Errorf("%w: content with role %q follows another content with the same role", ErrRolesNotAlternating, normalizedRole(c.Role))
+	default:
+		return fmt.Errorf("unknown role alternation policy: %d", r.RoleAlternation)
+	}
+}
+
+func normalizedRole(role string) string {
+	if role == "" {
+		return genai.RoleUser
+	}
+	return role
 }
 
 // LLMResponse is the raw LLM response.
@@ -15,9 +15,11 @@
 package model_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/internal/llminternal/converters"
@@ -242,3 +244,80 @@ func TestCreateResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestLLMRequest_AppendContent(t *testing.T) {
+	userText := genai.NewContentFromText("hi", genai.RoleUser)
+	modelCall := genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel)
+	response1 := genai.NewContentFromFunctionResponse("get_weather", map[string]any{"temp": 20}, genai.RoleUser)
+	response2 := genai.NewContentFromFunctionResponse("get_time", map[string]any{"time": "10:00"}, genai.RoleUser)
+
+	testCases := []struct {
+		name    string
+		policy  model.RoleAlternationPolicy
+		initial []*genai.Content
+		append  []*genai.Content
+		want    []*genai.Content
+		wantErr error
+	}{
+		{
+			name:   "alternating roles",
+			append: []*genai.Content{userText, modelCall, response1},
+			want:   []*genai.Content{userText, modelCall, response1},
+		},
+		{
+			name:   "merge consecutive function responses",
+			append: []*genai.Content{userText, modelCall, response1, response2},
+			want: []*genai.Content{userText, modelCall, {
+				Role:  genai.RoleUser,
+				Parts: []*genai.Part{response1.Parts[0], response2.Parts[0]},
+			}},
+		},
+		{
+			name:    "empty role is treated as user",
+			initial: []*genai.Content{{Parts: []*genai.Part{{Text: "a"}}}},
+			append:  []*genai.Content{genai.NewContentFromText("b", genai.RoleUser)},
+			want:    []*genai.Content{{Parts: []*genai.Part{{Text: "a"}, {Text: "b"}}}},
+		},
+		{
+			name:    "error policy",
+			policy:  model.RoleAlternationError,
+			append:  []*genai.Content{userText, userText},
+			want:    []*genai.Content{userText},
+			wantErr: model.ErrRolesNotAlternating,
+		},
+		{
+			name:   "nil content is ignored",
+			append: []*genai.Content{userText, nil},
+			want:   []*genai.Content{userText},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &model.LLMRequest{Contents: tc.initial, RoleAlternation: tc.policy}
+			var err error
+			for _, c := range tc.append {
+				if err = req.AppendContent(c); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("AppendContent() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, req.Contents); diff != "" {
+				t.Errorf("Contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLLMRequest_AppendContentDoesNotMutateExisting(t *testing.T) {
+	first := genai.NewContentFromText("a", genai.RoleUser)
+	req := &model.LLMRequest{Contents: []*genai.Content{first}}
+	if err := req.AppendContent(genai.NewContentFromText("b", genai.RoleUser)); err != nil {
+		t.Fatalf("AppendContent() failed: %v", err)
+	}
+	if len(first.Parts) != 1 {
+		t.Errorf("AppendContent() modified the existing content: %+v", first)
+	}
+}
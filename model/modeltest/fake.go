@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modeltest provides a fake model to test agents and tools without
+// calling a real model.
+//
+// A FakeModel returns scripted responses, e.g. a function call then a text
+// answer, to drive the tool-calling loop of an agent deterministically:
+//
+//	llm := modeltest.NewFakeModel(
+//		modeltest.FunctionCall("get_weather", map[string]any{"city": "Paris"}),
+//		modeltest.Text("It is sunny in Paris."),
+//	)
+//	a, err := llmagent.New(llmagent.Config{Name: "weather", Model: llm, Tools: tools})
+//	// Run the agent, then check the requests received by llm.
+package modeltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// ErrNoMoreResponses is returned by a FakeModel called more times than it
+// has responses.
+var ErrNoMoreResponses = errors.New("fake model has no more responses")
+
+// FakeModel is a model.LLM returning queued responses. It is safe for
+// concurrent use.
+type FakeModel struct {
+	mu        sync.Mutex
+	responses []*model.LLMResponse
+	requests  []*model.LLMRequest
+}
+
+// NewFakeModel returns a FakeModel returning the responses in order, one
+// per call, whether streamed or not.
+func NewFakeModel(responses ...*model.LLMResponse) *FakeModel {
+	return &FakeModel{responses: responses}
+}
+
+// Text returns a model response with the given text.
+func Text(text string) *model.LLMResponse {
+	return &model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}
+}
+
+// FunctionCall returns a model response calling the named tool with args.
+func FunctionCall(name string, args map[string]any) *model.LLMResponse {
+	return &model.LLMResponse{Content: genai.NewContentFromFunctionCall(name, args, genai.RoleModel)}
+}
+
+// Name implements model.LLM.
+func (m *FakeModel) Name() string {
+	return "fake-model"
+}
+
+// GenerateContent implements model.LLM. It records req, and returns the
+// next queued response, or ErrNoMoreResponses.
+func (m *FakeModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.mu.Lock()
+		m.requests = append(m.requests, req)
+		n := len(m.requests)
+		var resp *model.LLMResponse
+		if len(m.responses) > 0 {
+			resp, m.responses = m.responses[0], m.responses[1:]
+		}
+		m.mu.Unlock()
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, err)
+			return
+		}
+		if resp == nil {
+			yield(nil, fmt.Errorf("%w: call #%d", ErrNoMoreResponses, n))
+			return
+		}
+		yield(resp, nil)
+	}
+}
+
+// Requests returns the requests received so far, in order.
+func (m *FakeModel) Requests() []*model.LLMRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*model.LLMRequest(nil), m.requests...)
+}
+
+// ToolNames returns the sorted names of the tools of the i-th request
+// received, e.g. to check which tools an agent offered the model at each
+// step. It returns nil if there is no such request.
+func (m *FakeModel) ToolNames(i int) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i < 0 || i >= len(m.requests) {
+		return nil
+	}
+	return m.requests[i].ToolNames()
+}
+
+// Remaining returns the number of queued responses not returned yet.
+func (m *FakeModel) Remaining() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.responses)
+}
+
+var _ model.LLM = (*FakeModel)(nil)
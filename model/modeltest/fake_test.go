@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modeltest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/modeltest"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestFakeModel(t *testing.T) {
+	type weatherArgs struct {
+		City string `json:"city"`
+	}
+	var gotCity string
+	weather, err := functiontool.New(functiontool.Config{Name: "get_weather", Description: "Gets the weather."},
+		func(_ tool.Context, args weatherArgs) (string, error) {
+			gotCity = args.City
+			return "sunny", nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := modeltest.NewFakeModel(
+		modeltest.FunctionCall("get_weather", map[string]any{"city": "Paris"}),
+		modeltest.Text("It is sunny in Paris."),
+	)
+	a, err := llmagent.New(llmagent.Config{Name: "weather_agent", Model: llm, Tools: []tool.Tool{weather}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texts, err := testutil.CollectTextParts(testutil.NewTestAgentRunner(t, a).Run(t, "session", "Weather in Paris?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"It is sunny in Paris."}, texts); diff != "" {
+		t.Errorf("texts mismatch (-want +got):\n%s", diff)
+	}
+	if gotCity != "Paris" {
+		t.Errorf("got tool called for %q, want Paris", gotCity)
+	}
+	if got := len(llm.Requests()); got != 2 || llm.Remaining() != 0 {
+		t.Errorf("got %d requests and %d remaining responses, want 2 and 0", got, llm.Remaining())
+	}
+	for i := range 2 {
+		if diff := cmp.Diff([]string{"get_weather"}, llm.ToolNames(i)); diff != "" {
+			t.Errorf("ToolNames(%d) mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	if got := llm.ToolNames(2); got != nil {
+		t.Errorf("ToolNames(2) = %v, want nil", got)
+	}
+
+	// The queue is exhausted.
+	for _, err := range llm.GenerateContent(t.Context(), &model.LLMRequest{}, false) {
+		if !errors.Is(err, modeltest.ErrNoMoreResponses) {
+			t.Errorf("got error %v, want %v", err, modeltest.ErrNoMoreResponses)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// Price is the price of the tokens of a model, per million tokens.
+type Price struct {
+	// Input is the price of the prompt tokens.
+	Input float64
+	// Output is the price of the response tokens, thinking tokens included.
+	Output float64
+	// CachedInput is the price of the prompt tokens served from the context
+	// cache. If zero, cached tokens are charged at the Input price.
+	CachedInput float64
+}
+
+// PricingTable maps model names to their prices.
+//
+// ADK does not ship prices, since they change over time and depend on the
+// provider and the account. Users supply the table matching their contract.
+type PricingTable map[string]Price
+
+// versionSuffix matches the version a model name is extended with, e.g.
+// "-001", "-preview-05-20", "-exp-03-25", "-latest" or "@001".
+var versionSuffix = regexp.MustCompile(`^(@|-(\d|preview|exp|latest)($|[-.\d]))`)
+
+// Lookup returns the price of the model.
+//
+// Model names are matched ignoring case and resource path prefixes such as
+// "models/". If there is no exact match, the longest name in the table that
+// the model name extends with a version is used, so that "gemini-2.5-flash"
+// matches versioned names such as "gemini-2.5-flash-001",
+// "gemini-2.5-flash-preview-05-20" or "gemini-2.5-flash@001", but not other
+// models such as "gemini-2.5-flash-lite".
+func (t PricingTable) Lookup(modelName string) (Price, bool) {
+	name := strings.ToLower(modelName[strings.LastIndex(modelName, "/")+1:])
+	if p, ok := t[name]; ok {
+		return p, true
+	}
+	var (
+		best  Price
+		found bool
+		size  int
+	)
+	for k, p := range t {
+		k = strings.ToLower(k)
+		if k == name {
+			return p, true
+		}
+		if rest, ok := strings.CutPrefix(name, k); ok && versionSuffix.MatchString(rest) && len(k) > size {
+			best, found, size = p, true, len(k)
+		}
+	}
+	return best, found
+}
+
+// CostOf returns the cost of the usage of the model, or 0 if the model is not
+// in the table.
+func (t PricingTable) CostOf(usage *genai.GenerateContentResponseUsageMetadata, modelName string) float64 {
+	if usage == nil {
+		return 0
+	}
+	p, ok := t.Lookup(modelName)
+	if !ok {
+		return 0
+	}
+	cachedPrice := p.CachedInput
+	if cachedPrice == 0 {
+		cachedPrice = p.Input
+	}
+	cached := float64(usage.CachedContentTokenCount)
+	input := float64(usage.PromptTokenCount+usage.ToolUsePromptTokenCount) - cached
+	output := float64(usage.CandidatesTokenCount + usage.ThoughtsTokenCount)
+	return (input*p.Input + cached*cachedPrice + output*p.Output) / 1e6
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestPricingTable_CostOf(t *testing.T) {
+	prices := model.PricingTable{
+		"gemini-2.5-flash":      {Input: 0.30, Output: 2.50, CachedInput: 0.075},
+		"gemini-2.5-flash-lite": {Input: 0.10, Output: 0.40},
+	}
+	usage := &genai.GenerateContentResponseUsageMetadata{
+		PromptTokenCount:        1_000_000,
+		CachedContentTokenCount: 400_000,
+		CandidatesTokenCount:    100_000,
+		ThoughtsTokenCount:      100_000,
+	}
+
+	tests := []struct {
+		name      string
+		modelName string
+		usage     *genai.GenerateContentResponseUsageMetadata
+		want      float64
+	}{
+		{
+			name:      "exact match",
+			modelName: "gemini-2.5-flash",
+			usage:     usage,
+			want:      0.6*0.30 + 0.4*0.075 + 0.2*2.50,
+		},
+		{
+			name:      "cached tokens at input price when no cached price",
+			modelName: "gemini-2.5-flash-lite",
+			usage:     usage,
+			want:      1*0.10 + 0.2*0.40,
+		},
+		{
+			name:      "versioned name with path matches the longest prefix",
+			modelName: "models/Gemini-2.5-Flash-Lite-001",
+			usage:     usage,
+			want:      1*0.10 + 0.2*0.40,
+		},
+		{
+			name:      "preview version matches the base model",
+			modelName: "gemini-2.5-flash-preview-05-20",
+			usage:     usage,
+			want:      0.6*0.30 + 0.4*0.075 + 0.2*2.50,
+		},
+		{
+			name:      "other model does not match its prefix",
+			modelName: "gemini-2.5-flash-image",
+			usage:     usage,
+			want:      0,
+		},
+		{
+			name:      "unknown model",
+			modelName: "gemini-2.5-pro",
+			usage:     usage,
+			want:      0,
+		},
+		{
+			name:      "nil usage",
+			modelName: "gemini-2.5-flash",
+			want:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prices.CostOf(tt.usage, tt.modelName)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CostOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricingTable_Lookup(t *testing.T) {
+	flash := model.Price{Input: 0.30, Output: 2.50}
+	prices := model.PricingTable{"gemini-2.5-flash": flash}
+
+	tests := []struct {
+		modelName string
+		want      bool
+	}{
+		{modelName: "gemini-2.5-flash", want: true},
+		{modelName: "gemini-2.5-flash-001", want: true},
+		{modelName: "gemini-2.5-flash@001", want: true},
+		{modelName: "gemini-2.5-flash-preview-05-20", want: true},
+		{modelName: "gemini-2.5-flash-lite", want: false},
+		{modelName: "gemini-2.5-flash-lite-001", want: false},
+		{modelName: "gemini-2.5-flashy", want: false},
+	}
+	for _, tt := range tests {
+		got, ok := prices.Lookup(tt.modelName)
+		if ok != tt.want {
+			t.Errorf("Lookup(%q) found = %t, want %t", tt.modelName, ok, tt.want)
+		}
+		if ok && got != flash {
+			t.Errorf("Lookup(%q) = %v, want %v", tt.modelName, got, flash)
+		}
+	}
+}
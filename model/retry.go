@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// RetryOptions configures the retries of NewRetryModel.
+type RetryOptions struct {
+	// MaxRetries is the maximum number of retries of a call, after the
+	// first attempt. Defaults to 3, a negative value disables the retries.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for each next
+	// retry. Defaults to 1 second.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts. Defaults to 30 seconds.
+	MaxDelay time.Duration
+	// RetryableCodes are the HTTP status codes of the genai.APIError
+	// errors that are retried. Defaults to 429, 500, 502, 503 and 504.
+	RetryableCodes []int
+}
+
+var defaultRetryableCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// NewRetryModel returns an LLM that retries the calls to m failing with a
+// retryable error, e.g. the 429 and 503 errors of Gemini when it is
+// overloaded.
+//
+// The delay between the attempts grows exponentially, with a random jitter
+// so that concurrent callers do not retry in lockstep. A call is not
+// retried if its context would expire before the next attempt, nor, for
+// streamed calls, once a response was yielded. When all the attempts fail,
+// the error of the last one is returned.
+func NewRetryModel(m LLM, opts RetryOptions) LLM {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = time.Second
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 30 * time.Second
+	}
+	if opts.RetryableCodes == nil {
+		opts.RetryableCodes = defaultRetryableCodes
+	}
+	return &retryLLM{LLM: m, opts: opts}
+}
+
+type retryLLM struct {
+	LLM
+	opts RetryOptions
+}
+
+func (m *retryLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		for attempt := 0; ; attempt++ {
+			yielded := false
+			var callErr error
+			for resp, err := range m.LLM.GenerateContent(ctx, req, stream) {
+				if err != nil && !yielded {
+					callErr = err
+					break
+				}
+				yielded = true
+				if !yield(resp, err) {
+					return
+				}
+			}
+			if callErr == nil {
+				return
+			}
+			if attempt >= m.opts.MaxRetries || !m.retryable(callErr) {
+				yield(nil, callErr)
+				return
+			}
+			delay := m.delay(attempt)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				yield(nil, callErr)
+				return
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				yield(nil, callErr)
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// retryable reports whether err is an API error with a retryable code.
+func (m *retryLLM) retryable(err error) bool {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(m.opts.RetryableCodes, apiErr.Code)
+	}
+	var apiErrPtr *genai.APIError
+	if errors.As(err, &apiErrPtr) && apiErrPtr != nil {
+		return slices.Contains(m.opts.RetryableCodes, apiErrPtr.Code)
+	}
+	return false
+}
+
+// delay returns the delay before the retry following the given attempt: a
+// random duration between half and all of the exponential backoff.
+func (m *retryLLM) delay(attempt int) time.Duration {
+	d := m.opts.MaxDelay
+	if attempt < 30 {
+		d = min(m.opts.BaseDelay<<attempt, m.opts.MaxDelay)
+	}
+	if d <= 0 {
+		// The shift overflowed.
+		d = m.opts.MaxDelay
+	}
+	return d/2 + rand.N(d/2+1)
+}
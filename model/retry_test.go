@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestNewRetryModel(t *testing.T) {
+	unavailable := genai.APIError{Code: 503, Status: "UNAVAILABLE"}
+	overloaded := fmt.Errorf("failed to call model: %w", &genai.APIError{Code: 429})
+	badRequest := genai.APIError{Code: 400}
+	opts := model.RetryOptions{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+	for _, tc := range []struct {
+		name         string
+		attempts     []streamAttempt
+		opts         model.RetryOptions
+		wantErr      error
+		wantFinal    string
+		wantRequests int
+	}{
+		{
+			name:         "retried until success",
+			attempts:     []streamAttempt{{err: unavailable}, {err: overloaded}, {chunks: []string{"Hello"}}},
+			opts:         opts,
+			wantFinal:    "Hello",
+			wantRequests: 3,
+		},
+		{
+			name:         "all attempts fail",
+			attempts:     []streamAttempt{{err: unavailable}, {err: unavailable}, {err: overloaded}},
+			opts:         opts,
+			wantErr:      overloaded,
+			wantRequests: 3,
+		},
+		{
+			name:         "not retryable",
+			attempts:     []streamAttempt{{err: badRequest}},
+			opts:         opts,
+			wantErr:      badRequest,
+			wantRequests: 1,
+		},
+		{
+			name:         "custom codes",
+			attempts:     []streamAttempt{{err: badRequest}, {chunks: []string{"Hello"}}},
+			opts:         model.RetryOptions{BaseDelay: time.Millisecond, RetryableCodes: []int{400}},
+			wantFinal:    "Hello",
+			wantRequests: 2,
+		},
+		{
+			name:         "stream already started",
+			attempts:     []streamAttempt{{chunks: []string{"Hel"}, err: unavailable}},
+			opts:         opts,
+			wantErr:      unavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "disabled",
+			attempts:     []streamAttempt{{err: unavailable}},
+			opts:         model.RetryOptions{MaxRetries: -1},
+			wantErr:      unavailable,
+			wantRequests: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			llm := &flakyStreamLLM{attempts: tc.attempts}
+			_, final, err := collectStream(model.NewRetryModel(llm, tc.opts), &model.LLMRequest{})
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Errorf("got error %v, want %v", err, tc.wantErr)
+				}
+			} else if err != nil || final != tc.wantFinal {
+				t.Errorf("got final %q, error %v, want %q", final, err, tc.wantFinal)
+			}
+			if len(llm.requests) != tc.wantRequests {
+				t.Errorf("got %d requests, want %d", len(llm.requests), tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestNewRetryModel_Deadline(t *testing.T) {
+	unavailable := genai.APIError{Code: 503}
+	llm := &flakyStreamLLM{attempts: []streamAttempt{{err: unavailable}, {chunks: []string{"Hello"}}}}
+	retrying := model.NewRetryModel(llm, model.RetryOptions{BaseDelay: time.Minute, MaxDelay: time.Minute})
+
+	// The context expires before the next attempt could be made.
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	start := time.Now()
+	var err error
+	for _, err = range retrying.GenerateContent(ctx, &model.LLMRequest{}, false) {
+	}
+	if !errors.As(err, &genai.APIError{}) {
+		t.Errorf("got error %v, want the error of the model", err)
+	}
+	if len(llm.requests) != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Errorf("got %d requests in %v, want 1 without waiting", len(llm.requests), time.Since(start))
+	}
+}
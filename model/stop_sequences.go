@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log"
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// MergeStopSequences merges the caller-provided stop sequences with the
+// model defaults.
+//
+// Empty and duplicate sequences are dropped. Provided sequences come first,
+// so when the result is longer than limit, the defaults are the ones left
+// out; the caller can find them as the defaults missing from the result. An
+// error is returned if the provided sequences alone are more than limit,
+// rather than sending the model only some of them. A non-positive limit
+// means no limit.
+func MergeStopSequences(provided, defaults []string, limit int) ([]string, error) {
+	var merged []string
+	seen := make(map[string]bool)
+	for i, seqs := range [][]string{provided, defaults} {
+		for _, s := range seqs {
+			if s == "" || seen[s] {
+				continue
+			}
+			seen[s] = true
+			merged = append(merged, s)
+		}
+		if limit > 0 && len(merged) > limit {
+			if i == 0 {
+				return nil, fmt.Errorf("%d stop sequences were provided, the model accepts at most %d", len(merged), limit)
+			}
+			merged = merged[:limit]
+		}
+	}
+	return merged, nil
+}
+
+// WithDefaultStopSequences returns an LLM that adds the given stop sequences
+// to the StopSequences of every request sent to llm, e.g. to reliably end the
+// tool call output of a model that needs a terminator.
+//
+// The sequences are merged with the ones set by the caller using
+// [MergeStopSequences] with the given limit, which should be the maximum
+// number of stop sequences accepted by the backend. The default sequences
+// left out because of the limit are logged, and requests with more stop
+// sequences than the limit fail.
+//
+// The models of this module do not wrap themselves: the Gemini models call
+// functions natively and need no terminator. Wrap the models emitting tool
+// calls as text, e.g. served through an OpenAI-compatible endpoint, with
+// their terminators.
+func WithDefaultStopSequences(llm LLM, stopSequences []string, limit int) LLM {
+	return &stopSequencesLLM{
+		LLM:           llm,
+		stopSequences: stopSequences,
+		limit:         limit,
+	}
+}
+
+type stopSequencesLLM struct {
+	LLM
+	stopSequences []string
+	limit         int
+}
+
+func (m *stopSequencesLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	// Copy the request and its config, which may be shared with the caller.
+	r := *req
+	var cfg genai.GenerateContentConfig
+	if req.Config != nil {
+		cfg = *req.Config
+	}
+	merged, err := MergeStopSequences(cfg.StopSequences, m.stopSequences, m.limit)
+	if err != nil {
+		return func(yield func(*LLMResponse, error) bool) {
+			yield(nil, err)
+		}
+	}
+	if dropped := slices.DeleteFunc(slices.Clone(m.stopSequences), func(s string) bool {
+		return s == "" || slices.Contains(merged, s)
+	}); len(dropped) > 0 {
+		log.Printf("Default stop sequences %q of model %q left out: at most %d stop sequences are accepted", dropped, m.Name(), m.limit)
+	}
+	cfg.StopSequences = merged
+	r.Config = &cfg
+	return m.LLM.GenerateContent(ctx, &r, stream)
+}
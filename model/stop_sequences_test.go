@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestMergeStopSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		provided []string
+		defaults []string
+		limit    int
+		want     []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "defaults only",
+			defaults: []string{"</tool_call>"},
+			want:     []string{"</tool_call>"},
+		},
+		{
+			name:     "provided come first",
+			provided: []string{"END"},
+			defaults: []string{"</tool_call>"},
+			want:     []string{"END", "</tool_call>"},
+		},
+		{
+			name:     "deduplicates and drops empty",
+			provided: []string{"END", "", "END", "</tool_call>"},
+			defaults: []string{"</tool_call>", "STOP"},
+			want:     []string{"END", "</tool_call>", "STOP"},
+		},
+		{
+			name:     "limit drops defaults first",
+			provided: []string{"a", "b"},
+			defaults: []string{"c", "d"},
+			limit:    3,
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "limit counts distinct provided",
+			provided: []string{"a", "b", "a"},
+			defaults: []string{"c"},
+			limit:    2,
+			want:     []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.MergeStopSequences(tt.provided, tt.defaults, tt.limit)
+			if err != nil {
+				t.Fatalf("MergeStopSequences() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeStopSequences() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeStopSequences_TooManyProvided(t *testing.T) {
+	if got, err := model.MergeStopSequences([]string{"a", "b", "c"}, []string{"d"}, 2); err == nil {
+		t.Errorf("MergeStopSequences() = %q, want an error for more provided sequences than the limit", got)
+	}
+}
+
+type recordingLLM struct {
+	req *model.LLMRequest
+}
+
+func (m *recordingLLM) Name() string { return "recording" }
+
+func (m *recordingLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	m.req = req
+	return func(yield func(*model.LLMResponse, error) bool) {}
+}
+
+func TestWithDefaultStopSequences(t *testing.T) {
+	inner := &recordingLLM{}
+	llm := model.WithDefaultStopSequences(inner, []string{"</tool_call>", "END"}, 2)
+
+	if got, want := llm.Name(), "recording"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+
+	req := &model.LLMRequest{
+		Config: &genai.GenerateContentConfig{StopSequences: []string{"END", "STOP"}},
+	}
+	for range llm.GenerateContent(t.Context(), req, false) {
+	}
+
+	if diff := cmp.Diff([]string{"END", "STOP"}, inner.req.Config.StopSequences); diff != "" {
+		t.Errorf("StopSequences mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"END", "STOP"}, req.Config.StopSequences); diff != "" {
+		t.Errorf("caller request was modified (-want +got):\n%s", diff)
+	}
+
+	for range llm.GenerateContent(t.Context(), &model.LLMRequest{}, false) {
+	}
+	if diff := cmp.Diff([]string{"</tool_call>", "END"}, inner.req.Config.StopSequences); diff != "" {
+		t.Errorf("StopSequences mismatch for request without config (-want +got):\n%s", diff)
+	}
+
+	inner.req = nil
+	tooMany := &model.LLMRequest{Config: &genai.GenerateContentConfig{StopSequences: []string{"a", "b", "c"}}}
+	var err error
+	for _, err = range llm.GenerateContent(t.Context(), tooMany, false) {
+	}
+	if err == nil {
+		t.Error("GenerateContent() with more stop sequences than the limit succeeded, want an error")
+	}
+	if inner.req != nil {
+		t.Error("the request with more stop sequences than the limit was sent to the model")
+	}
+}
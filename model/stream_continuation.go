@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// continuationPrompt asks the model to continue the response that was cut
+// off.
+const continuationPrompt = "Your previous response was interrupted. Continue it exactly where it stopped, without repeating any of the text already written."
+
+// WithStreamContinuation returns an LLM that resumes streamed responses
+// interrupted by an error, e.g. a dropped connection, instead of failing.
+//
+// When a stream fails after yielding partial text, the request is sent again
+// with the text generated so far and a prompt to continue it, so the tokens
+// already generated are kept. The partial responses of the continuation are
+// passed through as they arrive, and the text generated before the error is
+// prepended to the first final response with text, so callers see a single
+// response. The final responses without text, e.g. a function call or the
+// end of the turn, are passed through unchanged.
+//
+// At most maxContinuations continuations are attempted per call. Errors
+// before any text was generated, errors of responses with non-text parts,
+// e.g. function calls, and errors of non-streaming calls are returned
+// unchanged.
+func WithStreamContinuation(llm LLM, maxContinuations int) LLM {
+	return &streamContinuationLLM{LLM: llm, maxContinuations: maxContinuations}
+}
+
+type streamContinuationLLM struct {
+	LLM
+	maxContinuations int
+}
+
+func (m *streamContinuationLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	if !stream {
+		return m.LLM.GenerateContent(ctx, req, stream)
+	}
+	return func(yield func(*LLMResponse, error) bool) {
+		// prefix is the text generated by the previous, interrupted attempts.
+		var prefix strings.Builder
+		r := req
+		for attempt := 0; ; attempt++ {
+			var generated strings.Builder
+			resumable, prefixed := true, false
+			var streamErr error
+			for resp, err := range m.LLM.GenerateContent(ctx, r, stream) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				if resp.Partial {
+					if text, ok := partialText(resp); ok {
+						generated.WriteString(text)
+					} else {
+						resumable = false
+					}
+				} else if prefix.Len() > 0 && !prefixed {
+					resp, prefixed = withTextPrefix(resp, prefix.String())
+				}
+				if !yield(resp, nil) {
+					return
+				}
+			}
+			if streamErr == nil {
+				return
+			}
+			prefix.WriteString(generated.String())
+			if !resumable || prefix.Len() == 0 || attempt >= m.maxContinuations || ctx.Err() != nil {
+				yield(nil, streamErr)
+				return
+			}
+			r = continuationRequest(req, prefix.String())
+		}
+	}
+}
+
+// partialText returns the text of a partial response, and false if it has
+// parts that cannot be continued as text. Thoughts are skipped.
+func partialText(resp *LLMResponse) (string, bool) {
+	if resp.Content == nil {
+		return "", true
+	}
+	var text strings.Builder
+	for _, p := range resp.Content.Parts {
+		switch {
+		case p == nil, p.Thought:
+		case p.FunctionCall != nil, p.InlineData != nil, p.FileData != nil, p.ExecutableCode != nil:
+			return "", false
+		default:
+			text.WriteString(p.Text)
+		}
+	}
+	return text.String(), true
+}
+
+// continuationRequest returns a copy of req that asks the model to continue
+// the given partial response.
+func continuationRequest(req *LLMRequest, partial string) *LLMRequest {
+	r := *req
+	r.Contents = append(slices.Clone(req.Contents),
+		genai.NewContentFromText(partial, genai.RoleModel),
+		genai.NewContentFromText(continuationPrompt, genai.RoleUser),
+	)
+	return &r
+}
+
+// withTextPrefix returns a copy of resp with prefix prepended to its first
+// text part, and whether it has one. Without text, resp is returned as is.
+func withTextPrefix(resp *LLMResponse, prefix string) (*LLMResponse, bool) {
+	if resp.Content == nil {
+		return resp, false
+	}
+	i := slices.IndexFunc(resp.Content.Parts, func(p *genai.Part) bool {
+		return p != nil && !p.Thought && p.Text != ""
+	})
+	if i < 0 {
+		return resp, false
+	}
+	parts := slices.Clone(resp.Content.Parts)
+	p := *parts[i]
+	p.Text = prefix + p.Text
+	parts[i] = &p
+	r := *resp
+	c := *resp.Content
+	c.Parts = parts
+	r.Content = &c
+	return &r, true
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+var errConnectionReset = errors.New("connection reset")
+
+// flakyStreamLLM streams the chunks of one attempt per call, followed by the
+// error of the attempt, if any.
+type flakyStreamLLM struct {
+	attempts []streamAttempt
+	requests []*model.LLMRequest
+}
+
+type streamAttempt struct {
+	chunks []string
+	err    error
+	// final replaces the final response repeating the chunks.
+	final []*model.LLMResponse
+}
+
+func (m *flakyStreamLLM) Name() string { return "flaky" }
+
+func (m *flakyStreamLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		attempt := m.attempts[len(m.requests)]
+		m.requests = append(m.requests, req)
+		for _, chunk := range attempt.chunks {
+			if !yield(&model.LLMResponse{Content: genai.NewContentFromText(chunk, genai.RoleModel), Partial: true}, nil) {
+				return
+			}
+		}
+		if attempt.err != nil {
+			yield(nil, attempt.err)
+			return
+		}
+		if attempt.final != nil {
+			for _, resp := range attempt.final {
+				if !yield(resp, nil) {
+					return
+				}
+			}
+			return
+		}
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(strings.Join(attempt.chunks, ""), genai.RoleModel)}, nil)
+	}
+}
+
+func collectStream(llm model.LLM, req *model.LLMRequest) (partials []string, final string, err error) {
+	for resp, err := range llm.GenerateContent(context.Background(), req, true) {
+		if err != nil {
+			return partials, final, err
+		}
+		if resp.Partial {
+			partials = append(partials, resp.Content.Parts[0].Text)
+		} else {
+			final = resp.Content.Parts[0].Text
+		}
+	}
+	return partials, final, nil
+}
+
+func TestWithStreamContinuation(t *testing.T) {
+	inner := &flakyStreamLLM{attempts: []streamAttempt{
+		{chunks: []string{"Once ", "upon "}, err: errConnectionReset},
+		{chunks: []string{"a "}, err: errConnectionReset},
+		{chunks: []string{"time."}},
+	}}
+	llm := model.WithStreamContinuation(inner, 2)
+
+	req := &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("Tell me a story", genai.RoleUser)}}
+	partials, final, err := collectStream(llm, req)
+	if err != nil {
+		t.Fatalf("GenerateContent() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Once ", "upon ", "a ", "time."}, partials); diff != "" {
+		t.Errorf("partial responses mismatch (-want +got):\n%s", diff)
+	}
+	if want := "Once upon a time."; final != want {
+		t.Errorf("final response = %q, want %q", final, want)
+	}
+
+	if len(inner.requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(inner.requests))
+	}
+	last := inner.requests[2].Contents
+	if len(last) != 3 || last[1].Role != genai.RoleModel || last[1].Parts[0].Text != "Once upon a " {
+		t.Errorf("continuation request contents = %v, want the partial output followed by a prompt to continue", last)
+	}
+	if len(req.Contents) != 1 {
+		t.Errorf("the caller's request was modified, got %d contents", len(req.Contents))
+	}
+}
+
+func TestWithStreamContinuation_NonTextResponses(t *testing.T) {
+	call := &model.LLMResponse{Content: genai.NewContentFromFunctionCall("weather", map[string]any{"city": "Paris"}, genai.RoleModel)}
+	end := &model.LLMResponse{TurnComplete: true, FinishReason: genai.FinishReasonStop}
+	inner := &flakyStreamLLM{attempts: []streamAttempt{
+		{chunks: []string{"Let me check "}, err: errConnectionReset},
+		{chunks: []string{"the weather."}, final: []*model.LLMResponse{
+			call,
+			{Content: genai.NewContentFromText("the weather.", genai.RoleModel)},
+			{Content: genai.NewContentFromText("Done.", genai.RoleModel)},
+			end,
+		}},
+	}}
+	llm := model.WithStreamContinuation(inner, 1)
+
+	var got []*model.LLMResponse
+	for resp, err := range llm.GenerateContent(t.Context(), &model.LLMRequest{}, true) {
+		if err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+		if !resp.Partial {
+			got = append(got, resp)
+		}
+	}
+	want := []*model.LLMResponse{
+		call,
+		{Content: genai.NewContentFromText("Let me check the weather.", genai.RoleModel)},
+		{Content: genai.NewContentFromText("Done.", genai.RoleModel)},
+		end,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("final responses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithStreamContinuation_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     []streamAttempt
+		max          int
+		wantRequests int
+	}{
+		{
+			name:         "no partial output",
+			attempts:     []streamAttempt{{err: errConnectionReset}},
+			max:          2,
+			wantRequests: 1,
+		},
+		{
+			name: "continuations exhausted",
+			attempts: []streamAttempt{
+				{chunks: []string{"Once "}, err: errConnectionReset},
+				{chunks: []string{"upon "}, err: errConnectionReset},
+			},
+			max:          1,
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &flakyStreamLLM{attempts: tt.attempts}
+			_, _, err := collectStream(model.WithStreamContinuation(inner, tt.max), &model.LLMRequest{})
+			if !errors.Is(err, errConnectionReset) {
+				t.Errorf("GenerateContent() error = %v, want %v", err, errConnectionReset)
+			}
+			if len(inner.requests) != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", len(inner.requests), tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// ParseStructuredResponse parses the JSON text of resp, e.g. the response
+// of a model configured with GenerateContentConfig.ResponseSchema or
+// ResponseJsonSchema, into a T. The text is the concatenation of the text
+// parts of the response, thoughts excluded, and may be wrapped in a
+// Markdown code block.
+//
+// If schema is not nil, the JSON value is validated against it first. The
+// validation error gives the path of the offending value in the response,
+// e.g. $.items[2].price.
+func ParseStructuredResponse[T any](resp *LLMResponse, schema *jsonschema.Resolved) (T, error) {
+	var zero T
+	if resp == nil || resp.Content == nil {
+		return zero, errors.New("response has no content")
+	}
+	var sb strings.Builder
+	for _, p := range resp.Content.Parts {
+		if p != nil && !p.Thought {
+			sb.WriteString(p.Text)
+		}
+	}
+	text := trimCodeBlock(sb.String())
+	if text == "" {
+		return zero, errors.New("response has no text")
+	}
+
+	if schema != nil {
+		var v any
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return zero, fmt.Errorf("response is not valid JSON: %w", err)
+		}
+		if err := schema.Validate(v); err != nil {
+			return zero, fmt.Errorf("response does not match the schema at %s: %w", invalidPath(schema.Schema(), schema.Schema(), v, "$"), err)
+		}
+	}
+	var out T
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		return zero, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return out, nil
+}
+
+// trimCodeBlock returns the content of text if it is a Markdown code block,
+// e.g. ```json ... ```, or text trimmed otherwise.
+func trimCodeBlock(text string) string {
+	text = strings.TrimSpace(text)
+	rest, ok := strings.CutPrefix(text, "```")
+	if !ok {
+		return text
+	}
+	body, ok := strings.CutSuffix(rest, "```")
+	if !ok {
+		return text
+	}
+	// Drop the language tag.
+	if _, after, found := strings.Cut(body, "\n"); found {
+		body = after
+	}
+	return strings.TrimSpace(body)
+}
+
+// invalidPath returns the path of the deepest value of v, at path, that
+// does not validate against the subschema of s describing it. The
+// subschemas are validated on their own with the definitions of root, which
+// covers the schemas inferred from Go types. The path stays at the value
+// whose subschema cannot be found or resolved.
+func invalidPath(root, s *jsonschema.Schema, v any, path string) string {
+	s = deref(root, s)
+	switch v := v.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			sub := s.Properties[key]
+			if sub == nil {
+				sub = s.AdditionalProperties
+			}
+			if sub != nil && !validates(root, sub, v[key]) {
+				return invalidPath(root, sub, v[key], path+"."+key)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if s.Items != nil && !validates(root, s.Items, item) {
+				return invalidPath(root, s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+	return path
+}
+
+// deref returns the definition of root referred to by s, if s is a
+// reference to it.
+func deref(root, s *jsonschema.Schema) *jsonschema.Schema {
+	if name, ok := strings.CutPrefix(s.Ref, "#/$defs/"); ok && root.Defs[name] != nil {
+		return root.Defs[name]
+	}
+	return s
+}
+
+// validates reports whether v validates against s, a subschema of root. It
+// reports true if s cannot be resolved on its own.
+func validates(root, s *jsonschema.Schema, v any) bool {
+	sub := *deref(root, s)
+	sub.Defs = root.Defs
+	resolved, err := sub.Resolve(nil)
+	if err != nil {
+		return true
+	}
+	return resolved.Validate(v) == nil
+}
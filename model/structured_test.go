@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+type item struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type order struct {
+	Customer item   `json:"customer"`
+	Items    []item `json:"items"`
+}
+
+func TestParseStructuredResponse(t *testing.T) {
+	schema, err := jsonschema.For[order](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := func(parts ...*genai.Part) *model.LLMResponse {
+		return &model.LLMResponse{Content: &genai.Content{Role: genai.RoleModel, Parts: parts}}
+	}
+	thought := &genai.Part{Text: "The user wants tea.", Thought: true}
+
+	tests := []struct {
+		name    string
+		resp    *model.LLMResponse
+		want    order
+		wantErr string
+	}{
+		{
+			name: "valid",
+			resp: response(thought, genai.NewPartFromText(`{"customer": {"name": "Ada", "price": 0}, `), genai.NewPartFromText(`"items": [{"name": "tea", "price": 2.5}]}`)),
+			want: order{Customer: item{Name: "Ada"}, Items: []item{{Name: "tea", Price: 2.5}}},
+		},
+		{
+			name: "code block",
+			resp: response(genai.NewPartFromText("```json\n{\"customer\": {\"name\": \"Ada\", \"price\": 0}, \"items\": []}\n```")),
+			want: order{Customer: item{Name: "Ada"}, Items: []item{}},
+		},
+		{
+			name:    "invalid item",
+			resp:    response(genai.NewPartFromText(`{"customer": {"name": "Ada", "price": 0}, "items": [{"name": "tea", "price": 2.5}, {"name": "cake", "price": "3"}]}`)),
+			wantErr: "response does not match the schema at $.items[1].price",
+		},
+		{
+			name:    "missing property",
+			resp:    response(genai.NewPartFromText(`{"customer": {"name": "Ada"}, "items": []}`)),
+			wantErr: "response does not match the schema at $.customer",
+		},
+		{
+			name:    "not JSON",
+			resp:    response(genai.NewPartFromText("Sorry, I cannot do that.")),
+			wantErr: "response is not valid JSON",
+		},
+		{
+			name:    "no text",
+			resp:    response(thought),
+			wantErr: "response has no text",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := model.ParseStructuredResponse[order](tc.resp, resolved)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("ParseStructuredResponse() error = %v, want it to contain %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseStructuredResponse() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseStructuredResponse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
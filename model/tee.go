@@ -0,0 +1,34 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "iter"
+
+// Tee returns a stream yielding the elements of stream, calling observe with
+// each of them before it is yielded, e.g. to log the chunks of a response
+// while consuming it. Like stream, it is lazy: observe is called as the
+// caller ranges over the returned stream, nothing is buffered, and the
+// elements after the caller stops ranging are neither read nor observed.
+// observe also sees the errors of stream.
+func Tee(stream iter.Seq2[*LLMResponse, error], observe func(*LLMResponse, error)) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		for resp, err := range stream {
+			observe(resp, err)
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
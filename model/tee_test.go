@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestTee(t *testing.T) {
+	errBroken := errors.New("broken stream")
+	resps := []*model.LLMResponse{
+		chunk(genai.NewPartFromText("It is ")),
+		chunk(genai.NewPartFromText("sunny.")),
+	}
+
+	var observed []string
+	observe := func(resp *model.LLMResponse, err error) {
+		if err != nil {
+			observed = append(observed, "error: "+err.Error())
+			return
+		}
+		observed = append(observed, resp.Content.Parts[0].Text)
+	}
+	var got []string
+	for resp, err := range model.Tee(streamOf(resps, errBroken), observe) {
+		// The observer is called before each element is yielded.
+		if len(observed) != len(got)+1 {
+			t.Fatalf("observed %d elements before yielding element %d", len(observed), len(got))
+		}
+		if err != nil {
+			got = append(got, "error: "+err.Error())
+			continue
+		}
+		got = append(got, resp.Content.Parts[0].Text)
+	}
+	want := []string{"It is ", "sunny.", "error: broken stream"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("yielded elements mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, observed); diff != "" {
+		t.Errorf("observed elements mismatch (-want +got):\n%s", diff)
+	}
+
+	// Stopping early stops reading the stream.
+	observed = nil
+	for range model.Tee(streamOf(resps, errBroken), observe) {
+		break
+	}
+	if diff := cmp.Diff([]string{"It is "}, observed); diff != "" {
+		t.Errorf("observed elements after break mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ErrModelTimeout is returned by the LLMs of NewTimeoutModel when the
+// wrapped model does not respond in time.
+var ErrModelTimeout = errors.New("model call timed out")
+
+// NewTimeoutModel returns an LLM that cancels the calls to m taking longer
+// than perCall. For streamed calls, perCall is an idle timeout instead: it
+// applies to the wait for each response, so that long streams making
+// progress are not cut off. The time the caller spends handling the
+// responses does not count.
+//
+// A call timing out fails with an error wrapping ErrModelTimeout. The
+// context of each call is cancelled once its stream is fully consumed or
+// abandoned. A non-positive perCall means no timeout: m is returned as is.
+func NewTimeoutModel(m LLM, perCall time.Duration) LLM {
+	if perCall <= 0 {
+		return m
+	}
+	return &timeoutLLM{LLM: m, perCall: perCall}
+}
+
+type timeoutLLM struct {
+	LLM
+	perCall time.Duration
+}
+
+func (m *timeoutLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		callCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		timer := time.AfterFunc(m.perCall, func() { cancel(ErrModelTimeout) })
+		defer timer.Stop()
+
+		received := 0
+		for resp, err := range m.LLM.GenerateContent(callCtx, req, stream) {
+			if err != nil && errors.Is(context.Cause(callCtx), ErrModelTimeout) {
+				yield(nil, m.timeoutError(stream, received, err))
+				return
+			}
+			received++
+			if stream {
+				timer.Stop()
+			}
+			if !yield(resp, err) {
+				return
+			}
+			if stream {
+				timer.Reset(m.perCall)
+			}
+		}
+	}
+}
+
+// timeoutError returns the error of a call that timed out after receiving
+// the given number of responses, and failed with err.
+func (m *timeoutLLM) timeoutError(stream bool, received int, err error) error {
+	switch {
+	case !stream:
+		return fmt.Errorf("%w: model %q did not respond within %v: %w", ErrModelTimeout, m.Name(), m.perCall, err)
+	case received == 0:
+		return fmt.Errorf("%w: model %q did not start streaming within %v: %w", ErrModelTimeout, m.Name(), m.perCall, err)
+	default:
+		return fmt.Errorf("%w: model %q streamed nothing for %v after %d responses: %w", ErrModelTimeout, m.Name(), m.perCall, received, err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// slowLLM streams a chunk after each of its delays, or fails with the error
+// of the context if it is cancelled first.
+type slowLLM struct {
+	delays []time.Duration
+	ctx    context.Context
+}
+
+func (m *slowLLM) Name() string { return "slow" }
+
+func (m *slowLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.ctx = ctx
+		for _, d := range m.delays {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case <-time.After(d):
+			}
+			if !yield(&model.LLMResponse{Content: genai.NewContentFromText("chunk", genai.RoleModel), Partial: stream}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestNewTimeoutModel(t *testing.T) {
+	const perCall = 50 * time.Millisecond
+	for _, tc := range []struct {
+		name         string
+		delays       []time.Duration
+		stream       bool
+		wantErr      string
+		wantReceived int
+	}{
+		{
+			name:         "long stream making progress",
+			delays:       []time.Duration{30 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond},
+			stream:       true,
+			wantReceived: 3,
+		},
+		{
+			name:         "stalled stream",
+			delays:       []time.Duration{10 * time.Millisecond, time.Second},
+			stream:       true,
+			wantErr:      "streamed nothing for 50ms after 1 responses",
+			wantReceived: 1,
+		},
+		{
+			name:    "slow call",
+			delays:  []time.Duration{time.Second},
+			wantErr: "did not respond within 50ms",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			llm := &slowLLM{delays: tc.delays}
+			received := 0
+			var gotErr error
+			for _, err := range model.NewTimeoutModel(llm, perCall).GenerateContent(t.Context(), &model.LLMRequest{}, tc.stream) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				received++
+				// Handling the responses does not count.
+				time.Sleep(2 * perCall)
+			}
+			if tc.wantErr == "" {
+				if gotErr != nil {
+					t.Errorf("got error %v, want none", gotErr)
+				}
+			} else if !errors.Is(gotErr, model.ErrModelTimeout) || !strings.Contains(gotErr.Error(), tc.wantErr) {
+				t.Errorf("got error %v, want a timeout error containing %q", gotErr, tc.wantErr)
+			}
+			if received != tc.wantReceived {
+				t.Errorf("got %d responses, want %d", received, tc.wantReceived)
+			}
+			if llm.ctx.Err() == nil {
+				t.Error("the context of the call was not cancelled after the call")
+			}
+		})
+	}
+}
+
+func TestNewTimeoutModel_NoTimeout(t *testing.T) {
+	llm := &slowLLM{delays: []time.Duration{10 * time.Millisecond}}
+	for _, perCall := range []time.Duration{0, -time.Second} {
+		if got := model.NewTimeoutModel(llm, perCall); got != model.LLM(llm) {
+			t.Errorf("NewTimeoutModel(llm, %v) = %v, want llm unchanged", perCall, got)
+		}
+	}
+}
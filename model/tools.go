@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// ToolNames returns the sorted names of the tools of the request.
+func (r *LLMRequest) ToolNames() []string {
+	return slices.Sorted(maps.Keys(r.Tools))
+}
+
+// RemoveTool removes the named tool from r.Tools and its function
+// declaration from r.Config.Tools, e.g. to disable a tool for one model
+// call in a BeforeModel callback. A genai.Tool left without function
+// declarations nor other settings is removed too. It reports whether the
+// tool was found.
+//
+// The genai.Tool values of the request are not modified in place, the ones
+// holding the declaration are replaced with copies.
+func (r *LLMRequest) RemoveTool(name string) bool {
+	_, found := r.Tools[name]
+	delete(r.Tools, name)
+	if r.Config == nil {
+		return found
+	}
+	var tools []*genai.Tool
+	for _, t := range r.Config.Tools {
+		if t == nil || !slices.ContainsFunc(t.FunctionDeclarations, isDeclarationOf(name)) {
+			tools = append(tools, t)
+			continue
+		}
+		found = true
+		c := *t
+		c.FunctionDeclarations = slices.DeleteFunc(slices.Clone(t.FunctionDeclarations), isDeclarationOf(name))
+		if len(c.FunctionDeclarations) == 0 {
+			c.FunctionDeclarations = nil
+			if reflect.ValueOf(c).IsZero() {
+				continue
+			}
+		}
+		tools = append(tools, &c)
+	}
+	r.Config.Tools = tools
+	return found
+}
+
+// Validate checks r before it is sent to a model, and returns the errors of
+// all the problems found, joined. It checks that:
+//   - Model and Contents are set;
+//   - each tool of r.Tools having a function declaration is declared in
+//     r.Config.Tools, as the two are filled separately and can drift, e.g.
+//     when a callback edits one of them;
+//   - no function is declared twice.
+//
+// Requests leaving out the declarations on purpose, as with
+// ToolDeclarationsOnFirstTurnOnly, do not pass.
+func (r *LLMRequest) Validate() error {
+	var errs []error
+	if r.Model == "" {
+		errs = append(errs, errors.New("no model set"))
+	}
+	if len(r.Contents) == 0 {
+		errs = append(errs, errors.New("no contents"))
+	}
+	declared := make(map[string]bool)
+	if r.Config != nil {
+		for _, t := range r.Config.Tools {
+			if t == nil {
+				continue
+			}
+			for _, d := range t.FunctionDeclarations {
+				if d == nil {
+					continue
+				}
+				if declared[d.Name] {
+					errs = append(errs, fmt.Errorf("function %q is declared more than once", d.Name))
+				}
+				declared[d.Name] = true
+			}
+		}
+	}
+	for _, name := range r.ToolNames() {
+		declarer, ok := r.Tools[name].(interface {
+			Declaration() *genai.FunctionDeclaration
+		})
+		if !ok || declarer.Declaration() == nil {
+			// e.g. a built-in tool of the model.
+			continue
+		}
+		if !declared[name] {
+			errs = append(errs, fmt.Errorf("tool %q has no function declaration in the config", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isDeclarationOf(name string) func(*genai.FunctionDeclaration) bool {
+	return func(d *genai.FunctionDeclaration) bool {
+		return d != nil && d.Name == name
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestLLMRequest_RemoveTool(t *testing.T) {
+	functions := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "sum"}, {Name: "search"}}}
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	req := &model.LLMRequest{
+		Tools: map[string]any{"sum": "sum tool", "search": "search tool"},
+		Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{
+			functions,
+			search,
+		}},
+	}
+
+	if diff := cmp.Diff([]string{"search", "sum"}, req.ToolNames()); diff != "" {
+		t.Errorf("ToolNames() mismatch (-want +got):\n%s", diff)
+	}
+
+	if !req.RemoveTool("sum") {
+		t.Error("RemoveTool(sum) = false, want true")
+	}
+	want := []*genai.Tool{
+		{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "search"}}},
+		search,
+	}
+	if diff := cmp.Diff(want, req.Config.Tools); diff != "" {
+		t.Errorf("Config.Tools mismatch (-want +got):\n%s", diff)
+	}
+	if len(functions.FunctionDeclarations) != 2 {
+		t.Errorf("RemoveTool() modified the genai.Tool of the request in place")
+	}
+
+	// The genai.Tool left without declarations is removed.
+	if !req.RemoveTool("search") {
+		t.Error("RemoveTool(search) = false, want true")
+	}
+	if diff := cmp.Diff([]*genai.Tool{search}, req.Config.Tools); diff != "" {
+		t.Errorf("Config.Tools mismatch (-want +got):\n%s", diff)
+	}
+	if names := req.ToolNames(); len(names) != 0 {
+		t.Errorf("ToolNames() = %v, want none", names)
+	}
+
+	if req.RemoveTool("missing") {
+		t.Error("RemoveTool(missing) = true, want false")
+	}
+	if (&model.LLMRequest{}).RemoveTool("sum") {
+		t.Error("RemoveTool() on an empty request = true, want false")
+	}
+}
+
+type declaredTool struct {
+	decl *genai.FunctionDeclaration
+}
+
+func (d declaredTool) Declaration() *genai.FunctionDeclaration {
+	return d.decl
+}
+
+func TestLLMRequest_Validate(t *testing.T) {
+	sum := &genai.FunctionDeclaration{Name: "sum"}
+	contents := []*genai.Content{genai.NewContentFromText("1+1?", genai.RoleUser)}
+
+	valid := &model.LLMRequest{
+		Model:    "gemini-2.5-flash",
+		Contents: contents,
+		Tools: map[string]any{
+			"sum": declaredTool{sum},
+			// Tools without declarations, e.g. built-in ones, are not checked.
+			"google_search": "search tool",
+		},
+		Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{
+			{FunctionDeclarations: []*genai.FunctionDeclaration{sum}},
+			{GoogleSearch: &genai.GoogleSearch{}},
+		}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	invalid := &model.LLMRequest{
+		Tools: map[string]any{"sum": declaredTool{sum}, "search": declaredTool{&genai.FunctionDeclaration{Name: "search"}}},
+		Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{
+			{FunctionDeclarations: []*genai.FunctionDeclaration{sum}},
+			{FunctionDeclarations: []*genai.FunctionDeclaration{sum}},
+		}},
+	}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want errors")
+	}
+	for _, want := range []string{
+		"no model set",
+		"no contents",
+		`function "sum" is declared more than once`,
+		`tool "search" has no function declaration in the config`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %v, want it to contain %q", err, want)
+		}
+	}
+}
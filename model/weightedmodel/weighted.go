@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package weightedmodel provides a model wrapper that splits the requests
+// across several models by weight, e.g. to send a small share of the
+// traffic to a new model version during a rollout or an A/B test.
+package weightedmodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"google.golang.org/adk/model"
+)
+
+// ServedByKey is the key of the CustomMetadata of the responses holding the
+// name of the model that served the request.
+const ServedByKey = "weighted_model_served_by"
+
+// Backend is a model and its share of the requests.
+type Backend struct {
+	// Model serves the requests routed to the backend.
+	Model model.LLM
+	// Weight is the share of the requests routed to the backend, relative
+	// to the weights of the other backends. A backend with a zero weight
+	// gets no requests.
+	Weight float64
+}
+
+// Config is the configuration of the weighted model.
+type Config struct {
+	// Name of the model. Defaults to the name of the first backend.
+	Name string
+	// Backends are the models the requests are split across. At least one
+	// is required.
+	Backends []Backend
+	// Rand picks the backend of each request. Set it to a generator with a
+	// fixed seed, e.g. rand.New(rand.NewPCG(1, 2)), for reproducible
+	// routing. Defaults to a randomly seeded generator.
+	Rand *rand.Rand
+}
+
+// New returns an LLM that sends each request to one of the backends, picked
+// at random according to their weights.
+//
+// The Model of the request is set to the name of the picked backend, so
+// that the usage of each backend is accounted separately, and the name is
+// recorded under ServedByKey in the CustomMetadata of the responses.
+func New(cfg Config) (model.LLM, error) {
+	if len(cfg.Backends) == 0 {
+		return nil, errors.New("at least one backend is required")
+	}
+	var total float64
+	for i, b := range cfg.Backends {
+		if b.Model == nil {
+			return nil, fmt.Errorf("backends[%d] has no model", i)
+		}
+		if b.Weight < 0 {
+			return nil, fmt.Errorf("backends[%d] has a negative weight %v", i, b.Weight)
+		}
+		total += b.Weight
+	}
+	if total <= 0 {
+		return nil, errors.New("at least one backend must have a positive weight")
+	}
+	if cfg.Name == "" {
+		cfg.Name = cfg.Backends[0].Model.Name()
+	}
+	cfg.Backends = slices.Clone(cfg.Backends)
+	if cfg.Rand == nil {
+		cfg.Rand = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return &weightedModel{cfg: cfg, total: total}, nil
+}
+
+type weightedModel struct {
+	cfg   Config
+	total float64
+
+	// mu guards cfg.Rand, which is not safe for concurrent use.
+	mu sync.Mutex
+}
+
+func (m *weightedModel) Name() string {
+	return m.cfg.Name
+}
+
+func (m *weightedModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		llm := m.pick()
+		name := llm.Name()
+		if req != nil {
+			req.Model = name
+		}
+		for resp, err := range llm.GenerateContent(ctx, req, stream) {
+			if resp != nil {
+				// The response may be kept by the backend, e.g. to aggregate
+				// a stream, annotate a copy.
+				c := *resp
+				c.CustomMetadata = maps.Clone(resp.CustomMetadata)
+				if c.CustomMetadata == nil {
+					c.CustomMetadata = make(map[string]any)
+				}
+				c.CustomMetadata[ServedByKey] = name
+				resp = &c
+			}
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
+
+// pick returns the model of a backend picked according to the weights.
+func (m *weightedModel) pick() model.LLM {
+	m.mu.Lock()
+	r := m.cfg.Rand.Float64() * m.total
+	m.mu.Unlock()
+	for _, b := range m.cfg.Backends {
+		if r < b.Weight {
+			return b.Model
+		}
+		r -= b.Weight
+	}
+	// Rounding errors may leave r slightly above the last weight.
+	for i := len(m.cfg.Backends) - 1; ; i-- {
+		if m.cfg.Backends[i].Weight > 0 {
+			return m.cfg.Backends[i].Model
+		}
+	}
+}
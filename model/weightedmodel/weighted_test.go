@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weightedmodel_test
+
+import (
+	"context"
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/weightedmodel"
+)
+
+type fakeModel struct {
+	name string
+	resp *model.LLMResponse
+}
+
+func (m *fakeModel) Name() string { return m.name }
+
+func (m *fakeModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(m.resp, nil)
+	}
+}
+
+func newFake(name string) *fakeModel {
+	return &fakeModel{name: name, resp: &model.LLMResponse{Content: genai.NewContentFromText(name, genai.RoleModel)}}
+}
+
+func TestNew_Errors(t *testing.T) {
+	stable := newFake("stable")
+	for _, cfg := range []weightedmodel.Config{
+		{},
+		{Backends: []weightedmodel.Backend{{Weight: 1}}},
+		{Backends: []weightedmodel.Backend{{Model: stable, Weight: -1}, {Model: stable, Weight: 2}}},
+		{Backends: []weightedmodel.Backend{{Model: stable}}},
+	} {
+		if _, err := weightedmodel.New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
+
+func TestWeightedModel(t *testing.T) {
+	stable, canary, disabled := newFake("stable"), newFake("canary"), newFake("disabled")
+	newModel := func(seed uint64) model.LLM {
+		t.Helper()
+		m, err := weightedmodel.New(weightedmodel.Config{
+			Backends: []weightedmodel.Backend{
+				{Model: stable, Weight: 9},
+				{Model: disabled, Weight: 0},
+				{Model: canary, Weight: 1},
+			},
+			Rand: rand.New(rand.NewPCG(seed, seed)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	route := func(m model.LLM) []string {
+		var served []string
+		for range 1000 {
+			req := &model.LLMRequest{Model: m.Name()}
+			for resp, err := range m.GenerateContent(t.Context(), req, false) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				name, _ := resp.CustomMetadata[weightedmodel.ServedByKey].(string)
+				if name != resp.Content.Parts[0].Text || name != req.Model {
+					t.Fatalf("got response of %q served by %q for request of model %q, want the same model",
+						resp.Content.Parts[0].Text, name, req.Model)
+				}
+				served = append(served, name)
+			}
+		}
+		return served
+	}
+
+	m := newModel(1)
+	if got := m.Name(); got != "stable" {
+		t.Errorf("Name() = %q, want the name of the first backend", got)
+	}
+	served := route(m)
+	counts := make(map[string]int)
+	for _, name := range served {
+		counts[name]++
+	}
+	if counts["disabled"] != 0 || counts["canary"] < 50 || counts["canary"] > 150 || counts["stable"] != 1000-counts["canary"] {
+		t.Errorf("got requests served %v, want about 900 by stable and 100 by canary", counts)
+	}
+	if stable.resp.CustomMetadata != nil {
+		t.Error("the response of the backend was modified")
+	}
+
+	// The same seed routes the requests the same way.
+	again := route(newModel(1))
+	for i := range served {
+		if served[i] != again[i] {
+			t.Fatalf("request %d served by %q, then %q with the same seed", i, served[i], again[i])
+		}
+	}
+}
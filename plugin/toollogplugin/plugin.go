@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toollogplugin provides a plugin logging tool calls and their
+// arguments to a [slog.Logger]. Successful calls are sampled, failed calls
+// are always logged.
+package toollogplugin
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/tool"
+)
+
+// DefaultName is the name of the plugin if none is configured.
+const DefaultName = "tool_log"
+
+// Config is the configuration of the plugin.
+type Config struct {
+	// Name of the plugin. Defaults to DefaultName.
+	Name string
+	// Logger receives the records. Defaults to slog.Default().
+	Logger *slog.Logger
+	// SampleRate is the fraction, between 0 and 1, of successful tool calls
+	// that are logged at the info level. Failed calls are always logged at
+	// the error level.
+	SampleRate float64
+	// Redact is called with a copy of the arguments of each logged call and
+	// returns the arguments to log, e.g. with sensitive values removed.
+	Redact func(toolName string, args map[string]any) map[string]any
+}
+
+// New returns a plugin logging tool calls as described by cfg.
+func New(cfg Config) (*plugin.Plugin, error) {
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1, got %v", cfg.SampleRate)
+	}
+	if cfg.Name == "" {
+		cfg.Name = DefaultName
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	l := &toolLogger{cfg: cfg, sample: rand.Float64}
+	return plugin.New(plugin.Config{
+		Name:                cfg.Name,
+		AfterToolCallback:   l.afterTool,
+		OnToolErrorCallback: l.onToolError,
+	})
+}
+
+type toolLogger struct {
+	cfg    Config
+	sample func() float64
+}
+
+// onToolError logs the original error of the tool, before any callback
+// turns it into a result.
+func (l *toolLogger) onToolError(ctx tool.Context, t tool.Tool, args map[string]any, err error) (map[string]any, error) {
+	l.log(ctx, slog.LevelError, "tool call failed", t, args, slog.String("error", err.Error()))
+	return nil, nil
+}
+
+// afterTool samples successful calls. Failed calls were already logged by
+// onToolError.
+func (l *toolLogger) afterTool(ctx tool.Context, t tool.Tool, args, _ map[string]any, err error) (map[string]any, error) {
+	if err == nil && l.cfg.SampleRate > 0 && l.sample() < l.cfg.SampleRate {
+		l.log(ctx, slog.LevelInfo, "tool call", t, args)
+	}
+	return nil, nil
+}
+
+func (l *toolLogger) log(ctx tool.Context, level slog.Level, msg string, t tool.Tool, args map[string]any, attrs ...slog.Attr) {
+	if !l.cfg.Logger.Enabled(ctx, level) {
+		return
+	}
+	if l.cfg.Redact != nil {
+		args = l.cfg.Redact(t.Name(), maps.Clone(args))
+	}
+	attrs = append([]slog.Attr{
+		slog.String("tool", t.Name()),
+		slog.String("function_call_id", ctx.FunctionCallID()),
+		slog.String("invocation_id", ctx.InvocationID()),
+		slog.String("agent", ctx.AgentName()),
+		slog.Any("args", args),
+	}, attrs...)
+	l.cfg.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toollogplugin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/plugin/toollogplugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestToolLogPlugin(t *testing.T) {
+	redact := func(_ string, args map[string]any) map[string]any {
+		if _, ok := args["token"]; ok {
+			args["token"] = "[REDACTED]"
+		}
+		return args
+	}
+	testCases := []struct {
+		name       string
+		sampleRate float64
+		toolErr    error
+		want       []map[string]any
+	}{
+		{
+			name:       "success sampled",
+			sampleRate: 1,
+			want: []map[string]any{{
+				"level": "INFO",
+				"msg":   "tool call",
+				"tool":  "lookup",
+				"args":  map[string]any{"query": "weather", "token": "[REDACTED]"},
+			}},
+		},
+		{
+			name:       "success not sampled",
+			sampleRate: 0,
+		},
+		{
+			name:       "failure always logged",
+			sampleRate: 0,
+			toolErr:    errors.New("backend unavailable"),
+			want: []map[string]any{{
+				"level": "ERROR",
+				"msg":   "tool call failed",
+				"tool":  "lookup",
+				"args":  map[string]any{"query": "weather", "token": "[REDACTED]"},
+				"error": "backend unavailable",
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			p, err := toollogplugin.New(toollogplugin.Config{
+				Logger:     slog.New(slog.NewJSONHandler(&buf, nil)),
+				SampleRate: tc.sampleRate,
+				Redact:     redact,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			lookup, err := functiontool.New(functiontool.Config{
+				Name:        "lookup",
+				Description: "looks up a query",
+			}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+				return map[string]any{"answer": "sunny"}, tc.toolErr
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			model := &testutil.MockModel{Responses: []*genai.Content{
+				genai.NewContentFromFunctionCall("lookup", map[string]any{"query": "weather", "token": "secret"}, genai.RoleModel),
+				genai.NewContentFromText("done", genai.RoleModel),
+			}}
+			a, err := llmagent.New(llmagent.Config{
+				Name:  "test_agent",
+				Model: model,
+				Tools: []tool.Tool{lookup},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testRunner := testutil.NewTestAgentRunnerWithPluginManager(t, a, runner.PluginConfig{
+				Plugins: []*plugin.Plugin{p},
+			})
+			if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "user input")); err != nil {
+				t.Fatalf("agent run failed: %v", err)
+			}
+
+			var got []map[string]any
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var rec map[string]any
+				if err := dec.Decode(&rec); err != nil {
+					t.Fatal(err)
+				}
+				for _, k := range []string{"time", "function_call_id", "invocation_id"} {
+					delete(rec, k)
+				}
+				if rec["agent"] != "test_agent" {
+					t.Errorf("agent = %v, want test_agent", rec["agent"])
+				}
+				delete(rec, "agent")
+				got = append(got, rec)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("log records mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidSampleRate(t *testing.T) {
+	for _, rate := range []float64{-0.1, 1.5} {
+		if _, err := toollogplugin.New(toollogplugin.Config{SampleRate: rate}); err == nil {
+			t.Errorf("New(SampleRate: %v) succeeded, want error", rate)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trajectoryplugin provides a plugin recording the trajectory of
+// the runs of a runner, i.e. the tool calls the agents make, and Match to
+// compare it with an expected trajectory, to test which tools an agent calls
+// with which arguments, e.g. with a scripted model.
+package trajectoryplugin
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Step is a tool call of a trajectory.
+type Step struct {
+	// Agent is the name of the agent calling the tool.
+	Agent string
+	// Tool is the name of the tool.
+	Tool string
+	// Args are the arguments of the call.
+	Args map[string]any
+	// Result is the result of the call, nil if it failed.
+	Result map[string]any
+	// Error is the error message of a failed call.
+	Error string
+}
+
+// Recorder records the steps of the runs of the runners it is plugged in.
+// Use it for tests, as it keeps all the steps in memory.
+type Recorder struct {
+	plugin *plugin.Plugin
+
+	mu    sync.Mutex
+	steps []Step
+}
+
+// NewRecorder creates a Recorder. Add its Plugin to the runner to record.
+func NewRecorder() (*Recorder, error) {
+	r := &Recorder{}
+	p, err := plugin.New(plugin.Config{
+		Name:              "trajectory",
+		AfterToolCallback: r.afterTool,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.plugin = p
+	return r, nil
+}
+
+// Plugin returns the plugin recording the steps.
+func (r *Recorder) Plugin() *plugin.Plugin {
+	return r.plugin
+}
+
+// Trajectory returns the steps recorded so far, in the order the tool calls
+// completed. The order of the calls run in parallel is not deterministic.
+func (r *Recorder) Trajectory() []Step {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.steps)
+}
+
+// Reset forgets the steps recorded so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = nil
+}
+
+func (r *Recorder) afterTool(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+	step := Step{
+		Agent:  ctx.AgentName(),
+		Tool:   t.Name(),
+		Args:   maps.Clone(args),
+		Result: maps.Clone(result),
+	}
+	if err != nil {
+		step.Error = err.Error()
+		step.Result = nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+	return nil, nil
+}
+
+type wildcard struct{}
+
+// Any matches any value in the expected steps given to Match, e.g. for the
+// volatile fields of the arguments or results, like IDs or timestamps.
+var Any any = wildcard{}
+
+// Match reports, as an error, how the trajectory got differs from want.
+// Nil if they match.
+//
+// The steps must be in the same order and match field by field, except for
+// the empty Agent, the nil Args and the nil Result fields of the expected
+// steps, which match any value. Values match when they are equal, or when
+// the expected value is Any, at any depth of the arguments and results.
+// Numbers match whatever their Go type, e.g. 1 and 1.0. The
+// functiontool.ProvenanceKey field of the results is ignored.
+func Match(got, want []Step) error {
+	if len(got) != len(want) {
+		return fmt.Errorf("got %d steps %v, want %d steps %v", len(got), toolNames(got), len(want), toolNames(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Tool != w.Tool {
+			return fmt.Errorf("step %d: got tool %q, want %q", i, g.Tool, w.Tool)
+		}
+		if w.Agent != "" && g.Agent != w.Agent {
+			return fmt.Errorf("step %d (%s): got agent %q, want %q", i, w.Tool, g.Agent, w.Agent)
+		}
+		if w.Args != nil && !matchValue(w.Args, g.Args) {
+			return fmt.Errorf("step %d (%s): got args %v, want %v", i, w.Tool, g.Args, w.Args)
+		}
+		if w.Result != nil {
+			result := g.Result
+			if _, ok := result[functiontool.ProvenanceKey]; ok && w.Result[functiontool.ProvenanceKey] == nil {
+				result = maps.Clone(result)
+				delete(result, functiontool.ProvenanceKey)
+			}
+			if !matchValue(w.Result, result) {
+				return fmt.Errorf("step %d (%s): got result %v, want %v", i, w.Tool, g.Result, w.Result)
+			}
+		}
+		if g.Error != w.Error {
+			return fmt.Errorf("step %d (%s): got error %q, want %q", i, w.Tool, g.Error, w.Error)
+		}
+	}
+	return nil
+}
+
+func toolNames(steps []Step) string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.Tool
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// matchValue reports whether got matches the expected value want.
+func matchValue(want, got any) bool {
+	if _, ok := want.(wildcard); ok {
+		return true
+	}
+	if w, ok := toFloat(want); ok {
+		g, ok := toFloat(got)
+		return ok && w == g
+	}
+	wv, gv := reflect.ValueOf(want), reflect.ValueOf(got)
+	if !wv.IsValid() || !gv.IsValid() {
+		return !wv.IsValid() && !gv.IsValid()
+	}
+	switch {
+	case wv.Kind() == reflect.Map && gv.Kind() == reflect.Map:
+		if wv.Len() != gv.Len() {
+			return false
+		}
+		for _, k := range wv.MapKeys() {
+			gk := reflect.ValueOf(k.Interface())
+			if !gk.Type().AssignableTo(gv.Type().Key()) {
+				return false
+			}
+			g := gv.MapIndex(gk)
+			if !g.IsValid() || !matchValue(wv.MapIndex(k).Interface(), g.Interface()) {
+				return false
+			}
+		}
+		return true
+	case (wv.Kind() == reflect.Slice || wv.Kind() == reflect.Array) && (gv.Kind() == reflect.Slice || gv.Kind() == reflect.Array):
+		if wv.Len() != gv.Len() {
+			return false
+		}
+		for i := range wv.Len() {
+			if !matchValue(wv.Index(i).Interface(), gv.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trajectoryplugin_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/plugin/trajectoryplugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder, err := trajectoryplugin.NewRecorder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type searchArgs struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	search, err := functiontool.New(functiontool.Config{
+		Name: "search",
+	}, func(_ tool.Context, args searchArgs) (map[string]any, error) {
+		return map[string]any{"id": "req-123", "hits": []string{"doc1", "doc2"}}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetch, err := functiontool.New(functiontool.Config{
+		Name: "fetch",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return nil, errors.New("not found")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "researcher",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("search", map[string]any{"query": "adk", "limit": 2}, genai.RoleModel),
+			genai.NewContentFromFunctionCall("fetch", map[string]any{"doc": "doc1"}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{search, fetch},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testRunner := testutil.NewTestAgentRunnerWithPluginManager(t, a, runner.PluginConfig{
+		Plugins: []*plugin.Plugin{recorder.Plugin()},
+	})
+	if _, err := testutil.CollectEvents(testRunner.Run(t, "session", "research adk")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	got := recorder.Trajectory()
+	want := []trajectoryplugin.Step{
+		{
+			Agent:  "researcher",
+			Tool:   "search",
+			Args:   map[string]any{"query": "adk", "limit": 2},
+			Result: map[string]any{"id": trajectoryplugin.Any, "hits": []string{"doc1", "doc2"}},
+		},
+		{
+			Tool:  "fetch",
+			Error: "not found",
+		},
+	}
+	if err := trajectoryplugin.Match(got, want); err != nil {
+		t.Errorf("Match() = %v, want nil", err)
+	}
+
+	recorder.Reset()
+	if got := recorder.Trajectory(); len(got) != 0 {
+		t.Errorf("Trajectory() after Reset() = %v, want none", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	got := []trajectoryplugin.Step{
+		{
+			Agent:  "a",
+			Tool:   "search",
+			Args:   map[string]any{"query": "adk", "filters": map[string]any{"lang": "go", "year": float64(2026)}},
+			Result: map[string]any{"hits": []any{"doc1"}},
+		},
+	}
+	tests := []struct {
+		name    string
+		want    []trajectoryplugin.Step
+		wantErr string
+	}{
+		{
+			name: "exact",
+			want: []trajectoryplugin.Step{{
+				Agent:  "a",
+				Tool:   "search",
+				Args:   map[string]any{"query": "adk", "filters": map[string]any{"lang": "go", "year": 2026}},
+				Result: map[string]any{"hits": []string{"doc1"}},
+			}},
+		},
+		{
+			name: "nested wildcard",
+			want: []trajectoryplugin.Step{{
+				Tool: "search",
+				Args: map[string]any{"query": "adk", "filters": map[string]any{"lang": "go", "year": trajectoryplugin.Any}},
+			}},
+		},
+		{
+			name:    "missing step",
+			want:    []trajectoryplugin.Step{{Tool: "search"}, {Tool: "fetch"}},
+			wantErr: "got 1 steps [search], want 2 steps [search, fetch]",
+		},
+		{
+			name:    "other tool",
+			want:    []trajectoryplugin.Step{{Tool: "fetch"}},
+			wantErr: `step 0: got tool "search", want "fetch"`,
+		},
+		{
+			name: "other args",
+			want: []trajectoryplugin.Step{{
+				Tool: "search",
+				Args: map[string]any{"query": "go"},
+			}},
+			wantErr: "step 0 (search): got args",
+		},
+		{
+			name: "other result",
+			want: []trajectoryplugin.Step{{
+				Tool:   "search",
+				Result: map[string]any{"hits": []any{}},
+			}},
+			wantErr: "step 0 (search): got result",
+		},
+		{
+			name:    "unexpected error",
+			want:    []trajectoryplugin.Step{{Tool: "search", Error: "boom"}},
+			wantErr: `step 0 (search): got error "", want "boom"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := trajectoryplugin.Match(got, tc.want)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Match() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Match() = %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
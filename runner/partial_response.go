@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+// PartialResponseStateKey is the session state key under which the runner
+// saves the text of an in-progress streamed response when
+// agent.RunConfig.PartialResponsePersistInterval is set.
+//
+// The key is cleared when the streamed response completes.
+const PartialResponseStateKey = "_adk_partial_response"
+
+// PartialResponse is a streamed response that did not complete.
+type PartialResponse struct {
+	InvocationID string
+	Author       string
+	Text         string
+}
+
+// LoadPartialResponse returns the partial response saved in the session
+// state, if any. A saved partial response means the invocation that produced
+// it was interrupted before the response completed, e.g. by a crash.
+//
+// The application can show the partial text to the user, or send it back to
+// the agent as context to continue generating the answer.
+func LoadPartialResponse(s session.Session) (*PartialResponse, bool) {
+	v, err := s.State().Get(PartialResponseStateKey)
+	if err != nil {
+		return nil, false
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	text, _ := m["text"].(string)
+	if text == "" {
+		return nil, false
+	}
+	invocationID, _ := m["invocation_id"].(string)
+	author, _ := m["author"].(string)
+	return &PartialResponse{
+		InvocationID: invocationID,
+		Author:       author,
+		Text:         text,
+	}, true
+}
+
+// partialResponseSaver accumulates the text of partial events and decides
+// when to save it to the session.
+type partialResponseSaver struct {
+	interval time.Duration
+
+	text      strings.Builder
+	lastSaved time.Time
+	saved     bool
+}
+
+// process accumulates the text of a partial event. It returns a state-only
+// event to append to the session when the text should be saved.
+//
+// When a non-partial event follows saved partial text, the saved text is
+// cleared as part of that event's state delta.
+func (p *partialResponseSaver) process(ev *session.Event) *session.Event {
+	if !ev.Partial {
+		if p.saved {
+			if ev.Actions.StateDelta == nil {
+				ev.Actions.StateDelta = make(map[string]any)
+			}
+			ev.Actions.StateDelta[PartialResponseStateKey] = nil
+		}
+		p.text.Reset()
+		p.lastSaved = time.Time{}
+		p.saved = false
+		return nil
+	}
+
+	if ev.Content == nil {
+		return nil
+	}
+	for _, part := range ev.Content.Parts {
+		if part.Text != "" && !part.Thought {
+			p.text.WriteString(part.Text)
+		}
+	}
+
+	now := time.Now()
+	if p.lastSaved.IsZero() {
+		// Start the interval at the first partial event of the response.
+		p.lastSaved = now
+		return nil
+	}
+	if now.Sub(p.lastSaved) < p.interval || p.text.Len() == 0 {
+		return nil
+	}
+	p.lastSaved = now
+	p.saved = true
+
+	checkpoint := session.NewEvent(ev.InvocationID)
+	checkpoint.Author = ev.Author
+	checkpoint.Branch = ev.Branch
+	checkpoint.Actions.StateDelta[PartialResponseStateKey] = map[string]any{
+		"invocation_id": ev.InvocationID,
+		"author":        ev.Author,
+		"text":          p.text.String(),
+	}
+	return checkpoint
+}
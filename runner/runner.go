@@ -17,11 +17,13 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"log"
 	"time"
 
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
@@ -29,11 +31,13 @@ import (
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/agent/runconfig"
 	artifactinternal "google.golang.org/adk/internal/artifact"
+	"google.golang.org/adk/internal/callgate"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/llminternal"
 	imemory "google.golang.org/adk/internal/memory"
 	"google.golang.org/adk/internal/plugininternal"
 	"google.golang.org/adk/internal/sessioninternal"
+	"google.golang.org/adk/internal/usage"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
@@ -54,6 +58,24 @@ type Config struct {
 	MemoryService memory.Service
 	// optional
 	PluginConfig PluginConfig
+	// MaxInvocationDuration, if positive, caps the duration of each
+	// invocation. When exceeded, the context of the agent run is cancelled
+	// with ErrInvocationTimeout as cause, and the run ends with an event
+	// with InvocationTimeoutErrorCode holding the text streamed so far.
+	MaxInvocationDuration time.Duration
+	// OnRequest, if set, is called with each request of the invocation
+	// right before it is sent to the model, after the request processors
+	// and the before model callbacks ran. It lets developers watch how the
+	// request evolves across the turns of an invocation. OnRequest must not
+	// modify the request.
+	OnRequest func(*model.LLMRequest)
+	// CallLimiter, if set, bounds the number of model and tool calls in
+	// flight, each call holding a weight of 1 while it runs. It applies to
+	// all the agents of the tree, however deep the parallel agents nest,
+	// and can be shared by several runners to enforce a system-wide limit.
+	// The calls made within a tool call, e.g. by an agent run as a tool,
+	// run under the slot of the tool call.
+	CallLimiter *semaphore.Weighted
 }
 
 type PluginConfig struct {
@@ -92,6 +114,10 @@ func New(cfg Config) (*Runner, error) {
 		memoryService:   cfg.MemoryService,
 		parents:         parents,
 		pluginManager:   pluginManager,
+
+		maxInvocationDuration: cfg.MaxInvocationDuration,
+		onRequest:             cfg.OnRequest,
+		callLimiter:           cfg.CallLimiter,
 	}, nil
 }
 
@@ -107,11 +133,19 @@ type Runner struct {
 
 	parents       parentmap.Map
 	pluginManager *plugininternal.PluginManager
+
+	maxInvocationDuration time.Duration
+	onRequest             func(*model.LLMRequest)
+	callLimiter           *semaphore.Weighted
 }
 
 // Run runs the agent for the given user input, yielding events from agents.
 // For each user message it finds the proper agent within an agent tree to
 // continue the conversation within the session.
+//
+// If ctx is cancelled while a response is streamed, the text streamed so far
+// is saved to the session as an event marked as interrupted, even if the
+// caller stops iterating.
 func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.Content, cfg agent.RunConfig) iter.Seq2[*session.Event, error] {
 	// TODO(hakim): we need to validate whether cfg is compatible with the Agent.
 	//   see adk-python/src/google/adk/runners.py Runner._new_invocation_context.
@@ -135,11 +169,29 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 			return
 		}
 
+		if r.maxInvocationDuration > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeoutCause(ctx, r.maxInvocationDuration, ErrInvocationTimeout)
+			defer cancel()
+		}
+
 		ctx = parentmap.ToContext(ctx, r.parents)
 		ctx = runconfig.ToContext(ctx, &runconfig.RunConfig{
 			StreamingMode: runconfig.StreamingMode(cfg.StreamingMode),
+			MaxLLMCalls:   cfg.MaxLLMCalls,
+			OnRequest:     r.onRequest,
 		})
 		ctx = plugininternal.ToContext(ctx, r.pluginManager)
+		if r.callLimiter != nil && callgate.FromContext(ctx) == nil {
+			// Agents run as tools keep the limiter of the invocation
+			// calling them.
+			ctx = callgate.ToContext(ctx, r.callLimiter)
+		}
+		if usage.FromContext(ctx) == nil {
+			// Agents run as tools share the usage of the invocation calling
+			// them.
+			ctx = usage.ToContext(ctx, &usage.Accumulator{})
+		}
 
 		var artifacts agent.Artifacts
 		if r.artifactService != nil {
@@ -197,9 +249,45 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 			}
 		}
 
+		var partialSaver *partialResponseSaver
+		if cfg.PartialResponsePersistInterval > 0 {
+			partialSaver = &partialResponseSaver{interval: cfg.PartialResponsePersistInterval}
+		}
+
+		var partial partialText
+		timedOut := func() bool {
+			return r.maxInvocationDuration > 0 && errors.Is(context.Cause(ctx), ErrInvocationTimeout)
+		}
+		// saveInterrupted saves the text of the response being streamed when
+		// the caller cancelled the context, so that the session keeps what
+		// the user has already seen.
+		saveInterrupted := func() (*session.Event, error) {
+			if ctx.Err() == nil || timedOut() {
+				return nil, nil
+			}
+			event := partial.interruptedEvent(ctx.InvocationID())
+			if event == nil {
+				return nil, nil
+			}
+			if partialSaver != nil {
+				// The interrupted event holds the partial text instead.
+				partialSaver.process(event)
+			}
+			if err := r.sessionService.AppendEvent(context.WithoutCancel(ctx), storedSession, event); err != nil {
+				return nil, fmt.Errorf("failed to add event to session: %w", err)
+			}
+			return event, nil
+		}
+
 		for event, err := range agentToRun.Run(ctx) {
+			if timedOut() {
+				// Stop the agent, even if it keeps producing events
+				// after the deadline.
+				break
+			}
 			if err != nil {
 				if !yield(event, err) {
+					_, _ = saveInterrupted()
 					return
 				}
 				continue
@@ -209,6 +297,7 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 				modifiedEvent, err := pluginManager.RunOnEventCallback(ctx, event)
 				if err != nil {
 					if !yield(nil, err) {
+						_, _ = saveInterrupted()
 						return
 					}
 					continue
@@ -218,6 +307,27 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 				}
 			}
 
+			if event.Kind == session.EventKindStatus {
+				// Status events are transient, neither persisted nor
+				// affecting the partial response checkpoints.
+				if !yield(event, nil) {
+					_, _ = saveInterrupted()
+					return
+				}
+				continue
+			}
+
+			partial.process(event)
+
+			if partialSaver != nil {
+				if checkpoint := partialSaver.process(event); checkpoint != nil {
+					if err := r.sessionService.AppendEvent(ctx, storedSession, checkpoint); err != nil {
+						yield(nil, fmt.Errorf("failed to save partial response to session: %w", err))
+						return
+					}
+				}
+			}
+
 			// only commit non-partial event to a session service
 			if !event.LLMResponse.Partial {
 				if err := r.sessionService.AppendEvent(ctx, storedSession, event); err != nil {
@@ -227,9 +337,32 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 			}
 
 			if !yield(event, nil) {
+				_, _ = saveInterrupted()
 				return
 			}
 		}
+
+		if timedOut() {
+			event := partial.timeoutEvent(ctx.InvocationID(), agentToRun.Name(), r.maxInvocationDuration)
+			if partialSaver != nil {
+				// The timeout event holds the partial text instead.
+				partialSaver.process(event)
+			}
+			if err := r.sessionService.AppendEvent(context.WithoutCancel(ctx), storedSession, event); err != nil {
+				yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+				return
+			}
+			yield(event, nil)
+		} else {
+			event, err := saveInterrupted()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if event != nil {
+				yield(event, nil)
+			}
+		}
 	}
 }
 
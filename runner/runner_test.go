@@ -17,18 +17,26 @@ package runner
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/agent/workflowagents/parallelagent"
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
 )
 
 func TestRunner_findAgentToRun(t *testing.T) {
@@ -393,3 +401,527 @@ func createSession(t *testing.T, ctx context.Context, sessionID, appName, userID
 
 	return resp.Session
 }
+
+func TestRunner_PartialResponsePersistInterval(t *testing.T) {
+	ctx := t.Context()
+	appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+	chunks := []string{"Once ", "upon ", "a ", "time"}
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				for _, chunk := range chunks {
+					ev := session.NewEvent(ctx.InvocationID())
+					ev.Author = "test_agent"
+					ev.LLMResponse = model.LLMResponse{
+						Content: genai.NewContentFromText(chunk, genai.RoleModel),
+						Partial: true,
+					}
+					if !yield(ev, nil) {
+						return
+					}
+				}
+				ev := session.NewEvent(ctx.InvocationID())
+				ev.Author = "test_agent"
+				ev.LLMResponse = model.LLMResponse{
+					Content: genai.NewContentFromText(strings.Join(chunks, ""), genai.RoleModel),
+				}
+				yield(ev, nil)
+			}
+		},
+	}))
+
+	tests := []struct {
+		name       string
+		stopAfter  int
+		wantText   string
+		wantLoaded bool
+	}{
+		{
+			name:       "interrupted while streaming",
+			stopAfter:  3,
+			wantText:   "Once upon a ",
+			wantLoaded: true,
+		},
+		{
+			name:       "completed response clears saved text",
+			stopAfter:  len(chunks) + 1,
+			wantLoaded: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionService := session.InMemoryService()
+			if _, err := sessionService.Create(ctx, &session.CreateRequest{
+				AppName:   appName,
+				UserID:    userID,
+				SessionID: sessionID,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			r, err := New(Config{
+				AppName:        appName,
+				Agent:          testAgent,
+				SessionService: sessionService,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := agent.RunConfig{
+				StreamingMode:                  agent.StreamingModeSSE,
+				PartialResponsePersistInterval: time.Nanosecond,
+			}
+			count := 0
+			for _, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("tell me a story", genai.RoleUser), cfg) {
+				if err != nil {
+					t.Fatalf("r.Run() returned an error: %v", err)
+				}
+				count++
+				if count == tt.stopAfter {
+					// Simulates a crash in the middle of the stream.
+					break
+				}
+			}
+
+			resp, err := sessionService.Get(ctx, &session.GetRequest{
+				AppName:   appName,
+				UserID:    userID,
+				SessionID: sessionID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := LoadPartialResponse(resp.Session)
+			if ok != tt.wantLoaded {
+				t.Fatalf("LoadPartialResponse() ok = %v, want %v", ok, tt.wantLoaded)
+			}
+			if !ok {
+				return
+			}
+			if got.Text != tt.wantText {
+				t.Errorf("LoadPartialResponse() text = %q, want %q", got.Text, tt.wantText)
+			}
+			if got.Author != "test_agent" {
+				t.Errorf("LoadPartialResponse() author = %q, want %q", got.Author, "test_agent")
+			}
+		})
+	}
+}
+
+func TestRunner_StatusEventsNotPersisted(t *testing.T) {
+	ctx := t.Context()
+	appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				status := session.NewEvent(ctx.InvocationID())
+				status.Author = "test_agent"
+				status.Kind = session.EventKindStatus
+				status.Status = session.AgentStatusFinalizing
+				if !yield(status, nil) {
+					return
+				}
+				ev := session.NewEvent(ctx.InvocationID())
+				ev.Author = "test_agent"
+				ev.LLMResponse = model.LLMResponse{
+					Content: genai.NewContentFromText("done", genai.RoleModel),
+				}
+				yield(ev, nil)
+			}
+		},
+	}))
+
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(Config{
+		AppName:        appName,
+		Agent:          testAgent,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotStatus []session.AgentStatus
+	for ev, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("r.Run() returned an error: %v", err)
+		}
+		if ev.Kind == session.EventKindStatus {
+			gotStatus = append(gotStatus, ev.Status)
+		}
+	}
+	if want := []session.AgentStatus{session.AgentStatusFinalizing}; !slices.Equal(gotStatus, want) {
+		t.Errorf("got streamed status events %v, want %v", gotStatus, want)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ev := range resp.Session.Events().All() {
+		if ev.Kind == session.EventKindStatus {
+			t.Errorf("status event %+v was saved to the session", ev)
+		}
+	}
+	if got := resp.Session.Events().Len(); got != 2 {
+		t.Errorf("got %d events in the session, want the user input and the response", got)
+	}
+}
+
+func TestRunner_MaxInvocationDuration(t *testing.T) {
+	ctx := t.Context()
+	appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+	var gotCause error
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				for _, text := range []string{"Hel", "lo"} {
+					ev := session.NewEvent(ctx.InvocationID())
+					ev.Author = "test_agent"
+					ev.LLMResponse = model.LLMResponse{
+						Content: genai.NewContentFromText(text, genai.RoleModel),
+						Partial: true,
+					}
+					if !yield(ev, nil) {
+						return
+					}
+				}
+				// A slow model call, only interrupted by the deadline.
+				<-ctx.Done()
+				gotCause = context.Cause(ctx)
+				yield(nil, ctx.Err())
+			}
+		},
+	}))
+
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(Config{
+		AppName:               appName,
+		Agent:                 testAgent,
+		SessionService:        sessionService,
+		MaxInvocationDuration: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last *session.Event
+	for ev, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("r.Run() returned an error: %v", err)
+		}
+		last = ev
+	}
+	if !errors.Is(gotCause, ErrInvocationTimeout) {
+		t.Errorf("got context cause %v, want %v", gotCause, ErrInvocationTimeout)
+	}
+	if last == nil || last.ErrorCode != InvocationTimeoutErrorCode {
+		t.Fatalf("got last event %+v, want a timeout event", last)
+	}
+	if last.Content == nil || len(last.Content.Parts) != 1 || last.Content.Parts[0].Text != "Hello" {
+		t.Errorf("got timeout event content %+v, want the partial text", last.Content)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := resp.Session.Events()
+	if got := events.At(events.Len() - 1); got.ErrorCode != InvocationTimeoutErrorCode {
+		t.Errorf("got last saved event %+v, want the timeout event", got)
+	}
+}
+
+// scriptedModel returns the responses in order, one per call.
+type scriptedModel struct {
+	responses []*genai.Content
+	calls     int
+}
+
+func (m *scriptedModel) Name() string { return "scripted" }
+
+func (m *scriptedModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if m.calls >= len(m.responses) {
+			yield(nil, fmt.Errorf("unexpected call %d", m.calls))
+			return
+		}
+		m.calls++
+		yield(&model.LLMResponse{Content: m.responses[m.calls-1]}, nil)
+	}
+}
+
+func TestRunner_OnRequest(t *testing.T) {
+	ctx := t.Context()
+	appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+	lookup, err := functiontool.New(functiontool.Config{
+		Name:        "lookup",
+		Description: "looks up a word",
+	}, func(_ tool.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"definition": "a greeting"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAgent := must(llmagent.New(llmagent.Config{
+		Name: "test_agent",
+		Model: &scriptedModel{responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("lookup", map[string]any{"word": "hi"}, genai.RoleModel),
+			genai.NewContentFromText("hi is a greeting", genai.RoleModel),
+		}},
+		Instruction: "Define words.",
+		Tools:       []tool.Tool{lookup},
+	}))
+
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var got [][]string
+	r, err := New(Config{
+		AppName:        appName,
+		Agent:          testAgent,
+		SessionService: sessionService,
+		OnRequest: func(req *model.LLMRequest) {
+			if req.Config == nil || req.Config.SystemInstruction == nil {
+				t.Errorf("OnRequest called before the instructions were added: %+v", req)
+			}
+			var roles []string
+			for _, c := range req.Contents {
+				roles = append(roles, c.Role)
+			}
+			got = append(got, roles)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("r.Run() returned an error: %v", err)
+		}
+	}
+	want := [][]string{
+		{genai.RoleUser},
+		{genai.RoleUser, genai.RoleModel, genai.RoleUser},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got request roles %v, want %v", got, want)
+	}
+}
+
+// concurrencyModel records the maximum number of its calls in flight.
+type concurrencyModel struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	calls       atomic.Int32
+}
+
+func (m *concurrencyModel) Name() string { return "concurrency" }
+
+func (m *concurrencyModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.calls.Add(1)
+		n := m.inFlight.Add(1)
+		for {
+			max := m.maxInFlight.Load()
+			if n <= max || m.maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		m.inFlight.Add(-1)
+		yield(&model.LLMResponse{Content: genai.NewContentFromText("done", genai.RoleModel)}, nil)
+	}
+}
+
+func TestRunner_CallLimiter(t *testing.T) {
+	ctx := t.Context()
+	appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+	// Two nested parallel agents fan out to six concurrent model calls.
+	llm := &concurrencyModel{}
+	var branches []agent.Agent
+	for i := range 2 {
+		var leaves []agent.Agent
+		for j := range 3 {
+			leaves = append(leaves, must(llmagent.New(llmagent.Config{
+				Name:  fmt.Sprintf("leaf_%d_%d", i, j),
+				Model: llm,
+			})))
+		}
+		branches = append(branches, must(parallelagent.New(parallelagent.Config{
+			AgentConfig: agent.Config{Name: fmt.Sprintf("branch_%d", i), SubAgents: leaves},
+		})))
+	}
+	root := must(parallelagent.New(parallelagent.Config{
+		AgentConfig: agent.Config{Name: "root", SubAgents: branches},
+	}))
+
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(Config{
+		AppName:        appName,
+		Agent:          root,
+		SessionService: sessionService,
+		CallLimiter:    semaphore.NewWeighted(2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+	}
+
+	if got := llm.calls.Load(); got != 6 {
+		t.Errorf("got %d model calls, want 6", got)
+	}
+	if got := llm.maxInFlight.Load(); got > 2 {
+		t.Errorf("got %d model calls in flight, want at most 2", got)
+	}
+}
+
+func TestRunner_CancelWhileStreaming(t *testing.T) {
+	tests := []struct {
+		name string
+		// stop is called with the cancel func of the run context after the
+		// last partial event, and reports whether to stop iterating.
+		stop func(cancel context.CancelFunc) bool
+	}{
+		{
+			name: "context cancelled",
+			stop: func(cancel context.CancelFunc) bool {
+				cancel()
+				return false
+			},
+		},
+		{
+			name: "context cancelled and iteration stopped",
+			stop: func(cancel context.CancelFunc) bool {
+				cancel()
+				return true
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			appName, userID, sessionID := "testApp", "testUser", "testSession"
+
+			testAgent := must(agent.New(agent.Config{
+				Name: "test_agent",
+				Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+					return func(yield func(*session.Event, error) bool) {
+						for _, text := range []string{"Hel", "lo"} {
+							ev := session.NewEvent(ctx.InvocationID())
+							ev.Author = "test_agent"
+							ev.LLMResponse = model.LLMResponse{
+								Content: genai.NewContentFromText(text, genai.RoleModel),
+								Partial: true,
+							}
+							if !yield(ev, nil) {
+								return
+							}
+						}
+						<-ctx.Done()
+						yield(nil, ctx.Err())
+					}
+				},
+			}))
+
+			sessionService := session.InMemoryService()
+			if _, err := sessionService.Create(t.Context(), &session.CreateRequest{
+				AppName:   appName,
+				UserID:    userID,
+				SessionID: sessionID,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			r, err := New(Config{
+				AppName:        appName,
+				Agent:          testAgent,
+				SessionService: sessionService,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			var last *session.Event
+			for ev, err := range r.Run(ctx, userID, sessionID, genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+				if err != nil {
+					if !errors.Is(err, context.Canceled) {
+						t.Fatalf("r.Run() returned an error: %v", err)
+					}
+					continue
+				}
+				last = ev
+				if ev.Partial && ev.Content.Parts[0].Text == "lo" && tc.stop(cancel) {
+					break
+				}
+			}
+
+			resp, err := sessionService.Get(t.Context(), &session.GetRequest{
+				AppName:   appName,
+				UserID:    userID,
+				SessionID: sessionID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			events := resp.Session.Events()
+			got := events.At(events.Len() - 1)
+			if !got.Interrupted || got.Partial || got.Author != "test_agent" {
+				t.Errorf("got last saved event %+v, want an interrupted event of test_agent", got)
+			}
+			if got.Content == nil || len(got.Content.Parts) != 1 || got.Content.Parts[0].Text != "Hello" {
+				t.Errorf("got saved content %+v, want the partial text", got.Content)
+			}
+			if tc.name == "context cancelled" && (last == nil || !last.Interrupted) {
+				t.Errorf("got last event %+v, want the interrupted event", last)
+			}
+		})
+	}
+}
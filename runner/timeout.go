@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// InvocationTimeoutErrorCode is the error code of the event emitted when an
+// invocation exceeds Config.MaxInvocationDuration.
+const InvocationTimeoutErrorCode = "INVOCATION_TIMEOUT"
+
+// ErrInvocationTimeout is the cause of the context cancellation when an
+// invocation exceeds Config.MaxInvocationDuration. Tools can check it with
+// context.Cause.
+var ErrInvocationTimeout = errors.New("invocation exceeded the maximum duration")
+
+// partialText accumulates the text of the partial events of the response
+// being streamed, to return it in the timeout and interrupted events.
+type partialText struct {
+	author string
+	branch string
+	text   strings.Builder
+}
+
+func (p *partialText) process(ev *session.Event) {
+	if !ev.Partial {
+		p.text.Reset()
+		return
+	}
+	if ev.Content == nil {
+		return
+	}
+	p.author = ev.Author
+	p.branch = ev.Branch
+	for _, part := range ev.Content.Parts {
+		if part.Text != "" && !part.Thought {
+			p.text.WriteString(part.Text)
+		}
+	}
+}
+
+// timeoutEvent returns the event ending an invocation that exceeded its
+// maximum duration, carrying the text of the interrupted response, if any.
+func (p *partialText) timeoutEvent(invocationID, agentName string, d time.Duration) *session.Event {
+	ev := session.NewEvent(invocationID)
+	ev.Author = agentName
+	ev.LLMResponse = model.LLMResponse{
+		ErrorCode:    InvocationTimeoutErrorCode,
+		ErrorMessage: fmt.Sprintf("%v of %v", ErrInvocationTimeout, d),
+		Interrupted:  true,
+		TurnComplete: true,
+	}
+	if p.text.Len() > 0 {
+		ev.Author = p.author
+		ev.Branch = p.branch
+		ev.Content = genai.NewContentFromText(p.text.String(), genai.RoleModel)
+	}
+	return ev
+}
+
+// interruptedEvent returns the event saving the text of a response cut short
+// by the caller, or nil if no text was streamed.
+func (p *partialText) interruptedEvent(invocationID string) *session.Event {
+	if p.text.Len() == 0 {
+		return nil
+	}
+	ev := session.NewEvent(invocationID)
+	ev.Author = p.author
+	ev.Branch = p.branch
+	ev.LLMResponse = model.LLMResponse{
+		Content:      genai.NewContentFromText(p.text.String(), genai.RoleModel),
+		Interrupted:  true,
+		TurnComplete: true,
+	}
+	p.text.Reset()
+	return ev
+}
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/server/adkrest/internal/sse"
 	"google.golang.org/adk/session"
 )
 
@@ -88,10 +89,6 @@ func (c *RuntimeAPIController) runAgent(ctx context.Context, runAgentRequest mod
 
 // RunSSEHandler executes an agent run and streams the resulting events using Server-Sent Events (SSE).
 func (c *RuntimeAPIController) RunSSEHandler(rw http.ResponseWriter, req *http.Request) error {
-	rw.Header().Set("Content-Type", "text/event-stream")
-	rw.Header().Set("Cache-Control", "no-cache")
-	rw.Header().Set("Connection", "keep-alive")
-
 	// set custom deadlines for this request - it overrides server-wide timeouts
 	rc := http.NewResponseController(rw)
 	deadline := time.Now().Add(c.sseTimeout)
@@ -117,44 +114,8 @@ func (c *RuntimeAPIController) RunSSEHandler(rw http.ResponseWriter, req *http.R
 
 	resp := r.Run(req.Context(), runAgentRequest.UserId, runAgentRequest.SessionId, &runAgentRequest.NewMessage, *rCfg)
 
-	rw.WriteHeader(http.StatusOK)
-	for event, err := range resp {
-		if err != nil {
-			_, err := fmt.Fprintf(rw, "Error while running agent: %v\n", err)
-			if err != nil {
-				return newStatusError(fmt.Errorf("failed to write response: %w", err), http.StatusInternalServerError)
-			}
-			err = rc.Flush()
-			if err != nil {
-				return newStatusError(fmt.Errorf("failed to flush: %w", err), http.StatusInternalServerError)
-			}
-
-			continue
-		}
-		err := flashEvent(rc, rw, *event)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func flashEvent(rc *http.ResponseController, rw http.ResponseWriter, event session.Event) error {
-	_, err := fmt.Fprintf(rw, "data: ")
-	if err != nil {
-		return newStatusError(fmt.Errorf("failed to write response: %w", err), http.StatusInternalServerError)
-	}
-	err = json.NewEncoder(rw).Encode(models.FromSessionEvent(event))
-	if err != nil {
-		return newStatusError(fmt.Errorf("failed to encode response: %w", err), http.StatusInternalServerError)
-	}
-	_, err = fmt.Fprintf(rw, "\n")
-	if err != nil {
-		return newStatusError(fmt.Errorf("failed to write response: %w", err), http.StatusInternalServerError)
-	}
-	err = rc.Flush()
-	if err != nil {
-		return newStatusError(fmt.Errorf("failed to flush: %w", err), http.StatusInternalServerError)
+	if err := sse.Write(rw, resp); err != nil {
+		return newStatusError(err, http.StatusInternalServerError)
 	}
 	return nil
 }
@@ -15,11 +15,21 @@
 package controllers
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/plugin"
 	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
 )
 
 func TestNewRuntimeAPIController_PluginsAssignment(t *testing.T) {
@@ -76,3 +86,40 @@ func TestNewRuntimeAPIController_PluginsAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestRunSSEHandler(t *testing.T) {
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "hello_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("Hello!", genai.RoleModel)}},
+	})
+	if err != nil {
+		t.Fatalf("llmagent.New() failed: %v", err)
+	}
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(t.Context(), &session.CreateRequest{AppName: "hello_agent", UserID: "user", SessionID: "s1"}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	controller := NewRuntimeAPIController(sessionService, nil, agent.NewSingleLoader(a), nil, 10*time.Second, runner.PluginConfig{})
+	server := httptest.NewServer(NewErrorHandler(controller.RunSSEHandler))
+	defer server.Close()
+
+	body := `{"appName":"hello_agent","userId":"user","sessionId":"s1","newMessage":{"role":"user","parts":[{"text":"hi"}]}}`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the response: %v", err)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	// The events are framed as by adkrest.WriteSSE.
+	frames := strings.Split(strings.TrimSuffix(string(got), "\n\n"), "\n\n")
+	if len(frames) != 1 || !strings.HasPrefix(frames[0], "event: message\nid: ") || !strings.Contains(frames[0], `"text":"Hello!"`) {
+		t.Errorf("response = %q, want a single message frame with the answer of the agent", got)
+	}
+}
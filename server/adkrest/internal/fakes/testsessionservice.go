@@ -36,6 +36,11 @@ func (s TestState) Set(key string, val any) error {
 	return nil
 }
 
+func (s TestState) Delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
 func (s TestState) All() iter.Seq2[string, any] {
 	return func(yield func(key string, val any) bool) {
 		for k, v := range s {
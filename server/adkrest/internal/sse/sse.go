@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package package sse implements the Server-Sent Events streaming of the events of
+// a run, shared by adkrest.WriteSSE and the run_sse endpoint of the REST
+// API.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+)
+
+// Event types written in the "event:" field of the frames.
+const (
+	EventMessage = "message"
+	EventPartial = "partial"
+	EventStatus  = "status"
+	EventError   = "error"
+)
+
+// Write streams the events to w as Server-Sent Events. See adkrest.WriteSSE.
+func Write(w http.ResponseWriter, events iter.Seq2[*session.Event, error]) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	for event, err := range events {
+		if err != nil {
+			if err := writeFrame(rc, w, EventError, "", map[string]string{"error": err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		if err := writeFrame(rc, w, eventType(event), event.ID, models.FromSessionEvent(*event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eventType(event *session.Event) string {
+	switch {
+	case event.Kind == session.EventKindStatus:
+		return EventStatus
+	case event.Partial:
+		return EventPartial
+	default:
+		return EventMessage
+	}
+}
+
+func writeFrame(rc *http.ResponseController, w http.ResponseWriter, eventType, id string, data any) error {
+	// The JSON encoding holds no newlines, so the data fits a single
+	// "data:" field.
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	frame := "event: " + eventType + "\n"
+	if id != "" {
+		frame += "id: " + id + "\n"
+	}
+	frame += "data: " + string(b) + "\n\n"
+	if _, err := fmt.Fprint(w, frame); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	if err := rc.Flush(); err != nil {
+		return fmt.Errorf("failed to flush event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkrest
+
+import (
+	"iter"
+	"net/http"
+
+	"google.golang.org/adk/server/adkrest/internal/sse"
+	"google.golang.org/adk/session"
+)
+
+// SSE event types written by WriteSSE in the "event:" field of the frames.
+const (
+	// SSEEventMessage is the type of the events of the agents.
+	SSEEventMessage = sse.EventMessage
+	// SSEEventPartial is the type of the partial events streamed while a
+	// model response is generated.
+	SSEEventPartial = sse.EventPartial
+	// SSEEventStatus is the type of the events of session.EventKindStatus.
+	SSEEventStatus = sse.EventStatus
+	// SSEEventError is the type of the errors of the run. Their data is an
+	// object with the error message in its "error" field.
+	SSEEventError = sse.EventError
+)
+
+// WriteSSE streams the events to w as Server-Sent Events, for web
+// frontends. It sets the response headers, then writes each event as a
+// frame with its type in the "event:" field, its ID in the "id:" field and
+// its JSON encoding, as served by the REST API, in the "data:" field, and
+// flushes it. Errors of the run are written as frames of type
+// SSEEventError, and the stream goes on. The run_sse endpoint of the REST
+// API streams its events the same way.
+//
+// Pass the events of a run bound to the context of the request, e.g.
+// runner.Run(req.Context(), ...), so that the run is cancelled when the
+// client disconnects. WriteSSE stops consuming the events and returns the
+// error as soon as writing to the client fails.
+func WriteSSE(w http.ResponseWriter, events iter.Seq2[*session.Event, error]) error {
+	return sse.Write(w, events)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkrest_test
+
+import (
+	"errors"
+	"iter"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/server/adkrest"
+	"google.golang.org/adk/session"
+)
+
+func TestWriteSSE(t *testing.T) {
+	partial := &session.Event{ID: "e1", LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromText("Hel", genai.RoleModel),
+		Partial: true,
+	}}
+	status := &session.Event{ID: "e2", Kind: session.EventKindStatus, Status: session.AgentStatusFinalizing}
+	final := &session.Event{ID: "e3", Author: "agent", LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromText("Hello", genai.RoleModel),
+	}}
+	events := func(yield func(*session.Event, error) bool) {
+		_ = yield(partial, nil) && yield(status, nil) && yield(nil, errors.New("model overloaded")) && yield(final, nil)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := adkrest.WriteSSE(rec, events); err != nil {
+		t.Fatalf("WriteSSE() failed: %v", err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	if !rec.Flushed {
+		t.Error("events were not flushed")
+	}
+	frames := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+	wantPrefixes := []string{
+		"event: partial\nid: e1\ndata: {",
+		"event: status\nid: e2\ndata: {",
+		"event: error\ndata: {\"error\":\"model overloaded\"}",
+		"event: message\nid: e3\ndata: {",
+	}
+	if len(frames) != len(wantPrefixes) {
+		t.Fatalf("got %d frames, want %d:\n%s", len(frames), len(wantPrefixes), rec.Body.String())
+	}
+	for i, want := range wantPrefixes {
+		if !strings.HasPrefix(frames[i], want) {
+			t.Errorf("frame %d = %q, want prefix %q", i, frames[i], want)
+		}
+	}
+	if !strings.Contains(frames[3], `"text":"Hello"`) {
+		t.Errorf("frame 3 = %q, want the text of the event", frames[3])
+	}
+}
+
+// failingWriter fails the writes, as when the client disconnected.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestWriteSSE_WriteFailure(t *testing.T) {
+	var yielded int
+	var events iter.Seq2[*session.Event, error] = func(yield func(*session.Event, error) bool) {
+		for {
+			yielded++
+			if !yield(&session.Event{ID: "e"}, nil) {
+				return
+			}
+		}
+	}
+
+	err := adkrest.WriteSSE(failingWriter{httptest.NewRecorder()}, events)
+	if err == nil {
+		t.Fatal("WriteSSE() succeeded, want the write error")
+	}
+	if yielded != 1 {
+		t.Errorf("got %d events consumed, want the stream to stop after the failed write", yielded)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"google.golang.org/adk/internal/sessionutils"
 	"google.golang.org/adk/session"
 )
 
@@ -108,13 +109,13 @@ func (s *databaseService) Create(ctx context.Context, req *session.CreateRequest
 
 		// apply state delta
 		if len(appDelta) > 0 {
-			maps.Copy(storageApp.State, appDelta)
+			sessionutils.ApplyStateDelta(storageApp.State, appDelta)
 			if err := tx.Save(&storageApp).Error; err != nil {
 				return fmt.Errorf("failed to save app state: %w", err)
 			}
 		}
 		if len(userDelta) > 0 {
-			maps.Copy(storageUser.State, userDelta)
+			sessionutils.ApplyStateDelta(storageUser.State, userDelta)
 			if err := tx.Save(&storageUser).Error; err != nil {
 				return fmt.Errorf("failed to save user state: %w", err)
 			}
@@ -392,19 +393,19 @@ func (s *databaseService) applyEvent(ctx context.Context, session *localSession,
 		// Merge state deltas and update the storage objects.
 		// GORM's .Save() method will correctly perform an INSERT or UPDATE.
 		if len(appDelta) > 0 {
-			maps.Copy(storageApp.State, appDelta)
+			sessionutils.ApplyStateDelta(storageApp.State, appDelta)
 			if err := tx.Save(&storageApp).Error; err != nil {
 				return fmt.Errorf("failed to save app state: %w", err)
 			}
 		}
 		if len(userDelta) > 0 {
-			maps.Copy(storageUser.State, userDelta)
+			sessionutils.ApplyStateDelta(storageUser.State, userDelta)
 			if err := tx.Save(&storageUser).Error; err != nil {
 				return fmt.Errorf("failed to save user state: %w", err)
 			}
 		}
 		if len(sessionDelta) > 0 {
-			maps.Copy(storageSess.State, sessionDelta)
+			sessionutils.ApplyStateDelta(storageSess.State, sessionDelta)
 			// The session state update will be saved along with the event timestamp update.
 		}
 
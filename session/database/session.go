@@ -149,6 +149,14 @@ func (s *state) Set(key string, value any) error {
 	return nil
 }
 
+func (s *state) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.state, key)
+	return nil
+}
+
 // TrimTempDeltaState removes temporary state delta keys from the event.
 func trimTempDeltaState(event *session.Event) *session.Event {
 	if len(event.Actions.StateDelta) == 0 {
@@ -184,6 +192,10 @@ func updateSessionState(sess *localSession, event *session.Event) error {
 		if strings.HasPrefix(key, session.KeyPrefixTemp) {
 			continue
 		}
+		if value == nil {
+			delete(sess.state, key)
+			continue
+		}
 		sess.state[key] = value
 	}
 
@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"iter"
+	"slices"
+
+	"google.golang.org/adk/model"
+)
+
+// EventSeverity is the severity of an event.
+type EventSeverity string
+
+const (
+	// EventSeverityDebug is the severity of the events only useful to
+	// debug an agent.
+	EventSeverityDebug EventSeverity = "debug"
+	// EventSeverityInfo is the severity of the regular events.
+	EventSeverityInfo EventSeverity = ""
+	// EventSeverityWarning is the severity of the events reporting an
+	// abnormal but recoverable situation.
+	EventSeverityWarning EventSeverity = "warning"
+	// EventSeverityError is the severity of the events reporting an error.
+	// Events with an error code have this severity whatever their Severity
+	// field.
+	EventSeverityError EventSeverity = "error"
+)
+
+func (s EventSeverity) rank() int {
+	switch s {
+	case EventSeverityDebug:
+		return 0
+	case EventSeverityWarning:
+		return 2
+	case EventSeverityError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// EventCategory is the category of an event, derived from its content.
+type EventCategory string
+
+const (
+	// EventCategoryContent is the category of the events carrying text or
+	// media of the conversation, e.g. the user input and model responses.
+	EventCategoryContent EventCategory = "content"
+	// EventCategoryTool is the category of the events carrying function
+	// calls or responses.
+	EventCategoryTool EventCategory = "tool"
+	// EventCategoryThinking is the category of the events only carrying
+	// thoughts of the model, e.g. the partial events streaming its
+	// reasoning, so that a UI can show them apart from the answer.
+	EventCategoryThinking EventCategory = "thinking"
+	// EventCategoryStatus is the category of the events of EventKindStatus.
+	EventCategoryStatus EventCategory = "status"
+	// EventCategoryError is the category of the events with an error code
+	// or of EventSeverityError.
+	EventCategoryError EventCategory = "error"
+)
+
+// EffectiveSeverity returns the severity of the event: EventSeverityError
+// if it has an error code, its Severity otherwise.
+func (e *Event) EffectiveSeverity() EventSeverity {
+	if e.ErrorCode != "" {
+		return EventSeverityError
+	}
+	return e.Severity
+}
+
+// Category returns the category of the event.
+func (e *Event) Category() EventCategory {
+	switch {
+	case e.EffectiveSeverity() == EventSeverityError:
+		return EventCategoryError
+	case e.Kind == EventKindStatus:
+		return EventCategoryStatus
+	case hasFunctionCalls(&e.LLMResponse) || hasFunctionResponses(&e.LLMResponse):
+		return EventCategoryTool
+	case onlyThoughts(&e.LLMResponse):
+		return EventCategoryThinking
+	default:
+		return EventCategoryContent
+	}
+}
+
+// onlyThoughts reports whether the content of resp only has thought parts.
+func onlyThoughts(resp *model.LLMResponse) bool {
+	if resp.Content == nil {
+		return false
+	}
+	found := false
+	for _, part := range resp.Content.Parts {
+		if part == nil {
+			continue
+		}
+		if !part.Thought {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// FilterEvents returns the events of stream keep returns true for, e.g. to
+// only show some categories of events in a UI while logs capture all of
+// them. The errors of stream are always passed through.
+func FilterEvents(stream iter.Seq2[*Event, error], keep func(*Event) bool) iter.Seq2[*Event, error] {
+	return func(yield func(*Event, error) bool) {
+		for event, err := range stream {
+			if err == nil && (event == nil || !keep(event)) {
+				continue
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// ContentOnly keeps the events of EventCategoryContent.
+func ContentOnly(e *Event) bool {
+	return e.Category() == EventCategoryContent
+}
+
+// ErrorsOnly keeps the events of EventCategoryError.
+func ErrorsOnly(e *Event) bool {
+	return e.Category() == EventCategoryError
+}
+
+// InCategories returns a predicate keeping the events of the given
+// categories.
+func InCategories(categories ...EventCategory) func(*Event) bool {
+	return func(e *Event) bool {
+		return slices.Contains(categories, e.Category())
+	}
+}
+
+// MinSeverity returns a predicate keeping the events of the given severity
+// or above, e.g. MinSeverity(EventSeverityInfo) leaves the debug events
+// out.
+func MinSeverity(severity EventSeverity) func(*Event) bool {
+	return func(e *Event) bool {
+		return e.EffectiveSeverity().rank() >= severity.rank()
+	}
+}
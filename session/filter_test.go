@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestFilterEvents(t *testing.T) {
+	text := &session.Event{ID: "text", LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromText("hi", genai.RoleModel),
+	}}
+	call := &session.Event{ID: "call", LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromFunctionCall("lookup", nil, genai.RoleModel),
+	}}
+	thinking := &session.Event{ID: "thinking", LLMResponse: model.LLMResponse{Partial: true, Content: &genai.Content{
+		Role:  genai.RoleModel,
+		Parts: []*genai.Part{{Text: "The user wants", Thought: true}},
+	}}}
+	status := &session.Event{ID: "status", Kind: session.EventKindStatus, Status: session.AgentStatusPlanning}
+	failed := &session.Event{ID: "failed", LLMResponse: model.LLMResponse{ErrorCode: "SAFETY"}}
+	debug := &session.Event{ID: "debug", Severity: session.EventSeverityDebug, LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromText("cache miss", genai.RoleModel),
+	}}
+	warning := &session.Event{ID: "warning", Severity: session.EventSeverityWarning, LLMResponse: model.LLMResponse{
+		Content: genai.NewContentFromText("retrying", genai.RoleModel),
+	}}
+	streamErr := errors.New("stream failed")
+	stream := func(yield func(*session.Event, error) bool) {
+		for _, ev := range []*session.Event{text, thinking, call, status, failed, debug, warning} {
+			if !yield(ev, nil) {
+				return
+			}
+		}
+		yield(nil, streamErr)
+	}
+
+	tests := []struct {
+		name string
+		keep func(*session.Event) bool
+		want []string
+	}{
+		{name: "content only", keep: session.ContentOnly, want: []string{"text", "debug", "warning", "error"}},
+		{name: "errors only", keep: session.ErrorsOnly, want: []string{"failed", "error"}},
+		{name: "thinking", keep: session.InCategories(session.EventCategoryThinking), want: []string{"thinking", "error"}},
+		{
+			name: "content and status",
+			keep: session.InCategories(session.EventCategoryContent, session.EventCategoryStatus),
+			want: []string{"text", "status", "debug", "warning", "error"},
+		},
+		{name: "warnings and above", keep: session.MinSeverity(session.EventSeverityWarning), want: []string{"failed", "warning", "error"}},
+		{name: "no debug", keep: session.MinSeverity(session.EventSeverityInfo), want: []string{"text", "thinking", "call", "status", "failed", "warning", "error"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for ev, err := range session.FilterEvents(stream, tc.keep) {
+				if err != nil {
+					got = append(got, "error")
+					continue
+				}
+				got = append(got, ev.ID)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FilterEvents() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
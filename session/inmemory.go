@@ -230,7 +230,7 @@ func (s *inMemoryService) AppendEvent(ctx context.Context, curSession Session, e
 		appDelta, userDelta, sessionDelta := sessionutils.ExtractStateDeltas(event.Actions.StateDelta)
 		s.updateAppState(appDelta, curSession.AppName())
 		s.updateUserState(userDelta, curSession.AppName(), curSession.UserID())
-		maps.Copy(stored_session.state, sessionDelta)
+		sessionutils.ApplyStateDelta(stored_session.state, sessionDelta)
 	}
 	return nil
 }
@@ -241,7 +241,7 @@ func (s *inMemoryService) updateAppState(appDelta stateMap, appName string) stat
 		innerMap = make(stateMap)
 		s.appState[appName] = innerMap
 	}
-	maps.Copy(innerMap, appDelta)
+	sessionutils.ApplyStateDelta(innerMap, appDelta)
 	return innerMap
 }
 
@@ -256,7 +256,7 @@ func (s *inMemoryService) updateUserState(userDelta stateMap, appName, userID st
 		innerMap = make(stateMap)
 		innerUsersMap[userID] = innerMap
 	}
-	maps.Copy(innerMap, userDelta)
+	sessionutils.ApplyStateDelta(innerMap, userDelta)
 	return innerMap
 }
 
@@ -267,7 +267,10 @@ func (s *inMemoryService) mergeStates(state stateMap, appName, userID string) st
 	if ok {
 		userState = userStateMap[userID]
 	}
-	return sessionutils.MergeStates(appState, userState, state)
+	// The app and user keys of the stored session state may be stale, e.g.
+	// deleted since, the service's app and user states are authoritative.
+	_, _, sessionState := sessionutils.ExtractStateDeltas(state)
+	return sessionutils.MergeStates(appState, userState, sessionState)
 }
 
 func (id id) Encode() string {
@@ -403,6 +406,14 @@ func (s *state) Set(key string, value any) error {
 	return nil
 }
 
+func (s *state) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.state, key)
+	return nil
+}
+
 // trimTempDeltaState removes temporary state delta keys from the event.
 func trimTempDeltaState(event *Event) *Event {
 	if len(event.Actions.StateDelta) == 0 {
@@ -439,7 +450,12 @@ func updateSessionState(session *session, event *Event) error {
 		if strings.HasPrefix(key, KeyPrefixTemp) {
 			continue
 		}
-		err := state.Set(key, value)
+		var err error
+		if value == nil {
+			err = state.Delete(key)
+		} else {
+			err = state.Set(key, value)
+		}
 		if err != nil {
 			return fmt.Errorf("error on updateSessionState state: %w", err)
 		}
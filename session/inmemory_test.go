@@ -15,6 +15,7 @@
 package session
 
 import (
+	"errors"
 	"maps"
 	"strconv"
 	"strings"
@@ -935,6 +936,31 @@ func Test_inMemoryService_StateManagement(t *testing.T) {
 			t.Errorf("Expected 'sk' key in stored event, but was missing or wrong value")
 		}
 	})
+
+	t.Run("nil_delta_deletes_keys", func(t *testing.T) {
+		s := emptyService(t)
+		s1, _ := s.Create(ctx, &CreateRequest{AppName: appName, UserID: "u1", SessionID: "s1", State: map[string]any{
+			"app:k1": "v1", "user:k1": "v1", "sk1": "v1", "sk2": "v2",
+		}})
+		s1.Session.(*session).updatedAt = time.Now()
+		_ = s.AppendEvent(ctx, s1.Session.(*session), &Event{
+			ID:          "event1",
+			Actions:     EventActions{StateDelta: map[string]any{"app:k1": nil, "user:k1": nil, "sk1": nil}},
+			LLMResponse: model.LLMResponse{},
+		})
+
+		wantState := map[string]any{"sk2": "v2"}
+		if diff := cmp.Diff(wantState, maps.Collect(s1.Session.State().All())); diff != "" {
+			t.Errorf("Session state mismatch (-want +got):\n%s", diff)
+		}
+		s1_got, _ := s.Get(ctx, &GetRequest{AppName: appName, UserID: "u1", SessionID: "s1"})
+		if diff := cmp.Diff(wantState, maps.Collect(s1_got.Session.State().All())); diff != "" {
+			t.Errorf("Persisted state mismatch (-want +got):\n%s", diff)
+		}
+		if _, err := s1_got.Session.State().Get("sk1"); !errors.Is(err, ErrStateKeyNotExist) {
+			t.Errorf("Get() of a deleted key error = %v, want %v", err, ErrStateKeyNotExist)
+		}
+	})
 }
 
 func serviceDbWithData(t *testing.T) Service {
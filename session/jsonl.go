@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// StreamToJSONL writes each event of events to w as a line of JSON, e.g. to
+// pipe the events returned by runner.Runner.Run to a log collector.
+//
+// If w has a Flush method, like bufio.Writer or http.ResponseWriter, it is
+// called after each event. StreamToJSONL stops and returns the first error
+// yielded by events or encountered while writing.
+func StreamToJSONL(w io.Writer, events iter.Seq2[*Event, error]) error {
+	enc := json.NewEncoder(w)
+	for event, err := range events {
+		if err != nil {
+			return err
+		}
+		// Encode terminates each value with a newline.
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+		if err := flush(w); err != nil {
+			return fmt.Errorf("failed to flush event: %w", err)
+		}
+	}
+	return nil
+}
+
+func flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func eventsOf(events []*Event, err error) iter.Seq2[*Event, error] {
+	return func(yield func(*Event, error) bool) {
+		for _, e := range events {
+			if !yield(e, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func TestStreamToJSONL(t *testing.T) {
+	events := []*Event{
+		{ID: "1", Author: "user", LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("hi", genai.RoleUser)}},
+		{ID: "2", Author: "agent", LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("hello", genai.RoleModel)}},
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := StreamToJSONL(w, eventsOf(events, nil)); err != nil {
+		t.Fatalf("StreamToJSONL() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(events), buf.String())
+	}
+	for i, line := range lines {
+		var got Event
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not a JSON event: %v", i, err)
+		}
+		if diff := cmp.Diff(events[i], &got); diff != "" {
+			t.Errorf("line %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestStreamToJSONL_ReturnsFirstError(t *testing.T) {
+	wantErr := errors.New("model failed")
+	events := []*Event{{ID: "1", Author: "agent"}}
+
+	var buf bytes.Buffer
+	err := StreamToJSONL(&buf, eventsOf(events, wantErr))
+	if !errors.Is(err, wantErr) {
+		t.Errorf("StreamToJSONL() error = %v, want %v", err, wantErr)
+	}
+	if got := strings.Count(buf.String(), "\n"); got != 1 {
+		t.Errorf("got %d lines written before the error, want 1", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestStreamToJSONL_WriteError(t *testing.T) {
+	events := []*Event{{ID: "1"}, {ID: "2"}}
+
+	err := StreamToJSONL(failingWriter{}, eventsOf(events, nil))
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("StreamToJSONL() error = %v, want the write error", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import "strings"
+
+// CallRationaleKey is the key of Event.CustomMetadata holding the text the
+// model gave before the function calls of the event, when it was streamed
+// in a preceding event, as in SSE streaming runs.
+const CallRationaleKey = "adk_call_rationale"
+
+// CallRationale returns the text the model gave before the function call
+// with the given ID, e.g. "I need the weather in Paris to answer.", for
+// explainability UIs and audits. It is empty if the event has no such call
+// or no text before it.
+//
+// The rationale of a call is the last run of text parts preceding it,
+// thoughts included, so that parallel calls following the same text share
+// it, and a call following another text has its own. Without text before
+// the call in the event, it is the one recorded under CallRationaleKey.
+func (e *Event) CallRationale(callID string) string {
+	if e == nil || e.Content == nil || callID == "" {
+		return ""
+	}
+	var texts []string
+	afterCall := false
+	for _, part := range e.Content.Parts {
+		switch {
+		case part == nil:
+		case part.FunctionCall != nil:
+			if part.FunctionCall.ID == callID {
+				if len(texts) == 0 {
+					rationale, _ := e.CustomMetadata[CallRationaleKey].(string)
+					return rationale
+				}
+				return strings.TrimSpace(strings.Join(texts, ""))
+			}
+			afterCall = true
+		case part.Text != "":
+			if afterCall {
+				texts, afterCall = nil, false
+			}
+			texts = append(texts, part.Text)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestEvent_CallRationale(t *testing.T) {
+	call := func(id string) *genai.Part {
+		return &genai.Part{FunctionCall: &genai.FunctionCall{ID: id, Name: "lookup"}}
+	}
+	event := &session.Event{LLMResponse: model.LLMResponse{Content: &genai.Content{
+		Role: genai.RoleModel,
+		Parts: []*genai.Part{
+			{Text: "The user asks about two cities, ", Thought: true},
+			{Text: "I need the weather of both."},
+			call("paris"),
+			call("rome"),
+			{Text: "\nI also need the time in Tokyo.\n"},
+			call("tokyo"),
+			call("nairobi"),
+		},
+	}}}
+
+	for id, want := range map[string]string{
+		"paris":   "The user asks about two cities, I need the weather of both.",
+		"rome":    "The user asks about two cities, I need the weather of both.",
+		"tokyo":   "I also need the time in Tokyo.",
+		"nairobi": "I also need the time in Tokyo.",
+		"missing": "",
+	} {
+		if got := event.CallRationale(id); got != want {
+			t.Errorf("CallRationale(%q) = %q, want %q", id, got, want)
+		}
+	}
+
+	noText := &session.Event{LLMResponse: model.LLMResponse{
+		Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{call("paris")}},
+	}}
+	if got := noText.CallRationale("paris"); got != "" {
+		t.Errorf("CallRationale() of a call without text = %q, want none", got)
+	}
+
+	streamed := &session.Event{LLMResponse: model.LLMResponse{
+		Content:        &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{call("paris")}},
+		CustomMetadata: map[string]any{session.CallRationaleKey: "I need the weather in Paris."},
+	}}
+	if got, want := streamed.CallRationale("paris"), "I need the weather in Paris."; got != want {
+		t.Errorf("CallRationale() of a call after streamed text = %q, want %q", got, want)
+	}
+}
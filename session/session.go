@@ -48,6 +48,15 @@ type Session interface {
 // State defines a standard interface for a key-value store.
 // It provides basic methods for accessing, modifying, and iterating over
 // key-value pairs.
+//
+// The prefix of a key sets the scope of its value: KeyPrefixApp for the
+// whole application, KeyPrefixUser for all the sessions of the user, and
+// KeyPrefixTemp for the current invocation only. Keys without prefix are
+// scoped to the session.
+//
+// The changes made by agents, tools and callbacks are recorded in the
+// EventActions.StateDelta of their event and applied when the event is
+// appended to the session, a nil value deleting the key.
 type State interface {
 	// Get retrieves the value associated with a given key.
 	// It returns a ErrStateKeyNotExist error if the key does not exist.
@@ -56,6 +65,9 @@ type State interface {
 	// existing value. It returns an error if the underlying storage
 	// operation fails.
 	Set(string, any) error
+	// Delete removes the given key. Removing a key that does not exist is
+	// not an error.
+	Delete(string) error
 	// All returns an iterator (iter.Seq2) that yields all key-value pairs
 	// currently in the state. The order of iteration is not guaranteed.
 	All() iter.Seq2[string, any]
@@ -115,13 +127,52 @@ type Event struct {
 	// Agent client will know from this field about which function call is long running.
 	// Only valid for function call event.
 	LongRunningToolIDs []string
+
+	// Kind distinguishes events that are not part of the conversation, such
+	// as status updates, from the regular content and tool events.
+	Kind EventKind
+	// Status is the phase the agent entered, for events of EventKindStatus.
+	Status AgentStatus
+	// Severity lets consumers filter the events, e.g. to leave debug
+	// events out of a UI. See FilterEvents.
+	Severity EventSeverity
 }
 
+// EventKind is the kind of an event.
+type EventKind string
+
+const (
+	// EventKindDefault is the kind of the events of the conversation: user
+	// input, model responses, function calls and responses, etc.
+	EventKindDefault EventKind = ""
+	// EventKindStatus is the kind of the events that report the phase an
+	// agent entered, e.g. to show a progress label in a UI. They carry no
+	// content and are not saved to the session.
+	EventKindStatus EventKind = "status"
+)
+
+// AgentStatus is the phase of an agent run reported by status events.
+type AgentStatus string
+
+const (
+	// AgentStatusPlanning is reported before the agent calls the model.
+	AgentStatusPlanning AgentStatus = "planning"
+	// AgentStatusCallingTools is reported before the agent runs the tools
+	// called by the model.
+	AgentStatusCallingTools AgentStatus = "calling_tools"
+	// AgentStatusFinalizing is reported before the agent returns its final
+	// response.
+	AgentStatusFinalizing AgentStatus = "finalizing"
+)
+
 // IsFinalResponse returns whether the event is the final response of an agent.
 //
 // Note: when multiple agents participate in one invocation, there could be
 // multiple events with IsFinalResponse() as True, for each participating agent.
 func (e *Event) IsFinalResponse() bool {
+	if e.Kind == EventKindStatus {
+		return false
+	}
 	if (e.Actions.SkipSummarization) || len(e.LongRunningToolIDs) > 0 {
 		return true
 	}
@@ -157,6 +208,11 @@ type EventActions struct {
 	TransferToAgent string
 	// The agent is escalating to a higher level agent.
 	Escalate bool
+	// Sections of the agent's system instruction set by tools, by key. They
+	// are appended to the instruction for the following model calls of the
+	// agent, in the same invocation only. A later update of a key replaces
+	// the section, and an empty value removes it.
+	InstructionUpdates map[string]string
 }
 
 // Prefixes for defining session's state scopes
@@ -142,5 +142,13 @@ func (s *vertexAiService) AppendEvent(ctx context.Context, sess session.Session,
 	if err != nil {
 		return fmt.Errorf("failed to append event: %w", err)
 	}
+	// Vertex AI merges the state deltas of the events into the session state
+	// without deleting keys, so the deletions are persisted by writing back
+	// the whole session state.
+	if deletesState(event.Actions.StateDelta) {
+		if err := s.client.updateSessionState(ctx, sessInt); err != nil {
+			return fmt.Errorf("failed to delete state keys: %w", err)
+		}
+	}
 	return nil
 }
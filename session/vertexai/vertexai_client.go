@@ -27,6 +27,7 @@ import (
 	"google.golang.org/genai"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -284,6 +285,35 @@ func (c *vertexAiClient) appendEvent(ctx context.Context, appName, sessionID str
 	return nil
 }
 
+// updateSessionState replaces the state of the session stored by Vertex AI
+// with the state of sess.
+func (c *vertexAiClient) updateSessionState(ctx context.Context, sess *localSession) error {
+	reasoningEngine, err := c.getReasoningEngineID(sess.appName)
+	if err != nil {
+		return err
+	}
+
+	sess.mu.RLock()
+	sessionState, err := structpb.NewStruct(sess.state)
+	sess.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to convert state to structpb: %w", err)
+	}
+
+	_, err = c.rpcClient.UpdateSession(ctx, &aiplatformpb.UpdateSessionRequest{
+		Session: &aiplatformpb.Session{
+			Name:         sessionNameByID(sess.sessionID, c, reasoningEngine),
+			UserId:       sess.userID,
+			SessionState: sessionState,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"session_state"}},
+	})
+	if err != nil {
+		return fmt.Errorf("error updating session state: %w", err)
+	}
+	return nil
+}
+
 func (c *vertexAiClient) listSessionEvents(ctx context.Context, appName, sessionID string, after time.Time, numRecentEvents int) ([]*session.Event, error) {
 	reasoningEngine, err := c.getReasoningEngineID(appName)
 	if err != nil {
@@ -16,6 +16,10 @@ package vertexai
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/session"
 )
 
 func TestGetReasoningEngineID(t *testing.T) {
@@ -100,3 +104,22 @@ func TestGetReasoningEngineID(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalSessionAppendEventDeletesState(t *testing.T) {
+	sess := &localSession{state: map[string]any{"kept": 1, "deleted": 2, "user:deleted": 3}}
+	event := session.NewEvent("invocation")
+	event.Actions.StateDelta = map[string]any{"deleted": nil, "user:deleted": nil, "temp:deleted": nil, "added": 4}
+
+	if err := sess.appendEvent(event); err != nil {
+		t.Fatalf("appendEvent() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"kept": 1, "added": 4}, sess.state); diff != "" {
+		t.Errorf("state mismatch (-want +got):\n%s", diff)
+	}
+	if !deletesState(event.Actions.StateDelta) {
+		t.Errorf("deletesState(%v) = false, want true", event.Actions.StateDelta)
+	}
+	if deletesState(map[string]any{"added": 4}) {
+		t.Error("deletesState() = true for a delta without nil values, want false")
+	}
+}
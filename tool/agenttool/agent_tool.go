@@ -18,8 +18,11 @@
 package agenttool
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"google.golang.org/genai"
@@ -27,6 +30,7 @@ import (
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/internal/llminternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
@@ -35,10 +39,28 @@ import (
 	"google.golang.org/adk/tool"
 )
 
+// DefaultMaxAgentDepth is the maximum number of nested agent tool calls
+// allowed when Config.MaxAgentDepth is not set.
+const DefaultMaxAgentDepth = 10
+
+var (
+	// ErrMaxAgentDepthExceeded is returned by the agent tool when running the
+	// wrapped agent would exceed the maximum agent call depth.
+	ErrMaxAgentDepthExceeded = errors.New("maximum agent depth exceeded")
+	// ErrAgentCycle is returned by the agent tool when the wrapped agent is
+	// already running higher up in the agent call chain, e.g. when an agent
+	// calls itself.
+	ErrAgentCycle = errors.New("agent call cycle detected")
+)
+
 // agentTool implements a tool that allows an agent to call another agent.
 type agentTool struct {
-	agent             agent.Agent
-	skipSummarization bool
+	agent               agent.Agent
+	skipSummarization   bool
+	maxAgentDepth       int
+	inputSchemaOverride *genai.Schema
+	isolateState        bool
+	shareArtifacts      bool
 }
 
 // Config holds the configuration for an agent tool.
@@ -46,20 +68,44 @@ type Config struct {
 	// SkipSummarization, if true, will cause the agent to skip summarization
 	// after the sub-agent finishes execution.
 	SkipSummarization bool
+	// MaxAgentDepth limits how deeply agent tools can be nested, i.e. how many
+	// agents can be running as tools of each other at the same time.
+	// If it is zero, DefaultMaxAgentDepth is used.
+	MaxAgentDepth int
+	// InputSchema, if set, is the schema of the tool's parameters, instead
+	// of the wrapped agent's input schema or the default "request" string
+	// parameter. The arguments are validated against it and sent to the
+	// wrapped agent as JSON.
+	InputSchema *genai.Schema
+	// IsolateState, if true, runs the wrapped agent with an empty session
+	// state. By default, it starts with a copy of the caller's session
+	// state, without the ADK internal keys. In both cases, the state changes
+	// of the wrapped agent are not applied to the caller's session.
+	IsolateState bool
+	// ShareArtifacts, if true, gives the wrapped agent access to the
+	// artifacts of the caller's session: it can load them, and the
+	// artifacts it saves are saved to the caller's session. By default, it
+	// gets its own, initially empty, artifacts.
+	ShareArtifacts bool
 }
 
 // New creates a new agent tool.
 // If cfg is nil, skipSummarization defaults to false.
 func New(agent agent.Agent, cfg *Config) tool.Tool {
 	if cfg == nil {
-		return &agentTool{
-			agent:             agent,
-			skipSummarization: false,
-		}
+		cfg = &Config{}
+	}
+	maxAgentDepth := cfg.MaxAgentDepth
+	if maxAgentDepth <= 0 {
+		maxAgentDepth = DefaultMaxAgentDepth
 	}
 	return &agentTool{
-		agent:             agent,
-		skipSummarization: cfg.SkipSummarization,
+		agent:               agent,
+		skipSummarization:   cfg.SkipSummarization,
+		maxAgentDepth:       maxAgentDepth,
+		inputSchemaOverride: cfg.InputSchema,
+		isolateState:        cfg.IsolateState,
+		shareArtifacts:      cfg.ShareArtifacts,
 	}
 }
 
@@ -78,9 +124,21 @@ func (t *agentTool) IsLongRunning() bool {
 	return false
 }
 
+// inputSchema returns the schema of the tool's parameters: the configured
+// one, or else the wrapped agent's input schema, if any.
+func (t *agentTool) inputSchema() *genai.Schema {
+	if t.inputSchemaOverride != nil {
+		return t.inputSchemaOverride
+	}
+	if llmAgent, ok := t.agent.(llminternal.Agent); ok && llmAgent != nil {
+		return llminternal.Reveal(llmAgent).InputSchema
+	}
+	return nil
+}
+
 // Declaration returns the function declaration for the wrapped agent.
-// It generates a function declaration based on the agent's input schema.
-// If the agent does not have an input schema, a default schema with a
+// It generates a function declaration based on the configured input schema
+// or the agent's input schema. If there is none, a default schema with a
 // "request" string parameter is used.
 func (t *agentTool) Declaration() *genai.FunctionDeclaration {
 	decl := &genai.FunctionDeclaration{
@@ -88,18 +146,8 @@ func (t *agentTool) Declaration() *genai.FunctionDeclaration {
 		Description: t.Description(),
 	}
 
-	var agentInputSchema *genai.Schema
-	llmAgent, ok := t.agent.(llminternal.Agent)
-	if ok && llmAgent != nil {
-		// TODO - understand what build_function_declaration does in python and apply if needed.
-		internalLlmAgent, ok := t.agent.(llminternal.Agent)
-		if !ok {
-			return nil
-		}
-		agentInputSchema = llminternal.Reveal(internalLlmAgent).InputSchema
-	}
-
-	if agentInputSchema != nil {
+	// TODO - understand what build_function_declaration does in python and apply if needed.
+	if agentInputSchema := t.inputSchema(); agentInputSchema != nil {
 		decl.Parameters = agentInputSchema
 	} else {
 		decl.Parameters = &genai.Schema{
@@ -123,22 +171,27 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 		return nil, fmt.Errorf("agentTool expects map[string]any arguments, got %T", args)
 	}
 
+	callChain := agentCallChainFromContext(toolCtx)
+	if len(callChain) == 0 {
+		callChain = []string{toolCtx.AgentName()}
+	}
+	if slices.Contains(callChain, t.agent.Name()) {
+		return nil, fmt.Errorf("%w: agent %q is already running in the call chain %q", ErrAgentCycle, t.agent.Name(), strings.Join(callChain, " -> "))
+	}
+	if len(callChain)-1 >= t.maxAgentDepth {
+		return nil, fmt.Errorf("%w: calling agent %q would exceed the maximum depth of %d", ErrMaxAgentDepthExceeded, t.agent.Name(), t.maxAgentDepth)
+	}
+	callChain = append(slices.Clone(callChain), t.agent.Name())
+
 	if t.skipSummarization {
 		if actions := toolCtx.Actions(); actions != nil {
 			actions.SkipSummarization = true
 		}
 	}
 
-	var agentInputSchema *genai.Schema
 	llmAgent, ok := t.agent.(llminternal.Agent)
 	isLllmAgent := (ok && llmAgent != nil)
-	if isLllmAgent {
-		internalLlmAgent, ok := t.agent.(llminternal.Agent)
-		if !ok {
-			return nil, fmt.Errorf("internal error: failed to convert to llm agent")
-		}
-		agentInputSchema = llminternal.Reveal(internalLlmAgent).InputSchema
-	}
+	agentInputSchema := t.inputSchema()
 
 	var content *genai.Content
 	var err error
@@ -166,12 +219,16 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 
 	sessionService := session.InMemoryService()
 
+	var artifactService artifact.Service = artifact.InMemoryService()
+	if t.shareArtifacts && toolCtx.Artifacts() != nil {
+		artifactService = &forwardingArtifactService{artifacts: toolCtx.Artifacts()}
+	}
+
 	r, err := runner.New(runner.Config{
-		AppName:        t.agent.Name(),
-		Agent:          t.agent,
-		SessionService: sessionService,
-		// TODO - use forwarding_artifact_service as in python.
-		ArtifactService: artifact.InMemoryService(),
+		AppName:         t.agent.Name(),
+		Agent:           t.agent,
+		SessionService:  sessionService,
+		ArtifactService: artifactService,
 		MemoryService:   memory.InMemoryService(),
 	})
 	if err != nil {
@@ -180,10 +237,12 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 
 	stateMap := make(map[string]any)
 
-	for k, v := range toolCtx.State().All() {
-		// Filter out adk internal states.
-		if !strings.HasPrefix(k, "_adk") {
-			stateMap[k] = v
+	if !t.isolateState {
+		for k, v := range toolCtx.State().All() {
+			// Filter out adk internal states.
+			if !strings.HasPrefix(k, "_adk") {
+				stateMap[k] = v
+			}
 		}
 	}
 
@@ -197,7 +256,8 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 	}
 
 	// TODO(dpasiukevich): verify agent loop termination.
-	eventCh := r.Run(toolCtx, subSession.Session.UserID(), subSession.Session.ID(), content, agent.RunConfig{
+	subCtx := context.WithValue(toolCtx, agentCallChainCtxKey, callChain)
+	eventCh := r.Run(subCtx, subSession.Session.UserID(), subSession.Session.ID(), content, agent.RunConfig{
 		StreamingMode: agent.StreamingModeSSE,
 	})
 
@@ -249,39 +309,20 @@ func (t *agentTool) Run(toolCtx tool.Context, args any) (map[string]any, error)
 	return map[string]any{"result": outputText}, nil
 }
 
-// ProcessRequest adds the agent tool's function declaration to the LLM request.
-func (t *agentTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
-	// TODO extract this function somewhere else, simillar operations are done for
-	// other tools with function declaration.
-	if req.Tools == nil {
-		req.Tools = make(map[string]any)
-	}
+type ctxKey int
 
-	name := t.Name()
-	if _, ok := req.Tools[name]; ok {
-		return fmt.Errorf("duplicate tool: %q", name)
-	}
-	req.Tools[name] = t
+const agentCallChainCtxKey ctxKey = 0
 
-	if req.Config == nil {
-		req.Config = &genai.GenerateContentConfig{}
-	}
-	if decl := t.Declaration(); decl == nil {
-		return nil
-	}
-	var funcTool *genai.Tool
-	for _, tool := range req.Config.Tools {
-		if tool != nil && tool.FunctionDeclarations != nil {
-			funcTool = tool
-			break
-		}
-	}
-	if funcTool == nil {
-		req.Config.Tools = append(req.Config.Tools, &genai.Tool{
-			FunctionDeclarations: []*genai.FunctionDeclaration{t.Declaration()},
-		})
-	} else {
-		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, t.Declaration())
-	}
-	return nil
+// agentCallChainFromContext returns the name of the agent that called the
+// outermost agent tool, followed by the names of the agents currently running
+// as agent tools, from the outermost to the innermost one. It is empty outside
+// of an agent tool.
+func agentCallChainFromContext(ctx context.Context) []string {
+	chain, _ := ctx.Value(agentCallChainCtxKey).([]string)
+	return chain
+}
+
+// ProcessRequest adds the agent tool's function declaration to the LLM request.
+func (t *agentTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
 }
@@ -15,7 +15,9 @@
 package agenttool_test
 
 import (
+	"errors"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +25,8 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/sessioninternal"
 	"google.golang.org/adk/internal/testutil"
@@ -104,7 +108,7 @@ func TestAgentTool_Run_InputValidation(t *testing.T) {
 	}
 	agent := createAgent(t, inputSchema, nil)
 	agentTool := agenttool.New(agent, nil)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 
 	tests := []struct {
 		name string
@@ -157,7 +161,7 @@ func TestAgentTool_Run_OutputValidation(t *testing.T) {
 
 	agent := createAgentWithModel(t, nil, outputSchema, testLLM)
 	agentTool := agenttool.New(agent, nil)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 	toolImpl, ok := agentTool.(toolinternal.FunctionTool)
 	if !ok {
 		t.Fatal("agentTool does not implement FunctionTool")
@@ -192,7 +196,7 @@ func TestAgentTool_Run_Successful(t *testing.T) {
 	}
 	agent := createAgentWithModel(t, inputSchema, outputSchema, testLLM)
 	agentTool := agenttool.New(agent, nil)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 	toolImpl, ok := agentTool.(toolinternal.FunctionTool)
 	if !ok {
 		t.Fatal("agentTool does not implement FunctionTool")
@@ -224,7 +228,7 @@ func TestAgentTool_Run_WithoutSchema(t *testing.T) {
 
 	agent := createAgentWithModel(t, nil, nil, testLLM)
 	agentTool := agenttool.New(agent, nil)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 	toolImpl, ok := agentTool.(toolinternal.FunctionTool)
 	if !ok {
 		t.Fatal("agentTool does not implement FunctionTool")
@@ -248,7 +252,7 @@ func TestAgentTool_Run_EmptyModelResponse(t *testing.T) {
 	}
 	agent := createAgentWithModel(t, nil, nil, testLLM)
 	agentTool := agenttool.New(agent, nil)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 	toolImpl, ok := agentTool.(toolinternal.FunctionTool)
 	if !ok {
 		t.Fatal("agentTool does not implement FunctionTool")
@@ -271,7 +275,7 @@ func TestAgentTool_Run_SkipSummarization(t *testing.T) {
 		},
 	}
 	agent := createAgentWithModel(t, nil, nil, testLLM)
-	toolCtx := createToolContext(t, agent)
+	toolCtx := createToolContext(t, rootAgent(t))
 
 	// Test with skipSummarization = true
 	agentToolSkip := agenttool.New(agent, &agenttool.Config{SkipSummarization: true})
@@ -309,6 +313,110 @@ func TestAgentTool_Run_SkipSummarization(t *testing.T) {
 	}
 }
 
+func TestAgentTool_Run_SelfCall(t *testing.T) {
+	testLLM := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("math_agent", map[string]any{"request": "again"}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+		},
+	}
+	var selfTool tool.Tool
+	a, err := llmagent.New(llmagent.Config{
+		Name:     "math_agent",
+		Model:    testLLM,
+		Toolsets: []tool.Toolset{&lazyToolset{tools: func() []tool.Tool { return []tool.Tool{selfTool} }}},
+	})
+	if err != nil {
+		t.Fatalf("llmagent.New() failed: %v", err)
+	}
+	selfTool = agenttool.New(a, nil)
+	toolImpl, ok := selfTool.(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("agentTool does not implement FunctionTool")
+	}
+
+	if _, err := toolImpl.Run(createToolContext(t, a), map[string]any{"request": "start"}); !errors.Is(err, agenttool.ErrAgentCycle) {
+		t.Errorf("Run() called by the wrapped agent: error = %v, want %v", err, agenttool.ErrAgentCycle)
+	}
+	if len(testLLM.Requests) != 0 {
+		t.Errorf("model received %d requests, want none", len(testLLM.Requests))
+	}
+
+	result, err := toolImpl.Run(createToolContext(t, rootAgent(t)), map[string]any{"request": "start"})
+	if err != nil {
+		t.Fatalf("Run() failed unexpectedly: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"result": "done"}, result); diff != "" {
+		t.Errorf("Run() result diff (-want +got):\n%s", diff)
+	}
+	if got := nestedCallError(t, testLLM); !strings.Contains(got, agenttool.ErrAgentCycle.Error()) {
+		t.Errorf("nested call error = %q, want it to contain %q", got, agenttool.ErrAgentCycle)
+	}
+}
+
+func TestAgentTool_Run_MaxAgentDepth(t *testing.T) {
+	innerAgent := createAgentWithModel(t, nil, nil, &testutil.MockModel{})
+	innerTool := agenttool.New(innerAgent, &agenttool.Config{MaxAgentDepth: 1})
+
+	testLLM := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("math_agent", map[string]any{"request": "nested"}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+		},
+	}
+	outerAgent, err := llmagent.New(llmagent.Config{
+		Name:  "outer_agent",
+		Model: testLLM,
+		Tools: []tool.Tool{innerTool},
+	})
+	if err != nil {
+		t.Fatalf("llmagent.New() failed: %v", err)
+	}
+	toolImpl, ok := agenttool.New(outerAgent, nil).(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("agentTool does not implement FunctionTool")
+	}
+
+	if _, err := toolImpl.Run(createToolContext(t, rootAgent(t)), map[string]any{"request": "start"}); err != nil {
+		t.Fatalf("Run() failed unexpectedly: %v", err)
+	}
+	if got := nestedCallError(t, testLLM); !strings.Contains(got, agenttool.ErrMaxAgentDepthExceeded.Error()) {
+		t.Errorf("nested call error = %q, want it to contain %q", got, agenttool.ErrMaxAgentDepthExceeded)
+	}
+}
+
+// nestedCallError returns the error reported to the model in the function
+// response of its last request.
+func nestedCallError(t *testing.T, m *testutil.MockModel) string {
+	t.Helper()
+	if len(m.Requests) == 0 {
+		t.Fatal("model received no requests")
+	}
+	req := m.Requests[len(m.Requests)-1]
+	for _, c := range req.Contents {
+		for _, p := range c.Parts {
+			if p.FunctionResponse == nil {
+				continue
+			}
+			if errMsg, ok := p.FunctionResponse.Response["error"].(string); ok {
+				return errMsg
+			}
+		}
+	}
+	t.Fatalf("no function response error found in the last request: %+v", req.Contents)
+	return ""
+}
+
+type lazyToolset struct {
+	tools func() []tool.Tool
+}
+
+func (ts *lazyToolset) Name() string { return "lazy" }
+
+func (ts *lazyToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return ts.tools(), nil
+}
+
 func createAgent(t *testing.T, inputSchema, outputSchema *genai.Schema) agent.Agent {
 	t.Helper()
 
@@ -348,7 +456,17 @@ func createAgentWithModel(t *testing.T, inputSchema, outputSchema *genai.Schema,
 	return agent
 }
 
-func createToolContext(t *testing.T, testAgent agent.Agent) tool.Context {
+// rootAgent returns an agent to call the agent tools under test.
+func rootAgent(t *testing.T) agent.Agent {
+	t.Helper()
+	a, err := agent.New(agent.Config{Name: "root_agent"})
+	if err != nil {
+		t.Fatalf("agent.New() failed: %v", err)
+	}
+	return a
+}
+
+func createToolContext(t *testing.T, caller agent.Agent) tool.Context {
 	t.Helper()
 
 	sessionService := session.InMemoryService()
@@ -364,8 +482,140 @@ func createToolContext(t *testing.T, testAgent agent.Agent) tool.Context {
 	sessionImpl := sessioninternal.NewMutableSession(sessionService, s)
 
 	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Agent:   caller,
 		Session: sessionImpl,
 	})
 
 	return toolinternal.NewToolContext(ctx, "", &session.EventActions{}, nil)
 }
+
+func TestAgentTool_InputSchemaOverride(t *testing.T) {
+	inputSchema := &genai.Schema{
+		Type: "OBJECT",
+		Properties: map[string]*genai.Schema{
+			"city": {Type: "STRING"},
+		},
+		Required: []string{"city"},
+	}
+	testLLM := &testutil.MockModel{
+		Responses: []*genai.Content{genai.NewContentFromText("sunny", genai.RoleModel)},
+	}
+	agent := createAgentWithModel(t, nil, nil, testLLM)
+	agentTool := agenttool.New(agent, &agenttool.Config{InputSchema: inputSchema})
+	toolImpl, ok := agentTool.(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("agentTool does not implement FunctionTool")
+	}
+
+	if diff := cmp.Diff(inputSchema, toolImpl.Declaration().Parameters); diff != "" {
+		t.Errorf("Declaration().Parameters diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := toolImpl.Run(createToolContext(t, rootAgent(t)), map[string]any{"request": "weather?"}); err == nil {
+		t.Error("Run() succeeded with arguments not matching the input schema, want error")
+	}
+	result, err := toolImpl.Run(createToolContext(t, rootAgent(t)), map[string]any{"city": "Paris"})
+	if err != nil {
+		t.Fatalf("Run() failed unexpectedly: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"result": "sunny"}, result); diff != "" {
+		t.Errorf("Run() result diff (-want +got):\n%s", diff)
+	}
+	contents := testLLM.Requests[0].Contents
+	if got, want := contents[len(contents)-1].Parts[0].Text, `{"city":"Paris"}`; got != want {
+		t.Errorf("sub-agent input = %q, want %q", got, want)
+	}
+}
+
+func TestAgentTool_Run_StateAndArtifacts(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           *agenttool.Config
+		wantState     any
+		wantArtifacts []string
+	}{
+		{
+			name:          "default",
+			wantState:     "Ada",
+			wantArtifacts: []string{"report.txt"},
+		},
+		{
+			name:          "isolated state",
+			cfg:           &agenttool.Config{IsolateState: true},
+			wantState:     nil,
+			wantArtifacts: []string{"report.txt"},
+		},
+		{
+			name:          "shared artifacts",
+			cfg:           &agenttool.Config{ShareArtifacts: true},
+			wantState:     "Ada",
+			wantArtifacts: []string{"report.txt", "summary.txt"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotState any
+			subAgent, err := llmagent.New(llmagent.Config{
+				Name:        "summarizer",
+				Description: "Summarizes the report.",
+				Model:       &testutil.MockModel{},
+				BeforeModelCallbacks: []llmagent.BeforeModelCallback{
+					func(ctx agent.CallbackContext, _ *model.LLMRequest) (*model.LLMResponse, error) {
+						gotState, _ = ctx.State().Get("user_name")
+						if resp, err := ctx.Artifacts().Load(ctx, "report.txt"); err == nil {
+							if _, err := ctx.Artifacts().Save(ctx, "summary.txt", genai.NewPartFromText("short "+resp.Part.Text)); err != nil {
+								return nil, err
+							}
+						}
+						return &model.LLMResponse{Content: genai.NewContentFromText("done", genai.RoleModel)}, nil
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to create agent: %v", err)
+			}
+
+			sessionService := session.InMemoryService()
+			createResponse, err := sessionService.Create(t.Context(), &session.CreateRequest{
+				AppName:   "testApp",
+				UserID:    "testUser",
+				SessionID: "testSession",
+				State:     map[string]any{"user_name": "Ada"},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			artifacts := &artifactinternal.Artifacts{
+				Service:   artifact.InMemoryService(),
+				AppName:   "testApp",
+				UserID:    "testUser",
+				SessionID: "testSession",
+			}
+			if _, err := artifacts.Save(t.Context(), "report.txt", genai.NewPartFromText("report")); err != nil {
+				t.Fatalf("failed to save artifact: %v", err)
+			}
+			ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+				Agent:     rootAgent(t),
+				Session:   sessioninternal.NewMutableSession(sessionService, createResponse.Session),
+				Artifacts: artifacts,
+			})
+			toolCtx := toolinternal.NewToolContext(ctx, "", &session.EventActions{}, nil)
+
+			toolImpl := agenttool.New(subAgent, tc.cfg).(toolinternal.FunctionTool)
+			if _, err := toolImpl.Run(toolCtx, map[string]any{"request": "summarize"}); err != nil {
+				t.Fatalf("Run() failed unexpectedly: %v", err)
+			}
+
+			if gotState != tc.wantState {
+				t.Errorf("sub-agent state[user_name] = %v, want %v", gotState, tc.wantState)
+			}
+			list, err := artifacts.List(t.Context())
+			if err != nil {
+				t.Fatalf("failed to list artifacts: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantArtifacts, list.FileNames); diff != "" {
+				t.Errorf("caller artifacts diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttool
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+)
+
+// forwardingArtifactService is the artifact service of a wrapped agent
+// sharing the artifacts of its caller. It forwards the calls to the
+// artifacts of the caller's tool context, whatever the app, user and
+// session of the requests.
+type forwardingArtifactService struct {
+	artifacts agent.Artifacts
+}
+
+func (s *forwardingArtifactService) Save(ctx context.Context, req *artifact.SaveRequest) (*artifact.SaveResponse, error) {
+	if req.Version != 0 {
+		return nil, errors.New("saving a given version of a shared artifact is not supported")
+	}
+	return s.artifacts.Save(ctx, req.FileName, req.Part)
+}
+
+func (s *forwardingArtifactService) Load(ctx context.Context, req *artifact.LoadRequest) (*artifact.LoadResponse, error) {
+	if req.Version != 0 {
+		return s.artifacts.LoadVersion(ctx, req.FileName, int(req.Version))
+	}
+	return s.artifacts.Load(ctx, req.FileName)
+}
+
+func (s *forwardingArtifactService) Delete(ctx context.Context, req *artifact.DeleteRequest) error {
+	return errors.New("deleting a shared artifact is not supported")
+}
+
+func (s *forwardingArtifactService) List(ctx context.Context, req *artifact.ListRequest) (*artifact.ListResponse, error) {
+	return s.artifacts.List(ctx)
+}
+
+func (s *forwardingArtifactService) Versions(ctx context.Context, req *artifact.VersionsRequest) (*artifact.VersionsResponse, error) {
+	return nil, errors.New("listing the versions of a shared artifact is not supported")
+}
+
+var _ artifact.Service = (*forwardingArtifactService)(nil)
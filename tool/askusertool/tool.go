@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package askusertool provides a tool that lets the model ask the user a
+// clarifying question.
+//
+// The tool is long-running: when the model calls it, the invocation ends
+// with the function call event, which carries the question. The resume
+// contract is:
+//
+//  1. The application finds the question in the events of the invocation
+//     with [Questions] and shows it to the user.
+//  2. The application sends the user's answer as the new message of the
+//     next run of the runner, in the content returned by [Question.Answer].
+//     The content is a function response with the ID of the original call,
+//     so the model receives the answer as the result of its question.
+//
+// Until the answer is supplied, the model has only seen a pending result.
+package askusertool
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// DefaultName is the default name of the tool.
+const DefaultName = "ask_user"
+
+// Config is the configuration of the ask user tool.
+type Config struct {
+	// Name of the tool. Defaults to DefaultName.
+	Name string
+	// Description of the tool. Defaults to a description that asks the model
+	// to use the tool only when it cannot proceed without the user's input.
+	Description string
+}
+
+// Args are the arguments of the tool.
+type Args struct {
+	// Question to ask the user.
+	Question string `json:"question"`
+	// Options the user can choose from, if the question has a closed set of
+	// answers.
+	Options []string `json:"options,omitempty"`
+}
+
+// Result is the pending result of the tool, returned to the model until the
+// user answers.
+type Result struct {
+	Status string `json:"status"`
+}
+
+// New creates an ask user tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = DefaultName
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Asks the user a clarifying question and waits for the answer. " +
+			"Use it only when the request is ambiguous or you cannot proceed without information only the user has."
+	}
+	t, err := functiontool.New(functiontool.Config{
+		Name:          cfg.Name,
+		Description:   cfg.Description,
+		IsLongRunning: true,
+	}, askUser)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ask user tool: %w", err)
+	}
+	return t, nil
+}
+
+func askUser(ctx tool.Context, args Args) (Result, error) {
+	if args.Question == "" {
+		return Result{}, fmt.Errorf("question must not be empty")
+	}
+	// Ends the invocation after the call, instead of letting the model
+	// continue without the answer.
+	ctx.Actions().SkipSummarization = true
+	return Result{Status: "waiting for the user's answer"}, nil
+}
+
+// Question is a question asked to the user by the tool.
+type Question struct {
+	// CallID is the ID of the function call that asked the question.
+	CallID string
+	// ToolName is the name of the tool that asked the question.
+	ToolName string
+	// Text of the question.
+	Text string
+	// Options the user can choose from, if any.
+	Options []string
+}
+
+// Questions returns the questions asked by the tool named toolName in the
+// function calls of ev that are waiting for an answer.
+func Questions(ev *session.Event, toolName string) []Question {
+	if ev == nil || ev.Content == nil {
+		return nil
+	}
+	var questions []Question
+	for _, p := range ev.Content.Parts {
+		call := p.FunctionCall
+		if call == nil || call.Name != toolName || !slices.Contains(ev.LongRunningToolIDs, call.ID) {
+			continue
+		}
+		q := Question{CallID: call.ID, ToolName: call.Name}
+		q.Text, _ = call.Args["question"].(string)
+		switch options := call.Args["options"].(type) {
+		case []string:
+			q.Options = options
+		case []any:
+			for _, o := range options {
+				if s, ok := o.(string); ok {
+					q.Options = append(q.Options, s)
+				}
+			}
+		}
+		questions = append(questions, q)
+	}
+	return questions
+}
+
+// Answer returns the user content that answers q, to be passed as the new
+// message to the runner.
+func (q Question) Answer(answer string) *genai.Content {
+	return &genai.Content{
+		Role: genai.RoleUser,
+		Parts: []*genai.Part{{
+			FunctionResponse: &genai.FunctionResponse{
+				ID:       q.CallID,
+				Name:     q.ToolName,
+				Response: map[string]any{"answer": answer},
+			},
+		}},
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package askusertool_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/askusertool"
+)
+
+func TestAskUserTool(t *testing.T) {
+	askUser, err := askusertool.New(askusertool.Config{})
+	if err != nil {
+		t.Fatalf("askusertool.New() failed: %v", err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall(askusertool.DefaultName, map[string]any{
+			"question": "Which city?",
+			"options":  []any{"Paris", "Rome"},
+		}, genai.RoleModel),
+		genai.NewContentFromText("It is sunny in Rome.", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "weather_agent",
+		Model: model,
+		Tools: []tool.Tool{askUser},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+	runner := testutil.NewTestAgentRunner(t, a)
+
+	// The invocation stops at the question.
+	var questions []askusertool.Question
+	for ev, err := range runner.Run(t, "session", "What's the weather?") {
+		if err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+		questions = append(questions, askusertool.Questions(ev, askusertool.DefaultName)...)
+	}
+	if len(model.Requests) != 1 {
+		t.Errorf("got %d model requests before the answer, want 1", len(model.Requests))
+	}
+	if len(questions) != 1 {
+		t.Fatalf("got questions %v, want 1", questions)
+	}
+	q := questions[0]
+	if q.Text != "Which city?" || q.CallID == "" {
+		t.Errorf("got question %+v, want %q with a call ID", q, "Which city?")
+	}
+	if diff := cmp.Diff([]string{"Paris", "Rome"}, q.Options); diff != "" {
+		t.Errorf("options mismatch (-want +got):\n%s", diff)
+	}
+
+	// The answer resumes the conversation.
+	texts, err := testutil.CollectTextParts(runner.RunContent(t, "session", q.Answer("Rome")))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"It is sunny in Rome."}, texts); diff != "" {
+		t.Errorf("response mismatch (-want +got):\n%s", diff)
+	}
+	if len(model.Requests) != 2 {
+		t.Fatalf("got %d model requests, want 2", len(model.Requests))
+	}
+	contents := model.Requests[1].Contents
+	last := contents[len(contents)-1].Parts[0].FunctionResponse
+	// The pending result is replaced by the answer in the model's history.
+	if last == nil || last.Name != askusertool.DefaultName || last.Response["answer"] != "Rome" {
+		t.Errorf("last content of the request = %+v, want the answer to the question", contents[len(contents)-1])
+	}
+}
+
+func TestQuestions_IgnoresAnsweredCalls(t *testing.T) {
+	ev := &session.Event{}
+	ev.Content = genai.NewContentFromFunctionCall(askusertool.DefaultName, map[string]any{"question": "Which city?"}, genai.RoleModel)
+	ev.Content.Parts[0].FunctionCall.ID = "call_1"
+
+	if got := askusertool.Questions(ev, askusertool.DefaultName); len(got) != 0 {
+		t.Errorf("Questions() = %v, want none for a call that is not long-running", got)
+	}
+	ev.LongRunningToolIDs = []string{"call_1"}
+	if got := askusertool.Questions(ev, "other_tool"); len(got) != 0 {
+		t.Errorf("Questions() = %v, want none for another tool", got)
+	}
+	if got := askusertool.Questions(ev, askusertool.DefaultName); len(got) != 1 {
+		t.Errorf("Questions() = %v, want one", got)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package charttool provides a tool that renders charts from data as PNG
+// images and stores them as artifacts.
+//
+// The rendered chart is saved with the artifact service of the current
+// session. Saving the artifact records it in the ArtifactDelta of the tool
+// response event, which is how the chart is surfaced to the user.
+package charttool
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Config is the configuration of the chart tool.
+type Config struct {
+	// Name of the tool. Defaults to "render_chart".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// Width of the rendered image in pixels. Defaults to 640.
+	Width int
+	// Height of the rendered image in pixels. Defaults to 480.
+	Height int
+}
+
+// ChartType is the kind of chart to render.
+type ChartType string
+
+const (
+	// ChartTypeBar renders the series as grouped bars.
+	ChartTypeBar ChartType = "bar"
+	// ChartTypeLine renders the series as lines.
+	ChartTypeLine ChartType = "line"
+)
+
+// Series is a named list of values to plot.
+type Series struct {
+	Name   string    `json:"name,omitempty" jsonschema:"name of the series"`
+	Values []float64 `json:"values" jsonschema:"values of the series, one per label"`
+}
+
+// Args are the arguments of the chart tool.
+type Args struct {
+	Type   ChartType `json:"type" jsonschema:"type of the chart, one of: bar, line"`
+	Title  string    `json:"title,omitempty" jsonschema:"title drawn above the chart, also used to name the artifact"`
+	Labels []string  `json:"labels,omitempty" jsonschema:"labels of the x axis"`
+	Series []Series  `json:"series" jsonschema:"data series to plot"`
+}
+
+// Result is the result of the chart tool.
+type Result struct {
+	// Artifact is the name of the artifact holding the PNG image.
+	Artifact string `json:"artifact"`
+	// Version is the version of the saved artifact.
+	Version int64 `json:"version"`
+}
+
+// New creates a chart tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = "render_chart"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Renders a bar or line chart from the given data series as a PNG image and saves it as an artifact."
+	}
+	if cfg.Width <= 0 {
+		cfg.Width = 640
+	}
+	if cfg.Height <= 0 {
+		cfg.Height = 480
+	}
+	t := &chartTool{width: cfg.Width, height: cfg.Height}
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type chartTool struct {
+	width, height int
+}
+
+func (t *chartTool) run(ctx tool.Context, args Args) (Result, error) {
+	if err := validate(args); err != nil {
+		return Result{}, err
+	}
+	if ctx.Artifacts() == nil {
+		return Result{}, fmt.Errorf("artifact service is not configured")
+	}
+
+	img := render(args, t.width, t.height)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return Result{}, fmt.Errorf("failed to encode chart: %w", err)
+	}
+
+	name := artifactName(args.Title, ctx.FunctionCallID())
+	resp, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(buf.Bytes(), "image/png"))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to save chart artifact %q: %w", name, err)
+	}
+	return Result{Artifact: name, Version: resp.Version}, nil
+}
+
+func validate(args Args) error {
+	switch args.Type {
+	case ChartTypeBar, ChartTypeLine:
+	default:
+		return fmt.Errorf("unsupported chart type %q, must be one of: %s, %s", args.Type, ChartTypeBar, ChartTypeLine)
+	}
+	if len(args.Series) == 0 {
+		return fmt.Errorf("at least one series is required")
+	}
+	for _, s := range args.Series {
+		if len(s.Values) == 0 {
+			return fmt.Errorf("series %q has no values", s.Name)
+		}
+		if len(args.Labels) > 0 && len(s.Values) != len(args.Labels) {
+			return fmt.Errorf("series %q has %d values, want one per label (%d)", s.Name, len(s.Values), len(args.Labels))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charttool_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/charttool"
+)
+
+func TestChartTool_Run(t *testing.T) {
+	chartTool, err := charttool.New(charttool.Config{Width: 200, Height: 100})
+	if err != nil {
+		t.Fatalf("charttool.New() failed: %v", err)
+	}
+	toolImpl, ok := chartTool.(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("chartTool does not implement FunctionTool")
+	}
+
+	for _, chartType := range []string{"bar", "line"} {
+		t.Run(chartType, func(t *testing.T) {
+			actions := &session.EventActions{}
+			ctx := createToolContext(t, actions)
+			result, err := toolImpl.Run(ctx, map[string]any{
+				"type":   chartType,
+				"title":  "Monthly Sales",
+				"labels": []any{"jan", "feb", "mar"},
+				"series": []any{
+					map[string]any{"name": "2025", "values": []any{1, 3, 2}},
+					map[string]any{"name": "2026", "values": []any{-1, 4, 5}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if got, want := result["artifact"], "chart_monthly_sales.png"; got != want {
+				t.Errorf("artifact = %v, want %v", got, want)
+			}
+			if _, ok := actions.ArtifactDelta["chart_monthly_sales.png"]; !ok {
+				t.Errorf("ArtifactDelta = %v, want it to contain the chart", actions.ArtifactDelta)
+			}
+
+			resp, err := ctx.Artifacts().Load(ctx, "chart_monthly_sales.png")
+			if err != nil {
+				t.Fatalf("failed to load chart artifact: %v", err)
+			}
+			if got := resp.Part.InlineData.MIMEType; got != "image/png" {
+				t.Errorf("MIMEType = %q, want image/png", got)
+			}
+			img, err := png.Decode(bytes.NewReader(resp.Part.InlineData.Data))
+			if err != nil {
+				t.Fatalf("failed to decode chart: %v", err)
+			}
+			if got := img.Bounds().Size(); got.X != 200 || got.Y != 100 {
+				t.Errorf("image size = %v, want 200x100", got)
+			}
+			// The plot area spans the image inside a margin of 40 pixels.
+			if !hasInk(img, image.Rect(0, 0, 200, 40)) {
+				t.Error("the title is not drawn above the plot area")
+			}
+			if !hasInk(img, image.Rect(0, 61, 200, 100)) {
+				t.Error("the labels are not drawn below the plot area")
+			}
+		})
+	}
+}
+
+func TestChartTool_RunInvalidArgs(t *testing.T) {
+	chartTool, err := charttool.New(charttool.Config{})
+	if err != nil {
+		t.Fatalf("charttool.New() failed: %v", err)
+	}
+	toolImpl, ok := chartTool.(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("chartTool does not implement FunctionTool")
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{
+			name: "unsupported type",
+			args: map[string]any{"type": "pie", "series": []any{map[string]any{"values": []any{1}}}},
+		},
+		{
+			name: "no series",
+			args: map[string]any{"type": "bar", "series": []any{}},
+		},
+		{
+			name: "values do not match labels",
+			args: map[string]any{"type": "bar", "labels": []any{"a", "b"}, "series": []any{map[string]any{"values": []any{1}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toolImpl.Run(createToolContext(t, nil), tt.args); err == nil {
+				t.Errorf("Run(%v) succeeded unexpectedly, want error", tt.args)
+			}
+		})
+	}
+}
+
+// hasInk reports whether img has pixels other than the white background in r.
+func hasInk(img image.Image, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if img.At(x, y) != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func createToolContext(t *testing.T, actions *session.EventActions) tool.Context {
+	t.Helper()
+
+	artifacts := &artifactinternal.Artifacts{
+		Service:   artifact.InMemoryService(),
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "session",
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Artifacts: artifacts,
+	})
+	return toolinternal.NewToolContext(ctx, "", actions, nil)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package charttool
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	margin = 40
+	// titleScale is the scale of the font of the title.
+	titleScale = 2
+	// labelGap is the space between the plot area and the labels.
+	labelGap = 6
+)
+
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	axisColor  = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	palette    = []color.RGBA{
+		{R: 0x42, G: 0x85, B: 0xf4, A: 0xff},
+		{R: 0xdb, G: 0x44, B: 0x37, A: 0xff},
+		{R: 0xf4, G: 0xb4, B: 0x00, A: 0xff},
+		{R: 0x0f, G: 0x9d, B: 0x58, A: 0xff},
+		{R: 0xab, G: 0x47, B: 0xbc, A: 0xff},
+	}
+)
+
+// render draws the chart described by args. Values are scaled to fit the
+// plot area, which always includes zero so bars have a common baseline. The
+// title is drawn centered above the plot area, and each label of the x axis
+// centered below its values, truncated to their width.
+func render(args Args, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	plot := image.Rect(margin, margin, width-margin, height-margin)
+	minV, maxV := valueRange(args.Series)
+	y := func(v float64) int {
+		return plot.Max.Y - int(math.Round((v-minV)/(maxV-minV)*float64(plot.Dy())))
+	}
+
+	n := 0
+	for _, s := range args.Series {
+		n = max(n, len(s.Values))
+	}
+	slot := float64(plot.Dx()) / float64(n)
+
+	switch args.Type {
+	case ChartTypeBar:
+		barWidth := slot * 0.8 / float64(len(args.Series))
+		for si, s := range args.Series {
+			c := palette[si%len(palette)]
+			for i, v := range s.Values {
+				x0 := plot.Min.X + int(slot*float64(i)+slot*0.1+barWidth*float64(si))
+				x1 := x0 + max(int(barWidth), 1)
+				y0, y1 := y(0), y(v)
+				if y1 < y0 {
+					y0, y1 = y1, y0
+				}
+				draw.Draw(img, image.Rect(x0, y0, x1, y1+1), image.NewUniform(c), image.Point{}, draw.Src)
+			}
+		}
+	case ChartTypeLine:
+		for si, s := range args.Series {
+			c := palette[si%len(palette)]
+			for i := 1; i < len(s.Values); i++ {
+				x0 := plot.Min.X + int(slot*(float64(i-1)+0.5))
+				x1 := plot.Min.X + int(slot*(float64(i)+0.5))
+				drawLine(img, x0, y(s.Values[i-1]), x1, y(s.Values[i]), c)
+			}
+		}
+	}
+
+	// Axes are drawn last so they stay visible on top of the data.
+	drawLine(img, plot.Min.X, plot.Min.Y, plot.Min.X, plot.Max.Y, axisColor)
+	drawLine(img, plot.Min.X, y(0), plot.Max.X, y(0), axisColor)
+
+	if title := fitText(args.Title, width, titleScale); title != "" {
+		drawText(img, (width-textWidth(title, titleScale))/2, (margin-glyphHeight*titleScale)/2, title, titleScale, axisColor)
+	}
+	for i, label := range args.Labels {
+		label = fitText(label, int(slot)-2, 1)
+		center := plot.Min.X + int(slot*(float64(i)+0.5))
+		drawText(img, center-textWidth(label, 1)/2, plot.Max.Y+labelGap, label, 1, axisColor)
+	}
+	return img
+}
+
+func valueRange(series []Series) (float64, float64) {
+	minV, maxV := 0.0, 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			minV = min(minV, v)
+			maxV = max(maxV, v)
+		}
+	}
+	if minV == maxV {
+		maxV = minV + 1
+	}
+	return minV, maxV
+}
+
+// drawLine draws a line using Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// artifactName derives a file name for the chart from its title, falling
+// back to the function call ID for untitled charts.
+func artifactName(title, functionCallID string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case r == ' ' || r == '-' || r == '_':
+			return '_'
+		default:
+			return -1
+		}
+	}, strings.TrimSpace(title))
+	if slug == "" {
+		slug = functionCallID
+	}
+	return "chart_" + slug + ".png"
+}
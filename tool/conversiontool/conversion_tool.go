@@ -0,0 +1,220 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conversiontool provides a tool that converts values between
+// units and between currencies.
+//
+// Physical units of length, mass and temperature are converted
+// deterministically. Currencies are converted with the rates of a pluggable
+// RateProvider, e.g. one querying a live exchange rate service, falling
+// back to a static table of rates when the provider fails.
+package conversiontool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// RateProvider provides currency exchange rates.
+type RateProvider interface {
+	// Rate returns the amount of the to currency one unit of the from
+	// currency is worth. Currencies are ISO 4217 codes, e.g. "USD".
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// RateProviderFunc adapts a function to a RateProvider.
+type RateProviderFunc func(ctx context.Context, from, to string) (float64, error)
+
+// Rate calls f(ctx, from, to).
+func (f RateProviderFunc) Rate(ctx context.Context, from, to string) (float64, error) {
+	return f(ctx, from, to)
+}
+
+// StaticRates is a RateProvider with a fixed table of rates. It holds the
+// value of one unit of each currency in a common base currency, e.g.
+// StaticRates{"USD": 1, "EUR": 1.08} when the base is USD.
+type StaticRates map[string]float64
+
+// Rate returns the rate derived from the values of the currencies.
+func (r StaticRates) Rate(_ context.Context, from, to string) (float64, error) {
+	fromValue, ok := r[from]
+	if !ok {
+		return 0, fmt.Errorf("no rate for currency %q", from)
+	}
+	toValue, ok := r[to]
+	if !ok || toValue == 0 {
+		return 0, fmt.Errorf("no rate for currency %q", to)
+	}
+	return fromValue / toValue, nil
+}
+
+// Config is the configuration of the conversion tool.
+type Config struct {
+	// Name of the tool. Defaults to "convert".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// RateProvider provides the currency exchange rates. Optional, when
+	// not set only FallbackRates are used.
+	RateProvider RateProvider
+	// FallbackRates are used when RateProvider is not set or fails.
+	// Optional, when neither is set currencies are not supported.
+	FallbackRates StaticRates
+	// CacheTTL is how long the rates of RateProvider are cached. Defaults
+	// to one hour.
+	CacheTTL time.Duration
+}
+
+// Args are the arguments of the conversion tool.
+type Args struct {
+	Value float64 `json:"value" jsonschema:"the value to convert"`
+	From  string  `json:"from" jsonschema:"the unit or ISO 4217 currency code of the value, e.g. km, lb, C or USD"`
+	To    string  `json:"to" jsonschema:"the unit or ISO 4217 currency code to convert to"`
+}
+
+// Result is the result of the conversion tool.
+type Result struct {
+	// Value is the converted value.
+	Value float64 `json:"value"`
+	// Unit is the unit or currency of the converted value.
+	Unit string `json:"unit"`
+	// Rate is the exchange rate used for currency conversions.
+	Rate float64 `json:"rate,omitempty"`
+	// RateSource is "live" when the rate comes from the rate provider and
+	// "fallback" when it comes from the fallback rates. It is empty when
+	// no rate is needed.
+	RateSource string `json:"rate_source,omitempty"`
+}
+
+// New creates a conversion tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = "convert"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Converts a value between units of length, mass or temperature"
+		if cfg.RateProvider != nil || len(cfg.FallbackRates) > 0 {
+			cfg.Description += ", or between currencies"
+		}
+		cfg.Description += "."
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = time.Hour
+	}
+
+	t := &conversionTool{
+		provider: cfg.RateProvider,
+		fallback: cfg.FallbackRates,
+		ttl:      cfg.CacheTTL,
+		cache:    make(map[[2]string]cachedRate),
+	}
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type cachedRate struct {
+	rate    float64
+	expires time.Time
+}
+
+type conversionTool struct {
+	provider RateProvider
+	fallback StaticRates
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[[2]string]cachedRate
+}
+
+func (t *conversionTool) run(ctx tool.Context, args Args) (Result, error) {
+	from, fromOK := lookupUnit(args.From)
+	to, toOK := lookupUnit(args.To)
+	switch {
+	case fromOK && toOK:
+		if from.dimension != to.dimension {
+			return Result{}, fmt.Errorf("cannot convert %s (%s) to %s (%s)", args.From, from.dimension, args.To, to.dimension)
+		}
+		return Result{Value: to.fromBase(from.toBase(args.Value)), Unit: to.symbol}, nil
+	case fromOK || toOK:
+		return Result{}, fmt.Errorf("cannot convert %s to %s", args.From, args.To)
+	}
+
+	fromCurrency, toCurrency := strings.ToUpper(args.From), strings.ToUpper(args.To)
+	if !isCurrencyCode(fromCurrency) || !isCurrencyCode(toCurrency) {
+		return Result{}, fmt.Errorf("unknown unit or currency in conversion of %s to %s", args.From, args.To)
+	}
+	rate, source, err := t.rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Value: args.Value * rate, Unit: toCurrency, Rate: rate, RateSource: source}, nil
+}
+
+// rate returns the exchange rate from the cache, the rate provider or the
+// fallback rates, in this order.
+func (t *conversionTool) rate(ctx context.Context, from, to string) (float64, string, error) {
+	if from == to {
+		return 1, "", nil
+	}
+	key := [2]string{from, to}
+	var providerErr error
+	if t.provider != nil {
+		t.mu.Lock()
+		cached, ok := t.cache[key]
+		t.mu.Unlock()
+		if ok && time.Now().Before(cached.expires) {
+			return cached.rate, "live", nil
+		}
+		rate, err := t.provider.Rate(ctx, from, to)
+		if err == nil && rate > 0 {
+			t.mu.Lock()
+			t.cache[key] = cachedRate{rate: rate, expires: time.Now().Add(t.ttl)}
+			t.mu.Unlock()
+			return rate, "live", nil
+		}
+		if err == nil {
+			err = fmt.Errorf("invalid rate %v", rate)
+		}
+		providerErr = err
+	}
+	if t.fallback != nil {
+		if rate, err := t.fallback.Rate(ctx, from, to); err == nil {
+			return rate, "fallback", nil
+		}
+	}
+	if providerErr != nil {
+		return 0, "", fmt.Errorf("failed to get the rate of %s to %s: %w", from, to, providerErr)
+	}
+	return 0, "", fmt.Errorf("no rate of %s to %s", from, to)
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversiontool_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/conversiontool"
+)
+
+func run(t *testing.T, convert tool.Tool, value float64, from, to string) (map[string]any, error) {
+	t.Helper()
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+	return convert.(toolinternal.FunctionTool).Run(ctx, map[string]any{"value": value, "from": from, "to": to})
+}
+
+func TestConversionTool_Units(t *testing.T) {
+	convert, err := conversiontool.New(conversiontool.Config{})
+	if err != nil {
+		t.Fatalf("conversiontool.New() failed: %v", err)
+	}
+
+	tests := []struct {
+		value     float64
+		from, to  string
+		want      float64
+		wantUnit  string
+		wantError string
+	}{
+		{value: 5, from: "km", to: "mi", want: 3.10686, wantUnit: "mi"},
+		{value: 12, from: "inches", to: "Feet", want: 1, wantUnit: "ft"},
+		{value: 1, from: "lb", to: "g", want: 453.59237, wantUnit: "g"},
+		{value: 100, from: "C", to: "F", want: 212, wantUnit: "F"},
+		{value: 32, from: "fahrenheit", to: "kelvin", want: 273.15, wantUnit: "K"},
+		{value: 1, from: "kg", to: "m", wantError: "cannot convert kg (mass) to m (length)"},
+		{value: 1, from: "kg", to: "USD", wantError: "cannot convert kg to USD"},
+		{value: 1, from: "parsec", to: "light year", wantError: "unknown unit or currency"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.from+"_to_"+tc.to, func(t *testing.T) {
+			got, err := run(t, convert, tc.value, tc.from, tc.to)
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("Run() error = %v, want error containing %q", err, tc.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if v := got["value"].(float64); math.Abs(v-tc.want) > 1e-4 {
+				t.Errorf("value = %v, want %v", v, tc.want)
+			}
+			if got["unit"] != tc.wantUnit {
+				t.Errorf("unit = %v, want %v", got["unit"], tc.wantUnit)
+			}
+		})
+	}
+}
+
+func TestConversionTool_Currencies(t *testing.T) {
+	var calls int
+	var providerErr error
+	convert, err := conversiontool.New(conversiontool.Config{
+		RateProvider: conversiontool.RateProviderFunc(func(_ context.Context, from, to string) (float64, error) {
+			calls++
+			if providerErr != nil {
+				return 0, providerErr
+			}
+			if from == "EUR" && to == "USD" {
+				return 1.1, nil
+			}
+			return 0, errors.New("unsupported currency")
+		}),
+		FallbackRates: conversiontool.StaticRates{"USD": 1, "GBP": 1.25},
+	})
+	if err != nil {
+		t.Fatalf("conversiontool.New() failed: %v", err)
+	}
+
+	for range 2 {
+		got, err := run(t, convert, 10, "eur", "usd")
+		if err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		if v := got["value"].(float64); math.Abs(v-11) > 1e-9 || got["unit"] != "USD" || got["rate_source"] != "live" {
+			t.Errorf("Run() = %v, want 11 USD at the live rate", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got %d rate provider calls, want the rate to be cached", calls)
+	}
+
+	got, err := run(t, convert, 10, "GBP", "USD")
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if v := got["value"].(float64); math.Abs(v-12.5) > 1e-9 || got["rate_source"] != "fallback" {
+		t.Errorf("Run() = %v, want 12.5 USD at the fallback rate", got)
+	}
+
+	providerErr = errors.New("service unavailable")
+	if _, err := run(t, convert, 10, "JPY", "USD"); err == nil || !strings.Contains(err.Error(), "service unavailable") {
+		t.Errorf("Run() error = %v, want the rate provider error", err)
+	}
+}
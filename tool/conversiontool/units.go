@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversiontool
+
+import "strings"
+
+type dimension string
+
+const (
+	length      dimension = "length"
+	mass        dimension = "mass"
+	temperature dimension = "temperature"
+)
+
+// unit converts values from and to the base unit of its dimension: meters,
+// kilograms and kelvins.
+type unit struct {
+	symbol    string
+	dimension dimension
+	// factor is the value of one unit in the base unit.
+	factor float64
+	// offset is added to the value scaled to the base unit, for the
+	// temperature scales not starting at absolute zero.
+	offset float64
+}
+
+func (u unit) toBase(v float64) float64 {
+	return v*u.factor + u.offset
+}
+
+func (u unit) fromBase(v float64) float64 {
+	return (v - u.offset) / u.factor
+}
+
+var units = []struct {
+	unit
+	aliases []string
+}{
+	{unit{"m", length, 1, 0}, []string{"meter", "meters", "metre", "metres"}},
+	{unit{"km", length, 1000, 0}, []string{"kilometer", "kilometers", "kilometre", "kilometres"}},
+	{unit{"cm", length, 0.01, 0}, []string{"centimeter", "centimeters", "centimetre", "centimetres"}},
+	{unit{"mm", length, 0.001, 0}, []string{"millimeter", "millimeters", "millimetre", "millimetres"}},
+	{unit{"mi", length, 1609.344, 0}, []string{"mile", "miles"}},
+	{unit{"yd", length, 0.9144, 0}, []string{"yard", "yards"}},
+	{unit{"ft", length, 0.3048, 0}, []string{"foot", "feet"}},
+	{unit{"in", length, 0.0254, 0}, []string{"inch", "inches"}},
+	{unit{"nmi", length, 1852, 0}, []string{"nautical mile", "nautical miles"}},
+
+	{unit{"kg", mass, 1, 0}, []string{"kilogram", "kilograms"}},
+	{unit{"g", mass, 0.001, 0}, []string{"gram", "grams"}},
+	{unit{"mg", mass, 1e-6, 0}, []string{"milligram", "milligrams"}},
+	{unit{"t", mass, 1000, 0}, []string{"tonne", "tonnes", "metric ton", "metric tons"}},
+	{unit{"lb", mass, 0.45359237, 0}, []string{"lbs", "pound", "pounds"}},
+	{unit{"oz", mass, 0.028349523125, 0}, []string{"ounce", "ounces"}},
+	{unit{"st", mass, 6.35029318, 0}, []string{"stone", "stones"}},
+
+	{unit{"K", temperature, 1, 0}, []string{"kelvin", "kelvins"}},
+	{unit{"C", temperature, 1, 273.15}, []string{"°c", "celsius", "degc"}},
+	{unit{"F", temperature, 5.0 / 9, 273.15 - 32*5.0/9}, []string{"°f", "fahrenheit", "degf"}},
+}
+
+// lookupUnit returns the unit of the given symbol or name, ignoring case.
+func lookupUnit(name string) (unit, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, u := range units {
+		if strings.ToLower(u.symbol) == name {
+			return u.unit, true
+		}
+		for _, alias := range u.aliases {
+			if alias == name {
+				return u.unit, true
+			}
+		}
+	}
+	return unit{}, false
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datafetchtool provides a tool that fetches CSV or JSON data from
+// a URL and returns it as structured records.
+//
+// The tool only fetches URLs of the allowed hosts, so that the model cannot
+// reach arbitrary endpoints. The data is parsed by the tool, so the model
+// receives clean records rather than raw text it must parse.
+package datafetchtool
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Config is the configuration of the data fetch tool.
+type Config struct {
+	// Name of the tool. Defaults to "fetch_data".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// AllowedHosts are the hosts the tool may fetch data from. An entry
+	// starting with "*." also allows the subdomains of the host, e.g.
+	// "*.example.com" allows "data.example.com". Required.
+	AllowedHosts []string
+	// Client is the HTTP client used to fetch the data. Defaults to
+	// http.DefaultClient. Redirects to hosts not allowed are rejected.
+	Client *http.Client
+	// MaxRows is the maximum number of records returned. Defaults to 100.
+	MaxRows int
+	// MaxBytes is the maximum size of the response body. Larger responses
+	// are rejected. Defaults to 1 MiB.
+	MaxBytes int64
+}
+
+// Args are the arguments of the data fetch tool.
+type Args struct {
+	URL string `json:"url" jsonschema:"the http or https URL of the CSV or JSON data"`
+}
+
+// Result is the result of the data fetch tool.
+type Result struct {
+	// Format is the format of the fetched data, "csv" or "json".
+	Format string `json:"format"`
+	// Columns are the columns of CSV data, from its header row.
+	Columns []string `json:"columns,omitempty"`
+	// Records are the fetched records, at most MaxRows of them. CSV rows
+	// map the columns to their values, JSON array elements that are not
+	// objects are held in the "value" field of their record.
+	Records []map[string]any `json:"records"`
+	// TotalRows is the number of records of the data.
+	TotalRows int `json:"total_rows"`
+	// Truncated reports whether records were left out to respect MaxRows.
+	Truncated bool `json:"truncated"`
+}
+
+// New creates a data fetch tool.
+func New(cfg Config) (tool.Tool, error) {
+	if len(cfg.AllowedHosts) == 0 {
+		return nil, fmt.Errorf("at least one allowed host is required")
+	}
+	for _, host := range cfg.AllowedHosts {
+		if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, fmt.Errorf("invalid allowed host %q: only a leading \"*.\" wildcard is supported", host)
+		}
+	}
+	if cfg.Name == "" {
+		cfg.Name = "fetch_data"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Fetches CSV or JSON data from a URL and returns it as structured records. " +
+			"Allowed hosts: " + strings.Join(cfg.AllowedHosts, ", ") + "."
+	}
+	if cfg.MaxRows <= 0 {
+		cfg.MaxRows = 100
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = 1 << 20
+	}
+
+	t := &fetchTool{
+		allowedHosts: cfg.AllowedHosts,
+		maxRows:      cfg.MaxRows,
+		maxBytes:     cfg.MaxBytes,
+	}
+	client := http.DefaultClient
+	if cfg.Client != nil {
+		client = cfg.Client
+	}
+	// Copy the client, to check the redirects without modifying the
+	// client of the caller.
+	c := *client
+	checkRedirect := c.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !t.allowed(req.URL) {
+			return fmt.Errorf("redirect to host %q is not allowed", req.URL.Hostname())
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	t.client = &c
+
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type fetchTool struct {
+	allowedHosts []string
+	client       *http.Client
+	maxRows      int
+	maxBytes     int64
+}
+
+func (t *fetchTool) run(ctx tool.Context, args Args) (Result, error) {
+	u, err := url.Parse(args.URL)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid URL %q: %w", args.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Result{}, fmt.Errorf("unsupported URL scheme %q, only http and https are supported", u.Scheme)
+	}
+	if !t.allowed(u) {
+		return Result{}, fmt.Errorf("host %q is not allowed", u.Hostname())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Accept", "text/csv, application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to fetch %q: %w", args.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Result{}, fmt.Errorf("failed to fetch %q: %s", args.URL, resp.Status)
+	}
+	if resp.ContentLength > t.maxBytes {
+		return Result{}, fmt.Errorf("response of %d bytes exceeds the limit of %d bytes", resp.ContentLength, t.maxBytes)
+	}
+
+	format, err := detectFormat(resp.Header.Get("Content-Type"), u)
+	if err != nil {
+		return Result{}, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.maxBytes+1))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(body)) > t.maxBytes {
+		return Result{}, fmt.Errorf("response exceeds the limit of %d bytes", t.maxBytes)
+	}
+
+	var result Result
+	switch format {
+	case "csv":
+		result, err = parseCSV(body)
+	case "json":
+		result, err = parseJSON(body)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	result.Format = format
+	result.TotalRows = len(result.Records)
+	if len(result.Records) > t.maxRows {
+		result.Records = result.Records[:t.maxRows]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+func (t *fetchTool) allowed(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return slices.ContainsFunc(t.allowedHosts, func(allowed string) bool {
+		allowed = strings.ToLower(allowed)
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok {
+			return strings.HasSuffix(host, "."+domain) || host == domain
+		}
+		return host == allowed
+	})
+}
+
+// detectFormat returns the format of the data from its content type,
+// falling back to the extension of the URL for generic content types.
+func detectFormat(contentType string, u *url.URL) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if contentType != "" && err != nil {
+		return "", fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+	switch {
+	case mediaType == "text/csv" || mediaType == "application/csv":
+		return "csv", nil
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return "json", nil
+	case mediaType == "" || mediaType == "text/plain" || mediaType == "application/octet-stream":
+		switch strings.ToLower(path.Ext(u.Path)) {
+		case ".csv":
+			return "csv", nil
+		case ".json":
+			return "json", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported content type %q, want CSV or JSON data", contentType)
+}
+
+func parseCSV(body []byte) (Result, error) {
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid CSV data: %w", err)
+	}
+	if len(rows) == 0 {
+		return Result{Records: []map[string]any{}}, nil
+	}
+	columns := rows[0]
+	records := make([]map[string]any, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]any, len(columns))
+		for i, column := range columns {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+	return Result{Columns: columns, Records: records}, nil
+}
+
+func parseJSON(body []byte) (Result, error) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return Result{}, fmt.Errorf("invalid JSON data: %w", err)
+	}
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	records := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		record, ok := v.(map[string]any)
+		if !ok {
+			record = map[string]any{"value": v}
+		}
+		records = append(records, record)
+	}
+	return Result{Records: records}, nil
+}
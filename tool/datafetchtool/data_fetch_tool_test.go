@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datafetchtool_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool/datafetchtool"
+)
+
+func TestDataFetchTool(t *testing.T) {
+	mux := http.NewServeMux()
+	serve := func(pattern, contentType, body string) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			if contentType != "" {
+				w.Header().Set("Content-Type", contentType)
+			}
+			w.Write([]byte(body))
+		})
+	}
+	serve("/sales", "text/csv", "region,total\nnorth,10\nsouth,20\neast,30\n")
+	serve("/users", "application/json; charset=utf-8", `[{"name":"ann"},{"name":"bob"}]`)
+	serve("/stats", "application/json", `{"count":3}`)
+	serve("/export.csv", "application/octet-stream", "a\n1\n")
+	serve("/page", "text/html", "<html></html>")
+	serve("/large", "application/json", `["`+strings.Repeat("x", 200)+`"]`)
+	serve("/broken", "application/json", `{"count":`)
+	mux.HandleFunc("/elsewhere", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://example.com/data.json", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetchTool, err := datafetchtool.New(datafetchtool.Config{
+		AllowedHosts: []string{"127.0.0.1"},
+		Client:       server.Client(),
+		MaxRows:      2,
+		MaxBytes:     100,
+	})
+	if err != nil {
+		t.Fatalf("datafetchtool.New() failed: %v", err)
+	}
+	toolImpl := fetchTool.(toolinternal.FunctionTool)
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+
+	tests := []struct {
+		name      string
+		url       string
+		want      map[string]any
+		wantError string
+	}{
+		{
+			name: "csv with row cap",
+			url:  server.URL + "/sales",
+			want: map[string]any{
+				"format":  "csv",
+				"columns": []any{"region", "total"},
+				"records": []any{
+					map[string]any{"region": "north", "total": "10"},
+					map[string]any{"region": "south", "total": "20"},
+				},
+				"total_rows": float64(3),
+				"truncated":  true,
+			},
+		},
+		{
+			name: "json array",
+			url:  server.URL + "/users",
+			want: map[string]any{
+				"format":     "json",
+				"records":    []any{map[string]any{"name": "ann"}, map[string]any{"name": "bob"}},
+				"total_rows": float64(2),
+				"truncated":  false,
+			},
+		},
+		{
+			name: "json object",
+			url:  server.URL + "/stats",
+			want: map[string]any{
+				"format":     "json",
+				"records":    []any{map[string]any{"count": float64(3)}},
+				"total_rows": float64(1),
+				"truncated":  false,
+			},
+		},
+		{
+			name: "format from extension",
+			url:  server.URL + "/export.csv",
+			want: map[string]any{
+				"format":     "csv",
+				"columns":    []any{"a"},
+				"records":    []any{map[string]any{"a": "1"}},
+				"total_rows": float64(1),
+				"truncated":  false,
+			},
+		},
+		{
+			name:      "unsupported content type",
+			url:       server.URL + "/page",
+			wantError: `unsupported content type "text/html"`,
+		},
+		{
+			name:      "oversized response",
+			url:       server.URL + "/large",
+			wantError: "exceeds the limit of 100 bytes",
+		},
+		{
+			name:      "invalid data",
+			url:       server.URL + "/broken",
+			wantError: "invalid JSON data",
+		},
+		{
+			name:      "host not allowed",
+			url:       "http://example.com/data.json",
+			wantError: `host "example.com" is not allowed`,
+		},
+		{
+			name:      "redirect to host not allowed",
+			url:       server.URL + "/elsewhere",
+			wantError: `redirect to host "example.com" is not allowed`,
+		},
+		{
+			name:      "unsupported scheme",
+			url:       "file:///etc/passwd",
+			wantError: `unsupported URL scheme "file"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := toolImpl.Run(ctx, map[string]any{"url": tc.url})
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("Run() error = %v, want error containing %q", err, tc.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDataFetchToolAllowedHosts(t *testing.T) {
+	if _, err := datafetchtool.New(datafetchtool.Config{}); err == nil {
+		t.Error("datafetchtool.New() succeeded without allowed hosts")
+	}
+	for _, host := range []string{"*", "*example.com", "data.*.com", "*.*.example.com"} {
+		if _, err := datafetchtool.New(datafetchtool.Config{AllowedHosts: []string{host}}); err == nil {
+			t.Errorf("datafetchtool.New() succeeded with the allowed host %q", host)
+		}
+	}
+
+	fetchTool, err := datafetchtool.New(datafetchtool.Config{
+		AllowedHosts: []string{"*.example.com"},
+		Client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", "application/json")
+			rec.WriteString(`{"host":"` + r.URL.Hostname() + `"}`)
+			return rec.Result(), nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("datafetchtool.New() failed: %v", err)
+	}
+	toolImpl := fetchTool.(toolinternal.FunctionTool)
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{host: "example.com", allowed: true},
+		{host: "data.example.com", allowed: true},
+		{host: "a.data.EXAMPLE.com", allowed: true},
+		{host: "evilexample.com", allowed: false},
+		{host: "example.com.evil.org", allowed: false},
+	}
+	for _, tc := range tests {
+		_, err := toolImpl.Run(ctx, map[string]any{"url": "http://" + tc.host + "/data.json"})
+		if got := err == nil; got != tc.allowed {
+			t.Errorf("Run() with host %q: error = %v, want allowed = %t", tc.host, err, tc.allowed)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -13,6 +13,13 @@
 // limitations under the License.
 
 // Package exitlooptool provides a tool that allows an agent to exit a loop.
+//
+// The tool takes no arguments. When the model calls it, it sets the Escalate
+// action of its tool.Context, which is recorded in the EventActions of the
+// function response event. The event goes up through the agents of the
+// invocation like any other, and loopagent stops iterating after the
+// sub-agent that produced it, without running the remaining sub-agents.
+// Other workflow agents can read event.Actions.Escalate the same way.
 package exitlooptool
 
 import (
@@ -31,7 +38,8 @@ func exitLoop(ctx tool.Context, myArgs EmptyArgs) (map[string]string, error) {
 	return map[string]string{}, nil
 }
 
-// New creates an instance of an exitLoop tool.
+// New creates an instance of an exitLoop tool, named "exit_loop". Its
+// result is an empty map.
 func New() (tool.Tool, error) {
 	exitLoopTool, err := functiontool.New(functiontool.Config{
 		Name:        "exit_loop",
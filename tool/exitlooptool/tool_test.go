@@ -19,16 +19,36 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/jsonschema-go/jsonschema"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
 	"google.golang.org/adk/agent/workflowagents/loopagent"
 	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/exitlooptool"
 )
 
+func TestExitLoopToolDeclaration(t *testing.T) {
+	exitLoopTool, err := exitlooptool.New()
+	if err != nil {
+		t.Fatalf("failed to create exit tool: %v", err)
+	}
+	decl := exitLoopTool.(toolinternal.FunctionTool).Declaration()
+	if decl.Name != "exit_loop" {
+		t.Errorf("Declaration().Name = %q, want %q", decl.Name, "exit_loop")
+	}
+	schema, ok := decl.ParametersJsonSchema.(*jsonschema.Schema)
+	if !ok {
+		t.Fatalf("Declaration().ParametersJsonSchema is %T, want *jsonschema.Schema", decl.ParametersJsonSchema)
+	}
+	if len(schema.Properties) != 0 || len(schema.Required) != 0 {
+		t.Errorf("Declaration() parameters = %+v, want none", schema)
+	}
+}
+
 // --- Test Suite ---
 func TestExitLoopToolExitsLoopAgent(t *testing.T) {
 	// Define the structure for our test cases
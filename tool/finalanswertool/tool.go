@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package finalanswertool provides a tool the model calls to end the
+// invocation with a structured final answer.
+//
+// The arguments of the call are the answer. They are validated against the
+// schema inferred from the answer type, so an invalid answer is reported to
+// the model as an error, and the model can call the tool again. A valid call
+// ends the invocation: the model is not called again with the result.
+// The application reads the answer from the function response event with
+// [Answer], or from the session state with Config.OutputKey.
+package finalanswertool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// DefaultName is the default name of the tool.
+const DefaultName = "final_answer"
+
+// answerKey is the key of the answer in the function response.
+const answerKey = "answer"
+
+// Config is the configuration of the final answer tool.
+type Config struct {
+	// Name of the tool. Defaults to DefaultName.
+	Name string
+	// Description of the tool. Defaults to a description that asks the model
+	// to call the tool once, with its complete answer.
+	Description string
+	// OutputKey, if set, is the session state key the answer is saved under.
+	OutputKey string
+}
+
+// New creates a final answer tool whose arguments are an answer of type T,
+// which must be a struct.
+func New[T any](cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = DefaultName
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Gives the final answer to the user's request and ends the conversation turn. " +
+			"Call it once, when the answer is complete, instead of replying with text."
+	}
+	t, err := functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, func(ctx tool.Context, answer T) (map[string]any, error) {
+		// Ends the invocation with the function response, instead of
+		// letting the model reply to it.
+		ctx.Actions().SkipSummarization = true
+		m, err := toMap(answer)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.OutputKey != "" {
+			ctx.Actions().StateDelta[cfg.OutputKey] = m
+		}
+		return map[string]any{answerKey: m}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating final answer tool: %w", err)
+	}
+	return t, nil
+}
+
+// Answer returns the answer given to the tool named toolName in the
+// function responses of ev. It reports false if ev holds no such answer.
+func Answer[T any](ev *session.Event, toolName string) (T, bool, error) {
+	var answer T
+	if ev == nil || ev.Content == nil {
+		return answer, false, nil
+	}
+	for _, p := range ev.Content.Parts {
+		fr := p.FunctionResponse
+		if fr == nil || fr.Name != toolName {
+			continue
+		}
+		v, ok := fr.Response[answerKey]
+		if !ok {
+			// The call failed, e.g. with invalid arguments.
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return answer, false, fmt.Errorf("failed to decode the final answer: %w", err)
+		}
+		if err := json.Unmarshal(b, &answer); err != nil {
+			return answer, false, fmt.Errorf("failed to decode the final answer: %w", err)
+		}
+		return answer, true, nil
+	}
+	return answer, false, nil
+}
+
+// toMap returns the JSON object form of the answer, as stored in the
+// function response and the session state.
+func toMap(answer any) (map[string]any, error) {
+	b, err := json.Marshal(answer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the final answer: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("final answer must be a JSON object: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finalanswertool_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/finalanswertool"
+)
+
+type verdict struct {
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+}
+
+func TestFinalAnswerTool(t *testing.T) {
+	finalAnswer, err := finalanswertool.New[verdict](finalanswertool.Config{OutputKey: "verdict"})
+	if err != nil {
+		t.Fatalf("finalanswertool.New() failed: %v", err)
+	}
+	model := &testutil.MockModel{Responses: []*genai.Content{
+		// Invalid answer, reported to the model.
+		genai.NewContentFromFunctionCall(finalanswertool.DefaultName, map[string]any{
+			"label":      "spam",
+			"confidence": "high",
+		}, genai.RoleModel),
+		genai.NewContentFromFunctionCall(finalanswertool.DefaultName, map[string]any{
+			"label":      "spam",
+			"confidence": 0.9,
+		}, genai.RoleModel),
+		genai.NewContentFromText("unexpected call after the final answer", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "classifier",
+		Model: model,
+		Tools: []tool.Tool{finalAnswer},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLM Agent: %v", err)
+	}
+	runner := testutil.NewTestAgentRunner(t, a)
+
+	var answers []verdict
+	for ev, err := range runner.Run(t, "session", "Classify: you won a prize!") {
+		if err != nil {
+			t.Fatalf("agent run failed: %v", err)
+		}
+		answer, ok, err := finalanswertool.Answer[verdict](ev, finalanswertool.DefaultName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			answers = append(answers, answer)
+		}
+		if v, ok := ev.Actions.StateDelta["verdict"]; ok {
+			if diff := cmp.Diff(map[string]any{"label": "spam", "confidence": 0.9}, v); diff != "" {
+				t.Errorf("state delta mismatch (-want +got):\n%s", diff)
+			}
+		}
+	}
+	if diff := cmp.Diff([]verdict{{Label: "spam", Confidence: 0.9}}, answers); diff != "" {
+		t.Errorf("answers mismatch (-want +got):\n%s", diff)
+	}
+	if len(model.Requests) != 2 {
+		t.Errorf("got %d model requests, want the invocation to end with the valid answer", len(model.Requests))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"google.golang.org/adk/tool"
+)
+
+// FileInput is the type of the tool parameters referencing a file by the
+// name of the artifact holding it, e.g. a document the user uploaded out of
+// band or saved with agent.RunConfig.SaveInputBlobsAsArtifacts. The model
+// passes the artifact name, and the tool reads the file with Open, so that
+// large files go through neither the model's context nor the arguments.
+//
+// In the function declaration, a FileInput parameter is a string.
+type FileInput struct {
+	// Artifact is the name of the artifact holding the file.
+	Artifact string
+}
+
+var fileInputSchema = &jsonschema.Schema{
+	Type:        "string",
+	Description: "The name of the artifact holding the file.",
+}
+
+// MarshalJSON encodes the FileInput as its artifact name.
+func (f FileInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Artifact)
+}
+
+// UnmarshalJSON decodes a FileInput from an artifact name.
+func (f *FileInput) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &f.Artifact)
+}
+
+// File is a file opened by FileInput.Open.
+type File struct {
+	io.Reader
+	// MIMEType is the MIME type of the file, if known.
+	MIMEType string
+	// Size is the size of the file in bytes.
+	Size int64
+}
+
+// Open returns a reader of the latest version of the artifact of f.
+// Artifacts referencing a file by URI are not supported.
+func (f FileInput) Open(ctx tool.Context) (*File, error) {
+	if strings.TrimSpace(f.Artifact) == "" {
+		return nil, fmt.Errorf("no artifact name given for the file")
+	}
+	artifacts := ctx.Artifacts()
+	if artifacts == nil {
+		return nil, fmt.Errorf("cannot open file %q: artifacts are not available", f.Artifact)
+	}
+	resp, err := artifacts.Load(ctx, f.Artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load artifact %q: %w", f.Artifact, err)
+	}
+	part := resp.Part
+	switch {
+	case part == nil:
+		return nil, fmt.Errorf("artifact %q is empty", f.Artifact)
+	case part.InlineData != nil:
+		data := part.InlineData.Data
+		return &File{Reader: bytes.NewReader(data), MIMEType: part.InlineData.MIMEType, Size: int64(len(data))}, nil
+	case part.Text != "":
+		return &File{Reader: strings.NewReader(part.Text), MIMEType: "text/plain", Size: int64(len(part.Text))}, nil
+	case part.FileData != nil:
+		return nil, fmt.Errorf("artifact %q references the file %s, which cannot be opened", f.Artifact, part.FileData.FileURI)
+	default:
+		return nil, fmt.Errorf("artifact %q holds no file", f.Artifact)
+	}
+}
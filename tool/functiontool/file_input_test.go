@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestFileInput(t *testing.T) {
+	type countArgs struct {
+		Document functiontool.FileInput `json:"document"`
+	}
+	type countResult struct {
+		MIMEType string `json:"mime_type"`
+		Size     int64  `json:"size"`
+		Lines    int    `json:"lines"`
+	}
+	countLines, err := functiontool.New(functiontool.Config{
+		Name:        "count_lines",
+		Description: "counts the lines of a document",
+	}, func(ctx tool.Context, args countArgs) (countResult, error) {
+		f, err := args.Document.Open(ctx)
+		if err != nil {
+			return countResult{}, err
+		}
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return countResult{}, err
+		}
+		return countResult{MIMEType: f.MIMEType, Size: f.Size, Lines: strings.Count(string(data), "\n")}, nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+
+	schema := countLines.(toolinternal.FunctionTool).Declaration().ParametersJsonSchema.(*jsonschema.Schema)
+	if got := schema.Properties["document"].Type; got != "string" {
+		t.Errorf("document parameter type = %q, want %q", got, "string")
+	}
+
+	artifacts := &artifactinternal.Artifacts{
+		Service:   artifact.InMemoryService(),
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "session",
+	}
+	if _, err := artifacts.Save(t.Context(), "report.csv", genai.NewPartFromBytes([]byte("a,b\n1,2\n3,4\n"), "text/csv")); err != nil {
+		t.Fatalf("failed to save artifact: %v", err)
+	}
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Artifacts: artifacts,
+	}), "", nil, nil)
+
+	got, err := countLines.(toolinternal.FunctionTool).Run(ctx, map[string]any{"document": "report.csv"})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	want := map[string]any{"mime_type": "text/csv", "size": float64(12), "lines": float64(3)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := countLines.(toolinternal.FunctionTool).Run(ctx, map[string]any{"document": "missing.csv"}); err == nil || !strings.Contains(err.Error(), "missing.csv") {
+		t.Errorf("Run() error = %v, want an error about the missing artifact", err)
+	}
+}
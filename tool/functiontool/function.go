@@ -16,14 +16,21 @@
 package functiontool
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/toolinternal/toolutils"
 	"google.golang.org/adk/internal/typeutil"
 	"google.golang.org/adk/model"
@@ -41,6 +48,10 @@ type Config struct {
 	// An optional JSON schema object defining the expected parameters for the tool.
 	// If it is nil, FunctionTool tries to infer the schema based on the handler type.
 	InputSchema *jsonschema.Schema
+	// SchemaConflictPolicy decides what New does when InputSchema
+	// conflicts with the schema inferred from the arguments type. Defaults
+	// to TrustOverride.
+	SchemaConflictPolicy SchemaConflictPolicy
 	// An optional JSON schema object defining the structure of the tool's output.
 	// If it is nil, FunctionTool tries to infer the schema based on the handler type.
 	OutputSchema *jsonschema.Schema
@@ -64,10 +75,106 @@ type Config struct {
 	// where ToolArgs is the input type of your go function
 	// Returning true means confirmation is required.
 	RequireConfirmationProvider any
+
+	// Version of the tool, reported in the provenance of its results.
+	Version string
+	// Provenance adds the ProvenanceKey field to the tool results, e.g. for
+	// the audit of the data the model gets. The field is declared in the
+	// output schema of the tool.
+	Provenance bool
+
+	// SchemaVersion of the input schema. Bump it on incompatible changes to
+	// the arguments, together with MigrateArgs.
+	SchemaVersion int
+	// MigrateArgs converts the arguments of a historical call made against
+	// an older input schema version, e.g. a call resumed after a
+	// confirmation, to the current SchemaVersion. It is called before the
+	// arguments are converted to the handler's input type. Without it,
+	// calls against other versions fail.
+	MigrateArgs func(fromVersion int, args map[string]any) (map[string]any, error)
+
+	// SecretProvider gives the handler access to secrets with Secret, e.g.
+	// the credentials of the service the tool calls, without exposing them
+	// to the model.
+	SecretProvider tool.SecretProvider
+	// Secrets are the names of the secrets the tool is allowed to read from
+	// SecretProvider.
+	Secrets []string
+
+	// ErrorFormatter converts the errors of the tool into the message sent
+	// to the model in the function response, e.g. "The search service is
+	// temporarily unavailable, ask the user to try again later.". Defaults
+	// to DefaultErrorFormatter.
+	ErrorFormatter func(error) string
+
+	// OnPartialArgs is called while the model streams the arguments of a
+	// call to the tool, with the arguments received so far, e.g. to start
+	// slow work as soon as the first field is known. See
+	// tool.PartialArgsReceiver. The handler still gets the complete, validated
+	// arguments.
+	OnPartialArgs func(ctx context.Context, callID string, args map[string]any)
+
+	// OnUpdate receives the results of a call to a tool created by
+	// NewLongRunning that follow the first one, as the function responses
+	// to send back to the agent, e.g. in the content of its next run. It is
+	// called from the goroutine running the operation, with the context of
+	// the call. It returns false to stop the operation. Required by
+	// NewLongRunning.
+	OnUpdate func(ctx tool.Context, response *genai.FunctionResponse) bool
+
+	// ResultKey, if set, is the key under which all the results of the
+	// tool are returned, structs and maps included, e.g. to namespace the
+	// results of tools whose outputs are aggregated. It replaces the
+	// default ResultKey of the results that are not objects. The
+	// ProvenanceKey, tool.ResultPartsKey and CompletedKey fields stay at the
+	// top level.
+	ResultKey string
+}
+
+// DefaultErrorFormatter is the default Config.ErrorFormatter. It returns the
+// error message without the stack traces and goroutine dumps it may contain,
+// e.g. after a panic in the handler.
+func DefaultErrorFormatter(err error) string {
+	msg := err.Error()
+	for _, marker := range []string{"\nstack:", "\ngoroutine "} {
+		if i := strings.Index(msg, marker); i >= 0 {
+			msg = msg[:i]
+		}
+	}
+	return strings.TrimSpace(msg)
 }
 
+// SchemaVersionKey is the argument that records the input schema version a
+// historical function call was made against. Calls without it are assumed
+// to match the current Config.SchemaVersion.
+const SchemaVersionKey = toolinternal.SchemaVersionKey
+
+// ProvenanceKey is the key of the field added to the results of function
+// tools when Config.Provenance is set. It holds the name and version of the
+// tool and the time the result was produced, so that the model and audit
+// logs can attribute the data to its source.
+const ProvenanceKey = "_provenance"
+
+// ResultKey is the key of the result map under which the output of the
+// handlers returning neither a struct nor a map, e.g. a string, a number or
+// a slice, is returned, since function responses are objects. A handler
+// returning "sunny" produces {"result": "sunny"}, and the output schema in
+// the function declaration describes this object. Config.ResultKey
+// overrides it.
+const ResultKey = "result"
+
 // Func represents a Go function that can be wrapped in a tool.
 // It takes a tool.Context and a generic argument type, and returns a generic result type.
+//
+// An error returned by the function does not end the turn of the agent: it
+// is sent to the model as the function response {"error": message}, the
+// message given by Config.ErrorFormatter, so that the model can recover,
+// e.g. by retrying with other arguments or by telling the user.
+//
+// The multimodal parts of the results built with tool.NewResult are passed
+// as is, e.g. images and audio are not encoded to base64 and back in the
+// conversion of the result to a map, only when sent to the model. Large
+// binary inputs are best given as FileInput parameters.
 type Func[TArgs, TResults any] func(tool.Context, TArgs) (TResults, error)
 
 // ErrInvalidArgument indicates the input parameter type is invalid.
@@ -76,7 +183,7 @@ var ErrInvalidArgument = errors.New("invalid argument")
 // New creates a new tool with a name, description, and the provided handler.
 // Input schema is automatically inferred from the input and output types.
 func New[TArgs, TResults any](cfg Config, handler Func[TArgs, TResults]) (tool.Tool, error) {
-	// TODO: How can we improve UX for functions that does not require an argument, returns a simple type value, or returns a no result?
+	// TODO: How can we improve UX for functions that return no result?
 	// https://github.com/modelcontextprotocol/go-sdk/discussions/37
 
 	var zeroArgs TArgs
@@ -88,7 +195,12 @@ func New[TArgs, TResults any](cfg Config, handler Func[TArgs, TResults]) (tool.T
 		return nil, fmt.Errorf("input must be a struct or a map or a pointer to those types, but received: %v: %w", argsType, ErrInvalidArgument)
 	}
 
-	ischema, err := resolvedSchema[TArgs](cfg.InputSchema)
+	switch cfg.ResultKey {
+	case ProvenanceKey, tool.ResultPartsKey, CompletedKey:
+		return nil, fmt.Errorf("result key %q is reserved", cfg.ResultKey)
+	}
+
+	ischema, conflicts, err := resolvedInputSchema[TArgs](cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to infer input schema: %w", err)
 	}
@@ -111,19 +223,38 @@ func New[TArgs, TResults any](cfg Config, handler Func[TArgs, TResults]) (tool.T
 	return &functionTool[TArgs, TResults]{
 		cfg:                         cfg,
 		inputSchema:                 ischema,
+		schemaConflicts:             conflicts,
 		outputSchema:                oschema,
 		handler:                     handler,
 		requireConfirmation:         cfg.RequireConfirmation,
 		requireConfirmationProvider: confirmWrapper,
+		wrapResult:                  cfg.ResultKey != "" || wrapsResult(reflect.TypeFor[TResults]()),
 	}, nil
 }
 
+// wrapsResult reports whether the results of type t are returned under
+// ResultKey. The results of interface types are only wrapped at run time
+// if they do not convert to a map.
+func wrapsResult(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return false
+	}
+	return true
+}
+
 // functionTool wraps a Go function.
 type functionTool[TArgs, TResults any] struct {
 	cfg Config
 
 	// A JSON Schema object defining the expected parameters for the tool.
 	inputSchema *jsonschema.Resolved
+	// schemaConflicts are the conflicts of the trusted Config.InputSchema
+	// with the arguments type, reported when the arguments fail to convert.
+	schemaConflicts []string
 	// A JSON Schema object defining the result of the tool.
 	outputSchema *jsonschema.Resolved
 
@@ -133,6 +264,18 @@ type functionTool[TArgs, TResults any] struct {
 	requireConfirmation bool
 
 	requireConfirmationProvider func(TArgs) bool
+
+	// streaming is set for the tools created by NewLongRunning.
+	streaming bool
+	// noArgs is set for the tools created by NewNoArgs.
+	noArgs bool
+	// wrapResult is set when the results are returned under resultKey.
+	wrapResult bool
+
+	// declaration is built once, on the first call to Declaration, as
+	// requests are assembled for each model call.
+	declarationOnce sync.Once
+	declaration     *genai.FunctionDeclaration
 }
 
 // Description implements tool.Tool.
@@ -150,22 +293,93 @@ func (f *functionTool[TArgs, TResults]) IsLongRunning() bool {
 	return f.cfg.IsLongRunning
 }
 
+// ReceivePartialArgs implements tool.PartialArgsReceiver.
+func (f *functionTool[TArgs, TResults]) ReceivePartialArgs(ctx context.Context, callID string, args map[string]any) {
+	if f.cfg.OnPartialArgs != nil {
+		f.cfg.OnPartialArgs(ctx, callID, args)
+	}
+}
+
 // ProcessRequest packs the function tool's declaration into the LLM request.
 func (f *functionTool[TArgs, TResults]) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
 	return toolutils.PackTool(req, f)
 }
 
-// FunctionDeclaration implements interfaces.FunctionTool.
+// FormatError implements toolinternal.ErrorFormatter.
+func (f *functionTool[TArgs, TResults]) FormatError(err error) string {
+	if f.cfg.ErrorFormatter != nil {
+		return f.cfg.ErrorFormatter(err)
+	}
+	return DefaultErrorFormatter(err)
+}
+
+// SchemaVersion implements toolinternal.SchemaVersioned.
+func (f *functionTool[TArgs, TResults]) SchemaVersion() int {
+	return f.cfg.SchemaVersion
+}
+
+// FunctionDeclaration implements interfaces.FunctionTool. The declaration
+// is built once; each call returns a shallow copy of it, so that requests
+// modifying the fields of their declaration do not affect the others. The
+// schemas are shared, they must be copied to be modified.
 func (f *functionTool[TArgs, TResults]) Declaration() *genai.FunctionDeclaration {
+	f.declarationOnce.Do(func() {
+		f.declaration = f.newDeclaration()
+	})
+	decl := *f.declaration
+	return &decl
+}
+
+// withReservedFields returns the output schema with the reserved fields
+// the tool adds to its results, ProvenanceKey and CompletedKey, declared,
+// so that the results match it.
+func (f *functionTool[TArgs, TResults]) withReservedFields(schema *jsonschema.Schema) *jsonschema.Schema {
+	if schema.Type != "object" || (!f.cfg.Provenance && !f.streaming) {
+		return schema
+	}
+	s := *schema
+	s.Properties = maps.Clone(schema.Properties)
+	if s.Properties == nil {
+		s.Properties = make(map[string]*jsonschema.Schema)
+	}
+	if f.cfg.Provenance {
+		s.Properties[ProvenanceKey] = &jsonschema.Schema{
+			Type:        "object",
+			Description: "The source of the result.",
+			Properties: map[string]*jsonschema.Schema{
+				"tool":      {Type: "string"},
+				"version":   {Type: "string"},
+				"timestamp": {Type: "string", Format: "date-time"},
+			},
+		}
+	}
+	if f.streaming {
+		s.Properties[CompletedKey] = &jsonschema.Schema{
+			Type:        "boolean",
+			Description: "Whether the operation is completed.",
+		}
+	}
+	return &s
+}
+
+func (f *functionTool[TArgs, TResults]) newDeclaration() *genai.FunctionDeclaration {
 	decl := &genai.FunctionDeclaration{
 		Name:        f.Name(),
 		Description: f.Description(),
 	}
-	if f.inputSchema != nil {
+	if f.inputSchema != nil && !f.noArgs {
 		decl.ParametersJsonSchema = f.inputSchema.Schema()
 	}
 	if f.outputSchema != nil {
-		decl.ResponseJsonSchema = f.outputSchema.Schema()
+		schema := f.outputSchema.Schema()
+		if f.wrapResult {
+			schema = &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{f.resultKey(): schema},
+				Required:   []string{f.resultKey()},
+			}
+		}
+		decl.ResponseJsonSchema = f.withReservedFields(schema)
 	}
 
 	if f.cfg.IsLongRunning {
@@ -182,19 +396,26 @@ func (f *functionTool[TArgs, TResults]) Declaration() *genai.FunctionDeclaration
 
 // Run executes the tool with the provided context and yields events.
 func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result map[string]any, err error) {
-	// TODO: Handle function call request from tc.InvocationContext.
-	defer func() {
-		if r := recover(); r != nil {
-			err = fmt.Errorf("panic in tool %q: %v\nstack: %s", f.Name(), r, debug.Stack())
-		}
-	}()
+	defer f.recoverPanic(&err)
 
 	m, ok := args.(map[string]any)
-	if !ok {
+	if !ok && args != nil {
 		return nil, fmt.Errorf("unexpected args type, got: %T", args)
 	}
+	if m == nil || f.noArgs {
+		// Models may omit the arguments of calls to tools without required
+		// parameters. Convert them as an empty object rather than JSON null.
+		m = map[string]any{}
+	}
+	m, err = f.migrateArgs(m)
+	if err != nil {
+		return nil, err
+	}
 	input, err := typeutil.ConvertToWithJSONSchema[map[string]any, TArgs](m, f.inputSchema)
 	if err != nil {
+		if len(f.schemaConflicts) > 0 {
+			return nil, fmt.Errorf("%w (the input schema of the tool conflicts with its arguments type: %s)", err, strings.Join(f.schemaConflicts, "; "))
+		}
 		return nil, err
 	}
 
@@ -223,10 +444,152 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 		}
 	}
 
+	output, secrets, err := f.call(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.buildResult(output, secrets)
+	if err != nil {
+		return nil, err
+	}
+	if f.streaming {
+		if resp == nil {
+			resp = make(map[string]any)
+		}
+		resp[CompletedKey] = false
+	}
+	return resp, nil
+}
+
+// recoverPanic turns a panic of the handler into the error *err.
+func (f *functionTool[TArgs, TResults]) recoverPanic(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if rerr, ok := r.(error); ok {
+		// Keep the error, e.g. context.Canceled, for errors.Is.
+		*err = fmt.Errorf("panic in tool %q: %w\nstack: %s", f.Name(), rerr, debug.Stack())
+		return
+	}
+	*err = fmt.Errorf("panic in tool %q: %v\nstack: %s", f.Name(), r, debug.Stack())
+}
+
+// call calls the handler, giving it access to the secrets of the tool, if
+// any. It returns the secret scope of the call, for the redaction of the
+// results.
+func (f *functionTool[TArgs, TResults]) call(ctx tool.Context, input TArgs) (TResults, *secretScope, error) {
+	var secrets *secretScope
+	if f.cfg.SecretProvider != nil {
+		secrets = &secretScope{tool: f.Name(), provider: f.cfg.SecretProvider, allowed: f.cfg.Secrets}
+		ctx = &secretContext{Context: ctx, scope: secrets}
+	}
 	output, err := f.handler(ctx, input)
+	if err != nil && secrets != nil {
+		err = secrets.redactError(err)
+	}
+	return output, secrets, err
+}
+
+// buildResult converts the output of the handler to the result map, with
+// the secrets redacted and the provenance added.
+func (f *functionTool[TArgs, TResults]) buildResult(output TResults, secrets *secretScope) (map[string]any, error) {
+	resp, err := f.convertResult(output)
 	if err != nil {
 		return nil, err
 	}
+	if secrets != nil {
+		resp, _ = secrets.redact(resp).(map[string]any)
+	}
+	if f.cfg.Provenance {
+		provenance := map[string]any{
+			"tool":      f.Name(),
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		}
+		if f.cfg.Version != "" {
+			provenance["version"] = f.cfg.Version
+		}
+		if resp == nil {
+			resp = make(map[string]any)
+		}
+		resp[ProvenanceKey] = provenance
+	}
+	return resp, nil
+}
+
+// migrateArgs removes the SchemaVersionKey argument from args and, if the
+// call was made against another schema version, migrates the arguments to
+// the current one.
+func (f *functionTool[TArgs, TResults]) migrateArgs(args map[string]any) (map[string]any, error) {
+	v, ok := args[SchemaVersionKey]
+	if !ok {
+		return args, nil
+	}
+	args = maps.Clone(args)
+	delete(args, SchemaVersionKey)
+
+	var from int
+	switch v := v.(type) {
+	case int:
+		from = v
+	case float64:
+		from = int(v)
+	default:
+		return nil, fmt.Errorf("tool %q: invalid %s argument %v", f.Name(), SchemaVersionKey, v)
+	}
+	if from == f.cfg.SchemaVersion {
+		return args, nil
+	}
+	if f.cfg.MigrateArgs == nil {
+		return nil, fmt.Errorf("tool %q: no migration of arguments from schema version %d to %d", f.Name(), from, f.cfg.SchemaVersion)
+	}
+	migrated, err := f.cfg.MigrateArgs(from, args)
+	if err != nil {
+		return nil, fmt.Errorf("tool %q: failed to migrate arguments from schema version %d to %d: %w", f.Name(), from, f.cfg.SchemaVersion, err)
+	}
+	return migrated, nil
+}
+
+// convertResult converts the output of the handler to the result map.
+func (f *functionTool[TArgs, TResults]) convertResult(output TResults) (map[string]any, error) {
+	if m, ok := any(output).(map[string]any); ok {
+		if parts, ok := m[tool.ResultPartsKey].([]tool.ResultPart); ok {
+			// Keep the multimodal parts out of the JSON conversion, so that
+			// their blobs are passed as is rather than encoded to base64 and
+			// decoded back. They are only encoded when sent to the model.
+			rest := maps.Clone(m)
+			delete(rest, tool.ResultPartsKey)
+			resp, err := f.convertResult(any(rest).(TResults))
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil {
+				resp = make(map[string]any)
+			}
+			resp[tool.ResultPartsKey] = parts
+			return resp, nil
+		}
+	}
+	if f.wrapResult {
+		// Convert the output to its JSON value, as it is sent to the model.
+		b, err := json.Marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		if v == nil && reflect.TypeFor[TResults]().Kind() == reflect.Slice {
+			v = []any{}
+		}
+		if f.outputSchema != nil {
+			if err := f.outputSchema.Validate(v); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]any{f.resultKey(): v}, nil
+	}
 	resp, err := typeutil.ConvertToWithJSONSchema[TResults, map[string]any](output, f.outputSchema)
 	if err == nil { // all good
 		return resp, nil
@@ -241,10 +604,18 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 			return resp, err // if it fails propagate original err.
 		}
 	}
-	wrappedOutput := map[string]any{"result": output}
+	wrappedOutput := map[string]any{f.resultKey(): output}
 	return wrappedOutput, nil
 }
 
+// resultKey returns the key under which the results are wrapped.
+func (f *functionTool[TArgs, TResults]) resultKey() string {
+	if f.cfg.ResultKey != "" {
+		return f.cfg.ResultKey
+	}
+	return ResultKey
+}
+
 // ** NOTE FOR REVIEWERS **
 // Initially I started to borrow the design of the MCP ServerTool and
 // ToolHandlerFor/ToolHandler [1], but got diverged.
@@ -252,10 +623,9 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 //    but we expect Function in our case is a simple wrapper around a Go
 //    function, and does not need to worry about how the result is translated
 //    in genai.Content.
-//  * Function returns only TResults, not (TResults, error). If the user
-//    function can return an error, that needs to be included in the output
-//    json schema. And for function that never returns an error, I think it
-//    gets less uglier.
+//  * Function returns (TResults, error), like most Go functions. The error
+//    is not part of the output json schema: it is reported to the model as
+//    the {"error": message} function response instead of the result.
 //  * MCP ToolHandler expects mcp.ServerSession. types.ToolContext may be close
 //    to it, but we don't need to expose this to user function
 //    (similar to ADK Python FunctionTool [2])
@@ -264,13 +634,14 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 //  [2] ADK Python https://github.com/google/adk-python/blob/04de3e197d7a57935488eb7bfa647c7ab62cd9d9/src/google/adk/tools/function_tool.py#L110-L112
 
 func resolvedSchema[T any](override *jsonschema.Schema) (*jsonschema.Resolved, error) {
-	// TODO: check if override schema is compatible with T.
+	// The override of the input schema is compared with T by
+	// resolvedInputSchema.
 	if override != nil {
 		return override.Resolve(nil)
 	}
-	schema, err := jsonschema.For[T](nil)
+	inferred, err := inferSchema[T]()
 	if err != nil {
 		return nil, err
 	}
-	return schema.Resolve(nil)
+	return inferred.resolved, nil
 }
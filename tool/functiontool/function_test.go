@@ -15,6 +15,7 @@
 package functiontool_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,6 +24,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -258,6 +260,84 @@ func TestFunctionTool_DifferentFunctionDeclarations_ConsolidatedInOneGenAiTool(t
 	}
 }
 
+func TestFunctionTool_DeclarationNotShared(t *testing.T) {
+	type Args struct {
+		X int `json:"x"`
+	}
+	identityTool, err := functiontool.New(functiontool.Config{
+		Name:        "identity",
+		Description: "returns the input value",
+	}, func(ctx tool.Context, input Args) (int, error) {
+		return input.X, nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+	requestProcessor := identityTool.(toolinternal.RequestProcessor)
+
+	// A callback editing the declaration of a request in place does not
+	// affect the next requests.
+	var first model.LLMRequest
+	if err := requestProcessor.ProcessRequest(nil, &first); err != nil {
+		t.Fatalf("ProcessRequest() failed: %v", err)
+	}
+	first.Config.Tools[0].FunctionDeclarations[0].Description = "edited"
+
+	var second model.LLMRequest
+	if err := requestProcessor.ProcessRequest(nil, &second); err != nil {
+		t.Fatalf("ProcessRequest() failed: %v", err)
+	}
+	if got := second.Config.Tools[0].FunctionDeclarations[0].Description; got != "returns the input value" {
+		t.Errorf("Description = %q after editing an earlier request, want %q", got, "returns the input value")
+	}
+}
+
+func TestFunctionTool_NilArgs(t *testing.T) {
+	type Args struct {
+		Verbose bool `json:"verbose,omitempty"`
+	}
+	type RequiredArgs struct {
+		City string `json:"city"`
+	}
+
+	var got *Args
+	noRequired, err := functiontool.New(functiontool.Config{
+		Name: "list_cities",
+	}, func(_ tool.Context, input Args) (string, error) {
+		got = &input
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+	required, err := functiontool.New(functiontool.Config{
+		Name: "get_weather",
+	}, func(_ tool.Context, input RequiredArgs) (string, error) {
+		return "sunny", nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+
+	for _, args := range []any{nil, map[string]any(nil), map[string]any{}} {
+		got = nil
+		result, err := noRequired.(toolinternal.FunctionTool).Run(createToolContext(t), args)
+		if err != nil {
+			t.Fatalf("Run(%#v) failed: %v", args, err)
+		}
+		if diff := cmp.Diff(map[string]any{"result": "ok"}, result); diff != "" {
+			t.Errorf("Run(%#v) mismatch (-want +got):\n%s", args, diff)
+		}
+		if got == nil || *got != (Args{}) {
+			t.Errorf("Run(%#v) called the handler with %v, want the zero Args", args, got)
+		}
+
+		if _, err := required.(toolinternal.FunctionTool).Run(createToolContext(t), args); err == nil {
+			t.Errorf("Run(%#v) of a tool with required parameters succeeded, want error", args)
+		}
+	}
+}
+
 func TestFunctionTool_ReturnsBasicType(t *testing.T) {
 	type Args struct {
 		City string `json:"city"`
@@ -348,6 +428,177 @@ func TestFunctionTool_ReturnsBasicType(t *testing.T) {
 	}
 }
 
+func TestFunctionTool_WrappedResults(t *testing.T) {
+	type Args struct{}
+	newTool := func(t *testing.T, result any) tool.Tool {
+		t.Helper()
+		var ft tool.Tool
+		var err error
+		cfg := functiontool.Config{Name: "f"}
+		switch result := result.(type) {
+		case string:
+			ft, err = functiontool.New(cfg, func(tool.Context, Args) (string, error) { return result, nil })
+		case []string:
+			ft, err = functiontool.New(cfg, func(tool.Context, Args) ([]string, error) { return result, nil })
+		case int:
+			ft, err = functiontool.New(cfg, func(tool.Context, Args) (int, error) { return result, nil })
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ft
+	}
+
+	for _, tc := range []struct {
+		name       string
+		result     any
+		want       any
+		wantSchema *jsonschema.Schema
+	}{
+		{
+			name:       "string",
+			result:     "sunny",
+			want:       "sunny",
+			wantSchema: &jsonschema.Schema{Type: "string"},
+		},
+		{
+			name:       "string slice",
+			result:     []string{"a", "b"},
+			want:       []any{"a", "b"},
+			wantSchema: &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		},
+		{
+			name:       "nil slice",
+			result:     []string(nil),
+			want:       []any{},
+			wantSchema: &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		},
+		{
+			name:       "int",
+			result:     42,
+			want:       float64(42),
+			wantSchema: &jsonschema.Schema{Type: "integer"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ft := newTool(t, tc.result).(toolinternal.FunctionTool)
+
+			wantSchema := &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{functiontool.ResultKey: tc.wantSchema},
+				Required:   []string{functiontool.ResultKey},
+			}
+			if diff := cmp.Diff(wantSchema, ft.Declaration().ResponseJsonSchema, cmpopts.IgnoreUnexported(jsonschema.Schema{})); diff != "" {
+				t.Errorf("ResponseJsonSchema mismatch (-want +got):\n%s", diff)
+			}
+
+			got, err := ft.Run(createToolContext(t), map[string]any{})
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			want := map[string]any{functiontool.ResultKey: tc.want}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+
+			// The result round-trips through JSON, e.g. when saved in a
+			// session, unchanged.
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded map[string]any
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, decoded); diff != "" {
+				t.Errorf("result JSON round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionTool_CustomResultKey(t *testing.T) {
+	type Args struct{}
+	type Forecast struct {
+		Sky string `json:"sky"`
+	}
+	cfg := functiontool.Config{Name: "forecast", ResultKey: "forecast", Provenance: true}
+	structTool, err := functiontool.New(cfg, func(tool.Context, Args) (Forecast, error) {
+		return Forecast{Sky: "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scalarTool, err := functiontool.New(cfg, func(tool.Context, Args) (string, error) {
+		return "sunny", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		tool       tool.Tool
+		want       any
+		wantSchema *jsonschema.Schema
+	}{
+		{
+			name: "struct",
+			tool: structTool,
+			want: map[string]any{"sky": "sunny"},
+			wantSchema: &jsonschema.Schema{
+				Type:                 "object",
+				Properties:           map[string]*jsonschema.Schema{"sky": {Type: "string"}},
+				Required:             []string{"sky"},
+				AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+			},
+		},
+		{
+			name:       "scalar",
+			tool:       scalarTool,
+			want:       "sunny",
+			wantSchema: &jsonschema.Schema{Type: "string"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ft := tc.tool.(toolinternal.FunctionTool)
+			schema, ok := ft.Declaration().ResponseJsonSchema.(*jsonschema.Schema)
+			if !ok {
+				t.Fatalf("ResponseJsonSchema = %T, want a *jsonschema.Schema", ft.Declaration().ResponseJsonSchema)
+			}
+			if diff := cmp.Diff([]string{"forecast"}, schema.Required); diff != "" {
+				t.Errorf("ResponseJsonSchema.Required mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantSchema, schema.Properties["forecast"], cmpopts.IgnoreUnexported(jsonschema.Schema{})); diff != "" {
+				t.Errorf("ResponseJsonSchema forecast property mismatch (-want +got):\n%s", diff)
+			}
+			// The provenance is declared at the top level.
+			if schema.Properties[functiontool.ProvenanceKey] == nil {
+				t.Errorf("ResponseJsonSchema does not declare the %q field", functiontool.ProvenanceKey)
+			}
+
+			got, err := ft.Run(createToolContext(t), map[string]any{})
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got["forecast"]); diff != "" {
+				t.Errorf("Run() result mismatch (-want +got):\n%s", diff)
+			}
+			// The provenance stays at the top level.
+			if _, ok := got[functiontool.ProvenanceKey]; !ok {
+				t.Errorf("Run() = %v, want a top-level %q field", got, functiontool.ProvenanceKey)
+			}
+		})
+	}
+
+	if _, err := functiontool.New(functiontool.Config{Name: "f", ResultKey: functiontool.ProvenanceKey}, func(tool.Context, Args) (string, error) {
+		return "", nil
+	}); err == nil {
+		t.Error("New() with a reserved result key succeeded, want an error")
+	}
+}
+
 func TestFunctionTool_MapInput(t *testing.T) {
 	type Output struct {
 		Sum int `json:"sum"`
@@ -642,6 +893,32 @@ func TestToolConfirmation(t *testing.T) {
 				genai.NewContentFromFunctionResponse("test_tool", map[string]any{"result": "ok"}, "user"),
 			},
 		},
+		{
+			name: "Confirmation of versioned tool is confirmed",
+			toolConfig: functiontool.Config{
+				Name:                "test_tool",
+				RequireConfirmation: true,
+				SchemaVersion:       2,
+			},
+			args:                    map[string]any{"Num": 1},
+			confirmFunctionResponse: &genai.FunctionResponse{Name: toolconfirmation.FunctionCallName, Response: map[string]any{"confirmed": true}},
+			want: []*genai.Content{
+				genai.NewContentFromFunctionCall("test_tool", map[string]any{"Num": 1}, "model"),
+				genai.NewContentFromFunctionCall(toolconfirmation.FunctionCallName, map[string]any{
+					"originalFunctionCall": &genai.FunctionCall{
+						Args: map[string]any{"Num": 1, functiontool.SchemaVersionKey: 2},
+						Name: "test_tool",
+					},
+					"toolConfirmation": toolconfirmation.ToolConfirmation{
+						Hint: "Please approve or reject the tool call test_tool() by responding with a FunctionResponse with an expected ToolConfirmation payload.",
+					},
+				}, "model"),
+				genai.NewContentFromFunctionResponse("test_tool", map[string]any{
+					"error": errors.New("error tool \"test_tool\" requires confirmation, please approve or reject"),
+				}, "user"),
+				genai.NewContentFromFunctionResponse("test_tool", map[string]any{"result": "ok"}, "user"),
+			},
+		},
 		{
 			name: "Confirmation Required and is rejected",
 			toolConfig: functiontool.Config{
@@ -1093,3 +1370,331 @@ func TestFunctionTool_PanicRecovery(t *testing.T) {
 		}
 	}
 }
+
+func TestFunctionTool_PanicWithError(t *testing.T) {
+	panicTool, err := functiontool.New(functiontool.Config{
+		Name: "panic_tool",
+	}, func(tool.Context, map[string]any) (string, error) {
+		panic(context.Canceled)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = panicTool.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want it to wrap %v", err, context.Canceled)
+	}
+}
+
+func TestFunctionTool_Provenance(t *testing.T) {
+	type Args struct {
+		City string `json:"city"`
+	}
+	handler := func(ctx tool.Context, input Args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	}
+
+	tests := []struct {
+		name string
+		cfg  functiontool.Config
+		want map[string]any
+	}{
+		{
+			name: "enabled",
+			cfg:  functiontool.Config{Name: "get_weather", Version: "1.2.0", Provenance: true},
+			want: map[string]any{"tool": "get_weather", "version": "1.2.0"},
+		},
+		{
+			name: "without version",
+			cfg:  functiontool.Config{Name: "get_weather", Provenance: true},
+			want: map[string]any{"tool": "get_weather"},
+		},
+		{
+			name: "disabled by default",
+			cfg:  functiontool.Config{Name: "get_weather", Version: "1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weatherTool, err := functiontool.New(tt.cfg, handler)
+			if err != nil {
+				t.Fatalf("functiontool.New() failed: %v", err)
+			}
+			result, err := weatherTool.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{"city": "Paris"})
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if result["weather"] != "sunny" {
+				t.Errorf("result[%q] = %v, want %q", "weather", result["weather"], "sunny")
+			}
+
+			got, ok := result[functiontool.ProvenanceKey].(map[string]any)
+			if tt.want == nil {
+				if ok {
+					t.Errorf("result has provenance %v, want none", got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("result[%q] = %v, want a map", functiontool.ProvenanceKey, result[functiontool.ProvenanceKey])
+			}
+			timestamp, _ := got["timestamp"].(string)
+			if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+				t.Errorf("provenance timestamp %q is not RFC 3339: %v", got["timestamp"], err)
+			}
+			delete(got, "timestamp")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("provenance mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// The results match the output schema, which declares the provenance.
+	type Weather struct {
+		Sky string `json:"sky"`
+	}
+	structTool, err := functiontool.New(functiontool.Config{Name: "get_weather", Provenance: true}, func(tool.Context, Args) (Weather, error) {
+		return Weather{Sky: "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+	ft := structTool.(toolinternal.FunctionTool)
+	result, err := ft.Run(createToolContext(t), map[string]any{"city": "Paris"})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	resolved, err := ft.Declaration().ResponseJsonSchema.(*jsonschema.Schema).Resolve(nil)
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if err := resolved.Validate(result); err != nil {
+		t.Errorf("result %v does not match the output schema: %v", result, err)
+	}
+}
+
+func TestFunctionTool_SchemaVersionMigration(t *testing.T) {
+	// Version 2 renamed the "count" argument to "num".
+	type Args struct {
+		Num int `json:"num"`
+	}
+	handler := func(ctx tool.Context, input Args) (map[string]any, error) {
+		return map[string]any{"num": input.Num}, nil
+	}
+	migrate := func(from int, args map[string]any) (map[string]any, error) {
+		if from != 1 {
+			return nil, fmt.Errorf("unknown version %d", from)
+		}
+		return map[string]any{"num": args["count"]}, nil
+	}
+
+	tests := []struct {
+		name    string
+		migrate func(int, map[string]any) (map[string]any, error)
+		args    map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "current call",
+			migrate: migrate,
+			args:    map[string]any{"num": 3},
+			want:    map[string]any{"num": float64(3)},
+		},
+		{
+			name:    "call against the current version",
+			migrate: migrate,
+			args:    map[string]any{"num": 3, functiontool.SchemaVersionKey: float64(2)},
+			want:    map[string]any{"num": float64(3)},
+		},
+		{
+			name:    "call against an old version",
+			migrate: migrate,
+			args:    map[string]any{"count": 3, functiontool.SchemaVersionKey: float64(1)},
+			want:    map[string]any{"num": float64(3)},
+		},
+		{
+			name:    "failed migration",
+			migrate: migrate,
+			args:    map[string]any{"count": 3, functiontool.SchemaVersionKey: 0},
+			wantErr: true,
+		},
+		{
+			name:    "no migration",
+			args:    map[string]any{"count": 3, functiontool.SchemaVersionKey: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countTool, err := functiontool.New(functiontool.Config{
+				Name:          "count",
+				SchemaVersion: 2,
+				MigrateArgs:   tt.migrate,
+			}, handler)
+			if err != nil {
+				t.Fatalf("functiontool.New() failed: %v", err)
+			}
+			got, err := countTool.(toolinternal.FunctionTool).Run(createToolContext(t), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionTool_HandlerError(t *testing.T) {
+	type lookupArgs struct {
+		ID string `json:"id"`
+	}
+	lookup, err := functiontool.New(functiontool.Config{
+		Name: "lookup",
+	}, func(_ tool.Context, args lookupArgs) (map[string]string, error) {
+		if args.ID != "42" {
+			return nil, fmt.Errorf("no order %q", args.ID)
+		}
+		return map[string]string{"status": "shipped"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("lookup", map[string]any{"id": "7"}, genai.RoleModel),
+		genai.NewContentFromFunctionCall("lookup", map[string]any{"id": "42"}, genai.RoleModel),
+		genai.NewContentFromText("Your order has shipped.", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "orders_agent",
+		Model: mockModel,
+		Tools: []tool.Tool{lookup},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts, err := testutil.CollectTextParts(testutil.NewTestAgentRunner(t, a).Run(t, "session", "where is my order?"))
+	if err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Your order has shipped."}, texts); diff != "" {
+		t.Errorf("texts mismatch (-want +got):\n%s", diff)
+	}
+
+	// The model got the error, then the result of its retry.
+	var got []map[string]any
+	for _, req := range mockModel.Requests[1:] {
+		last := req.Contents[len(req.Contents)-1]
+		got = append(got, last.Parts[0].FunctionResponse.Response)
+	}
+	want := []map[string]any{
+		{"error": `no order "7"`},
+		{"status": "shipped"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFunctionTool_BlobResult(t *testing.T) {
+	image := make([]byte, 1<<20)
+	render, err := functiontool.New(functiontool.Config{
+		Name: "render",
+	}, func(tool.Context, map[string]any) (map[string]any, error) {
+		result := tool.NewResult(tool.BlobPart(image, "image/png"))
+		result["width"] = 640
+		return result, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := render.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if got["width"] != float64(640) {
+		t.Errorf("got width %v, want 640", got["width"])
+	}
+	parts, ok := got[tool.ResultPartsKey].([]tool.ResultPart)
+	if !ok || len(parts) != 1 || parts[0].InlineData == nil {
+		t.Fatalf("got parts %v, want the image part", got[tool.ResultPartsKey])
+	}
+	if data := parts[0].InlineData.Data; len(data) != len(image) || &data[0] != &image[0] {
+		t.Error("got a copy of the image, want the image returned by the handler")
+	}
+
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("render", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "render_agent",
+		Model: mockModel,
+		Tools: []tool.Tool{render},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "draw")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+	contents := mockModel.Requests[1].Contents
+	resp := contents[len(contents)-1].Parts[0].FunctionResponse
+	if len(resp.Parts) != 1 || resp.Parts[0].InlineData == nil || len(resp.Parts[0].InlineData.Data) != len(image) {
+		t.Errorf("got function response parts %v, want the image", resp.Parts)
+	}
+	if diff := cmp.Diff(map[string]any{"width": float64(640)}, resp.Response); diff != "" {
+		t.Errorf("function response mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFunctionTool_ErrorFormatter(t *testing.T) {
+	handler := func(ctx tool.Context, _ SimpleArgs) (string, error) {
+		return "", errors.New("dial tcp 10.0.0.7:443: connection refused")
+	}
+	tests := []struct {
+		name      string
+		formatter func(error) string
+		err       error
+		want      string
+	}{
+		{
+			name: "default",
+			err:  errors.New("dial tcp 10.0.0.7:443: connection refused"),
+			want: "dial tcp 10.0.0.7:443: connection refused",
+		},
+		{
+			name: "default strips stack traces",
+			err:  errors.New("panic in tool \"search\": boom\nstack: goroutine 7 [running]:\nmain.search()"),
+			want: "panic in tool \"search\": boom",
+		},
+		{
+			name: "custom",
+			formatter: func(err error) string {
+				return "The search service is temporarily unavailable; try rephrasing or ask the user."
+			},
+			err:  errors.New("dial tcp 10.0.0.7:443: connection refused"),
+			want: "The search service is temporarily unavailable; try rephrasing or ask the user.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchTool, err := functiontool.New(functiontool.Config{
+				Name:           "search",
+				ErrorFormatter: tt.formatter,
+			}, handler)
+			if err != nil {
+				t.Fatalf("functiontool.New() failed: %v", err)
+			}
+			formatter, ok := searchTool.(toolinternal.ErrorFormatter)
+			if !ok {
+				t.Fatal("function tool does not implement toolinternal.ErrorFormatter")
+			}
+			if got := formatter.FormatError(tt.err); got != tt.want {
+				t.Errorf("FormatError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
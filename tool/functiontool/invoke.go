@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"fmt"
+	"reflect"
+
+	"google.golang.org/adk/tool"
+)
+
+// Invoke calls the handler of t, a tool created by this package, with typed
+// arguments and returns its typed results, without the model nor the JSON
+// conversion of the arguments and results, e.g. to test the handler or to
+// call a tool from the code of another tool.
+//
+// As with Run, the handler gets the secrets of the tool, the secret values
+// it read are replaced with "[REDACTED]" in a copy of its results and in its
+// error, and its panics are returned as errors. The confirmation of the call is not requested, the
+// caller being the code rather than the model, and the callbacks of the
+// agent do not apply as the call is not part of its tool-calling loop.
+//
+// It returns an error if t does not take TArgs and return TResults, as is
+// the case for the tools wrapping a function tool, e.g. by partialtool.
+func Invoke[TArgs, TResults any](ctx tool.Context, t tool.Tool, args TArgs) (results TResults, err error) {
+	f, ok := t.(*functionTool[TArgs, TResults])
+	if !ok {
+		return results, fmt.Errorf("tool %q is not a function tool taking %v and returning %v", t.Name(), reflect.TypeFor[TArgs](), reflect.TypeFor[TResults]())
+	}
+	defer f.recoverPanic(&err)
+	results, secrets, err := f.call(ctx, args)
+	if secrets != nil {
+		results = redactResults(secrets, results)
+	}
+	return results, err
+}
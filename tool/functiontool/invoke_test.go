@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestInvoke(t *testing.T) {
+	type Args struct {
+		A, B int
+	}
+	type Results struct {
+		Sum int
+	}
+	errNegative := errors.New("negative numbers are not supported")
+	sum, err := functiontool.New(functiontool.Config{Name: "sum", RequireConfirmation: true}, func(_ tool.Context, args Args) (Results, error) {
+		if args.A < 0 || args.B < 0 {
+			return Results{}, errNegative
+		}
+		if args.A == 0 && args.B == 0 {
+			panic("zero")
+		}
+		return Results{Sum: args.A + args.B}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := createToolContext(t)
+
+	got, err := functiontool.Invoke[Args, Results](ctx, sum, Args{A: 1, B: 2})
+	if err != nil {
+		t.Fatalf("Invoke() failed: %v", err)
+	}
+	if got.Sum != 3 {
+		t.Errorf("Invoke() = %+v, want a sum of 3", got)
+	}
+	if ctx.Actions().SkipSummarization {
+		t.Error("Invoke() requested a confirmation, want a direct call")
+	}
+
+	if _, err := functiontool.Invoke[Args, Results](ctx, sum, Args{A: -1}); !errors.Is(err, errNegative) {
+		t.Errorf("Invoke() error = %v, want %v", err, errNegative)
+	}
+	if _, err := functiontool.Invoke[Args, Results](ctx, sum, Args{}); err == nil || !strings.Contains(err.Error(), `panic in tool "sum": zero`) {
+		t.Errorf("Invoke() error = %v, want the panic as error", err)
+	}
+	if _, err := functiontool.Invoke[Args, map[string]any](ctx, sum, Args{}); err == nil || !strings.Contains(err.Error(), `tool "sum" is not a function tool taking`) {
+		t.Errorf("Invoke() error = %v, want a type mismatch error", err)
+	}
+}
+
+func TestInvoke_RedactsSecrets(t *testing.T) {
+	type Request struct {
+		URL     string
+		Headers map[string]string
+	}
+	type Results struct {
+		Status  int
+		Request *Request
+		Log     []string
+		Detail  any
+	}
+	log := []string{"connecting"}
+	call, err := functiontool.New(functiontool.Config{
+		Name:           "call_api",
+		SecretProvider: mapSecretProvider{"api_key": "s3cr3t"},
+		Secrets:        []string{"api_key"},
+	}, func(ctx tool.Context, _ struct{}) (Results, error) {
+		key, err := functiontool.Secret(ctx, "api_key")
+		if err != nil {
+			return Results{}, err
+		}
+		log[0] = "connecting with " + key
+		return Results{
+			Status:  200,
+			Request: &Request{URL: "https://api.example.com?key=" + key, Headers: map[string]string{"Authorization": "Bearer " + key}},
+			Log:     log,
+			Detail:  map[string]any{"key": key},
+		}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := functiontool.Invoke[struct{}, Results](createToolContext(t), call, struct{}{})
+	if err != nil {
+		t.Fatalf("Invoke() failed: %v", err)
+	}
+	want := Results{
+		Status:  200,
+		Request: &Request{URL: "https://api.example.com?key=[REDACTED]", Headers: map[string]string{"Authorization": "Bearer [REDACTED]"}},
+		Log:     []string{"connecting with [REDACTED]"},
+		Detail:  map[string]any{"key": "[REDACTED]"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Invoke() mismatch (-want +got):\n%s", diff)
+	}
+	if log[0] != "connecting with s3cr3t" {
+		t.Errorf("Invoke() modified the values of the handler, got %q", log[0])
+	}
+}
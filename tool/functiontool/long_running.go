@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/tool"
+)
+
+// CompletedKey is the key of the field added to the results of the tools
+// created by NewLongRunning. It is false in the intermediate results and
+// true in the final one.
+const CompletedKey = "_completed"
+
+// StreamFunc represents a Go function reporting the progress of a
+// long-running operation. It yields the status of the operation as it
+// goes, the last value being the final result.
+type StreamFunc[TArgs, TResults any] func(tool.Context, TArgs) iter.Seq2[TResults, error]
+
+// NewLongRunning creates a long-running tool from a handler streaming its
+// results, e.g. the status of a deployment taking minutes, without blocking
+// the turn of the agent.
+//
+// The first value yielded by the handler, e.g. a pending status, is
+// returned to the model as the result of the call, and the turn of the
+// agent ends, as for the other long-running tools. The handler then keeps
+// running in the background: the next values are passed to
+// Config.OnUpdate as function responses, followed by the final result with
+// CompletedKey set. The application sends them back to the agent, e.g. in
+// the content of its next run, so that the model sees the updates.
+//
+// The context of the handler is cancelled with the one of the call until
+// the first value is yielded, and when OnUpdate returns false afterwards.
+// The changes the handler makes to the state after the first value are not
+// saved.
+func NewLongRunning[TArgs, TResults any](cfg Config, handler StreamFunc[TArgs, TResults]) (tool.Tool, error) {
+	if cfg.OnUpdate == nil {
+		return nil, fmt.Errorf("an OnUpdate function is required for the long-running tool %q", cfg.Name)
+	}
+	cfg.IsLongRunning = true
+	var ft *functionTool[TArgs, TResults]
+	t, err := New(cfg, func(ctx tool.Context, args TArgs) (TResults, error) {
+		return ft.start(ctx, handler, args)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ft = t.(*functionTool[TArgs, TResults])
+	ft.streaming = true
+	return ft, nil
+}
+
+// firstResult is the first value yielded by the handler of a long-running
+// tool, or the error ending the operation before it.
+type firstResult[TResults any] struct {
+	value TResults
+	err   error
+}
+
+// start runs the handler in the background and returns its first result.
+func (f *functionTool[TArgs, TResults]) start(ctx tool.Context, handler StreamFunc[TArgs, TResults], args TArgs) (TResults, error) {
+	var secrets *secretScope
+	if sc, ok := ctx.(*secretContext); ok {
+		secrets = sc.scope
+	}
+	opCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	detached := &detachedContext{Context: ctx, ctx: opCtx}
+	first := make(chan firstResult[TResults], 1)
+	go func() {
+		defer cancel()
+		received, err := f.runOperation(detached, handler, args, secrets, first)
+		switch {
+		case err == nil || (received && opCtx.Err() != nil):
+			// Stopped by OnUpdate, or cancelled with the call.
+		case !received:
+			first <- firstResult[TResults]{err: err}
+		default:
+			if secrets != nil {
+				err = secrets.redactError(err)
+			}
+			f.update(detached, map[string]any{"error": f.FormatError(err), CompletedKey: true})
+		}
+	}()
+
+	select {
+	case r := <-first:
+		return r.value, r.err
+	case <-ctx.Done():
+		cancel()
+		var zero TResults
+		return zero, ctx.Err()
+	}
+}
+
+// runOperation runs the handler, sending its first result to first, and
+// the next ones and the final result to Config.OnUpdate. It returns the
+// error ending the operation, and whether the first result was sent.
+func (f *functionTool[TArgs, TResults]) runOperation(ctx tool.Context, handler StreamFunc[TArgs, TResults], args TArgs, secrets *secretScope, first chan<- firstResult[TResults]) (received bool, err error) {
+	defer f.recoverPanic(&err)
+
+	var last map[string]any
+	for result, err := range handler(ctx, args) {
+		if err != nil {
+			return received, err
+		}
+		last, err = f.buildResult(result, secrets)
+		if err != nil {
+			return received, err
+		}
+		if !received {
+			first <- firstResult[TResults]{value: result}
+			received = true
+			continue
+		}
+		update := maps.Clone(last)
+		if update == nil {
+			update = make(map[string]any)
+		}
+		update[CompletedKey] = false
+		if ctx.Err() != nil || !f.update(ctx, update) {
+			return received, nil
+		}
+	}
+	if !received {
+		return false, errors.New("the operation ended without a result")
+	}
+	if ctx.Err() != nil {
+		return received, nil
+	}
+	if last == nil {
+		last = make(map[string]any)
+	}
+	last[CompletedKey] = true
+	f.update(ctx, last)
+	return received, nil
+}
+
+// update passes a result of the call of ctx to Config.OnUpdate.
+func (f *functionTool[TArgs, TResults]) update(ctx tool.Context, result map[string]any) bool {
+	return f.cfg.OnUpdate(ctx, &genai.FunctionResponse{
+		ID:       ctx.FunctionCallID(),
+		Name:     f.Name(),
+		Response: result,
+	})
+}
+
+// detachedContext is the tool.Context of a long-running operation, which
+// outlives the call of the tool: its cancellation and values come from ctx.
+type detachedContext struct {
+	tool.Context
+	ctx context.Context
+}
+
+func (c *detachedContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *detachedContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *detachedContext) Err() error                  { return c.ctx.Err() }
+func (c *detachedContext) Value(key any) any           { return c.ctx.Value(key) }
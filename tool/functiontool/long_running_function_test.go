@@ -15,15 +15,20 @@
 package functiontool_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"iter"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/jsonschema-go/jsonschema"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent/llmagent"
+	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/tool"
@@ -323,3 +328,194 @@ func TestLongRunningToolIDsAreSet(t *testing.T) {
 			functionCallEvent.LLMResponse.Content.Parts[0].FunctionCall.ID)
 	}
 }
+
+func TestNewLongRunning(t *testing.T) {
+	type DeployArgs struct {
+		Service string `json:"service"`
+	}
+	type DeployStatus struct {
+		Status string `json:"status"`
+	}
+	proceed := make(chan struct{})
+	updates := make(chan *genai.FunctionResponse, 10)
+	deploy, err := functiontool.NewLongRunning(functiontool.Config{
+		Name:        "deploy",
+		Description: "deploys a service",
+		OnUpdate: func(_ tool.Context, resp *genai.FunctionResponse) bool {
+			updates <- resp
+			return true
+		},
+	}, func(ctx tool.Context, args DeployArgs) iter.Seq2[DeployStatus, error] {
+		return func(yield func(DeployStatus, error) bool) {
+			if !yield(DeployStatus{Status: "building"}, nil) {
+				return
+			}
+			<-proceed
+			for _, status := range []string{"rolling out", "deployed " + args.Service} {
+				if !yield(DeployStatus{Status: status}, nil) {
+					return
+				}
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewLongRunning() failed: %v", err)
+	}
+	if !deploy.IsLongRunning() {
+		t.Error("IsLongRunning() = false, want true")
+	}
+	schema := deploy.(toolinternal.FunctionTool).Declaration().ResponseJsonSchema.(*jsonschema.Schema)
+	if schema.Properties[functiontool.CompletedKey] == nil {
+		t.Errorf("ResponseJsonSchema does not declare the %q field", functiontool.CompletedKey)
+	}
+
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("deploy", map[string]any{"service": "api"}, "model"),
+		genai.NewContentFromText("deploying the api", "model"),
+		genai.NewContentFromText("api is deployed", "model"),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "deploy_agent",
+		Model: mockModel,
+		Tools: []tool.Tool{deploy},
+	})
+	if err != nil {
+		t.Fatalf("failed to create llm agent: %v", err)
+	}
+	runner := testutil.NewTestAgentRunner(t, a)
+
+	// The turn ends with the first result, while the operation goes on.
+	parts, err := testutil.CollectParts(runner.Run(t, "test_session", "deploy the api"))
+	if err != nil {
+		t.Fatalf("failed to collect events: %v", err)
+	}
+	wantParts := []*genai.Part{
+		genai.NewPartFromFunctionCall("deploy", map[string]any{"service": "api"}),
+		genai.NewPartFromFunctionResponse("deploy", map[string]any{"status": "building", functiontool.CompletedKey: false}),
+		genai.NewPartFromText("deploying the api"),
+	}
+	ignoreIDs := cmp.Options{cmpopts.IgnoreFields(genai.FunctionCall{}, "ID"), cmpopts.IgnoreFields(genai.FunctionResponse{}, "ID")}
+	if diff := cmp.Diff(wantParts, parts, ignoreIDs); diff != "" {
+		t.Errorf("event parts mismatch (-want +got):\n%s", diff)
+	}
+	callID := parts[0].FunctionCall.ID
+
+	// The next results are passed to OnUpdate, the last one twice, marked
+	// as completed.
+	close(proceed)
+	var got []*genai.FunctionResponse
+	for range 3 {
+		got = append(got, <-updates)
+	}
+	want := []*genai.FunctionResponse{
+		{ID: callID, Name: "deploy", Response: map[string]any{"status": "rolling out", functiontool.CompletedKey: false}},
+		{ID: callID, Name: "deploy", Response: map[string]any{"status": "deployed api", functiontool.CompletedKey: false}},
+		{ID: callID, Name: "deploy", Response: map[string]any{"status": "deployed api", functiontool.CompletedKey: true}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("updates mismatch (-want +got):\n%s", diff)
+	}
+
+	// Sent back to the agent, the final result reaches the model.
+	final := &genai.Content{Role: "user", Parts: []*genai.Part{{FunctionResponse: got[2]}}}
+	if _, err := testutil.CollectParts(runner.RunContent(t, "test_session", final)); err != nil {
+		t.Fatalf("failed to collect events: %v", err)
+	}
+	lastRequest := mockModel.Requests[len(mockModel.Requests)-1]
+	lastContent := lastRequest.Contents[len(lastRequest.Contents)-1]
+	if diff := cmp.Diff(final, lastContent, ignoreIDs); diff != "" {
+		t.Errorf("last content sent to the model mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewLongRunning_Errors(t *testing.T) {
+	if _, err := functiontool.NewLongRunning(functiontool.Config{Name: "no_updates"}, func(tool.Context, struct{}) iter.Seq2[string, error] {
+		return func(func(string, error) bool) {}
+	}); err == nil {
+		t.Error("NewLongRunning() without OnUpdate succeeded, want an error")
+	}
+
+	updates := make(chan *genai.FunctionResponse, 1)
+	failing, err := functiontool.NewLongRunning(functiontool.Config{
+		Name: "failing",
+		OnUpdate: func(_ tool.Context, resp *genai.FunctionResponse) bool {
+			updates <- resp
+			return true
+		},
+	}, func(ctx tool.Context, _ struct{}) iter.Seq2[string, error] {
+		return func(yield func(string, error) bool) {
+			if yield("started", nil) {
+				yield("", errors.New("deployment failed"))
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewLongRunning() failed: %v", err)
+	}
+	result, err := failing.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"result": "started", functiontool.CompletedKey: false}, result); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	wantUpdate := map[string]any{"error": "deployment failed", functiontool.CompletedKey: true}
+	if diff := cmp.Diff(wantUpdate, (<-updates).Response); diff != "" {
+		t.Errorf("update mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewLongRunning_Cancellation(t *testing.T) {
+	newWaitTool := func(onUpdate func(tool.Context, *genai.FunctionResponse) bool, stopped chan<- struct{}) tool.Tool {
+		t.Helper()
+		waitTool, err := functiontool.NewLongRunning(functiontool.Config{
+			Name:        "wait",
+			Description: "waits forever",
+			OnUpdate:    onUpdate,
+		}, func(ctx tool.Context, _ struct{}) iter.Seq2[string, error] {
+			return func(yield func(string, error) bool) {
+				defer close(stopped)
+				for ctx.Err() == nil {
+					if !yield("waiting", nil) {
+						return
+					}
+				}
+			}
+		})
+		if err != nil {
+			t.Fatalf("NewLongRunning() failed: %v", err)
+		}
+		return waitTool
+	}
+
+	t.Run("by the call before the first result", func(t *testing.T) {
+		stopped := make(chan struct{})
+		waitTool := newWaitTool(func(tool.Context, *genai.FunctionResponse) bool {
+			t.Error("OnUpdate called after the cancellation of the call")
+			return false
+		}, stopped)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		toolCtx := toolinternal.NewToolContext(icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{}), "", nil, nil)
+		if _, err := waitTool.(toolinternal.FunctionTool).Run(toolCtx, map[string]any{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+		}
+		<-stopped
+	})
+
+	t.Run("by OnUpdate", func(t *testing.T) {
+		stopped := make(chan struct{})
+		calls := 0
+		waitTool := newWaitTool(func(tool.Context, *genai.FunctionResponse) bool {
+			calls++
+			return calls < 3
+		}, stopped)
+		if _, err := waitTool.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{}); err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		<-stopped
+		if calls != 3 {
+			t.Errorf("OnUpdate called %d times, want 3", calls)
+		}
+	})
+}
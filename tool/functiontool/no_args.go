@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"errors"
+
+	"google.golang.org/adk/tool"
+)
+
+// NoArgsFunc represents a Go function taking no arguments that can be
+// wrapped in a tool, e.g. returning the current user or the time.
+type NoArgsFunc[TResults any] func(tool.Context) (TResults, error)
+
+// NewNoArgs creates a tool from a handler taking no arguments. The function
+// declaration of the tool has no parameters, and the arguments of the calls
+// are ignored. Config.InputSchema and Config.RequireConfirmationProvider
+// must not be set.
+func NewNoArgs[TResults any](cfg Config, handler NoArgsFunc[TResults]) (tool.Tool, error) {
+	if cfg.InputSchema != nil {
+		return nil, errors.New("an input schema cannot be set for a tool without arguments")
+	}
+	if cfg.RequireConfirmationProvider != nil {
+		return nil, errors.New("a confirmation provider cannot be set for a tool without arguments, use RequireConfirmation")
+	}
+	t, err := New(cfg, func(ctx tool.Context, _ struct{}) (TResults, error) {
+		return handler(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ft := t.(*functionTool[struct{}, TResults])
+	ft.noArgs = true
+	return ft, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestNewNoArgs(t *testing.T) {
+	type timeResult struct {
+		Time string `json:"time"`
+	}
+	currentTime, err := functiontool.NewNoArgs(functiontool.Config{
+		Name:        "current_time",
+		Description: "returns the current time",
+	}, func(tool.Context) (timeResult, error) {
+		return timeResult{Time: "12:00"}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewNoArgs() failed: %v", err)
+	}
+	ft := currentTime.(toolinternal.FunctionTool)
+
+	decl := ft.Declaration()
+	if decl.ParametersJsonSchema != nil || decl.Parameters != nil {
+		t.Errorf("got parameters %v, want none", decl.ParametersJsonSchema)
+	}
+	if decl.ResponseJsonSchema == nil {
+		t.Error("got no response schema, want the schema of the result")
+	}
+
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+	for _, args := range []any{nil, map[string]any{}, map[string]any{"timezone": "UTC"}} {
+		got, err := ft.Run(ctx, args)
+		if err != nil {
+			t.Fatalf("Run(%v) failed: %v", args, err)
+		}
+		if diff := cmp.Diff(map[string]any{"time": "12:00"}, got); diff != "" {
+			t.Errorf("Run(%v) mismatch (-want +got):\n%s", args, diff)
+		}
+	}
+}
+
+func TestNewNoArgs_InvalidConfig(t *testing.T) {
+	handler := func(tool.Context) (string, error) { return "", nil }
+	for _, cfg := range []functiontool.Config{
+		{Name: "f", InputSchema: &jsonschema.Schema{Type: "object"}},
+		{Name: "f", RequireConfirmationProvider: func(struct{}) bool { return true }},
+	} {
+		if _, err := functiontool.NewNoArgs(cfg, handler); err == nil {
+			t.Errorf("NewNoArgs(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
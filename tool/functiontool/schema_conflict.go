@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// SchemaConflictPolicy decides what New does when Config.InputSchema
+// conflicts with the schema inferred from the arguments type of the
+// handler, e.g. after a field was added to the type but not to the
+// hand-written schema.
+//
+// The schemas conflict when a property has incompatible types, when a
+// property of the override is not a field of the arguments type, or when a
+// property required by one of the schemas is missing from the other.
+type SchemaConflictPolicy int
+
+const (
+	// TrustOverride uses Config.InputSchema even if it conflicts with the
+	// inferred schema. The conflicts are only reported in the errors of the
+	// calls whose arguments fail to convert to the arguments type. It is
+	// the default.
+	TrustOverride SchemaConflictPolicy = iota
+	// PreferInferred uses the inferred schema instead of a conflicting
+	// Config.InputSchema.
+	PreferInferred
+	// ErrorOnConflict makes New fail when Config.InputSchema conflicts
+	// with the inferred schema, describing the conflicts.
+	ErrorOnConflict
+)
+
+// resolvedInputSchema resolves the input schema of a tool, comparing
+// Config.InputSchema with the schema inferred from TArgs as
+// Config.SchemaConflictPolicy requires. With TrustOverride, it also returns
+// the conflicts, if any, for the errors of the calls failing to convert
+// their arguments to TArgs.
+func resolvedInputSchema[TArgs any](cfg Config) (*jsonschema.Resolved, []string, error) {
+	if cfg.InputSchema == nil {
+		resolved, err := resolvedSchema[TArgs](nil)
+		return resolved, nil, err
+	}
+	inferred, err := inferSchema[TArgs]()
+	if err != nil {
+		if cfg.SchemaConflictPolicy == TrustOverride {
+			// Nothing to compare the trusted override with.
+			resolved, err := cfg.InputSchema.Resolve(nil)
+			return resolved, nil, err
+		}
+		return nil, nil, err
+	}
+	conflicts := schemaConflicts(cfg.InputSchema, inferred.schema, "")
+	switch {
+	case len(conflicts) == 0:
+		resolved, err := cfg.InputSchema.Resolve(nil)
+		return resolved, nil, err
+	case cfg.SchemaConflictPolicy == TrustOverride:
+		resolved, err := cfg.InputSchema.Resolve(nil)
+		return resolved, conflicts, err
+	case cfg.SchemaConflictPolicy == PreferInferred:
+		return inferred.resolved, nil, nil
+	}
+	return nil, nil, fmt.Errorf("the input schema conflicts with the arguments type %v: %s", reflect.TypeFor[TArgs](), strings.Join(conflicts, "; "))
+}
+
+// schemaConflicts returns the descriptions of the conflicts between the
+// override schema and the inferred one, at the given property path.
+func schemaConflicts(override, inferred *jsonschema.Schema, path string) []string {
+	if override == nil || inferred == nil {
+		return nil
+	}
+	at := ""
+	if path != "" {
+		at = " of property " + path
+	}
+	var conflicts []string
+	if types, want := schemaTypes(override), schemaTypes(inferred); len(types) > 0 && len(want) > 0 {
+		for _, typ := range types {
+			if !slices.Contains(want, typ) && (typ != "integer" || !slices.Contains(want, "number")) {
+				conflicts = append(conflicts, fmt.Sprintf("type %q%s is not one of %q", typ, at, want))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		// The properties of incompatible types are not compared.
+		return conflicts
+	}
+
+	// Schemas without properties, e.g. of maps, accept any property.
+	if len(inferred.Properties) > 0 {
+		for _, name := range sortedKeys(override.Properties) {
+			if _, ok := inferred.Properties[name]; !ok {
+				conflicts = append(conflicts, fmt.Sprintf("property %s is not a field", join(path, name)))
+			}
+		}
+		for _, name := range override.Required {
+			_, declared := override.Properties[name]
+			if _, ok := inferred.Properties[name]; !ok && !declared {
+				conflicts = append(conflicts, fmt.Sprintf("required property %s is not a field", join(path, name)))
+			}
+		}
+	}
+	if len(override.Properties) > 0 || len(override.Required) > 0 {
+		for _, name := range inferred.Required {
+			if _, ok := override.Properties[name]; !ok {
+				conflicts = append(conflicts, fmt.Sprintf("required field %s is missing", join(path, name)))
+			}
+		}
+	}
+	for _, name := range sortedKeys(override.Properties) {
+		conflicts = append(conflicts, schemaConflicts(override.Properties[name], inferred.Properties[name], join(path, name))...)
+	}
+	return append(conflicts, schemaConflicts(override.Items, inferred.Items, path+"[]")...)
+}
+
+func schemaTypes(s *jsonschema.Schema) []string {
+	if s.Type != "" {
+		return []string{strings.ToLower(s.Type)}
+	}
+	types := make([]string, len(s.Types))
+	for i, t := range s.Types {
+		types[i] = strings.ToLower(t)
+	}
+	return types
+}
+
+func sortedKeys(m map[string]*jsonschema.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestSchemaConflictPolicy(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type args struct {
+		Name    string  `json:"name"`
+		Count   float64 `json:"count,omitempty"`
+		Address address `json:"address"`
+	}
+	handler := func(tool.Context, args) (string, error) { return "", nil }
+
+	compatible := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name":  {Type: "string", Description: "the name"},
+			"count": {Type: "integer"},
+			"address": {
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"city": {Type: "string"}},
+			},
+		},
+		Required: []string{"name", "address"},
+	}
+	conflicting := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name":  {Type: "integer"},
+			"extra": {Type: "string"},
+		},
+		Required: []string{"name"},
+	}
+
+	tests := []struct {
+		name      string
+		schema    *jsonschema.Schema
+		policy    functiontool.SchemaConflictPolicy
+		wantErr   []string
+		wantExtra bool
+	}{
+		{
+			name:      "trust override",
+			schema:    conflicting,
+			policy:    functiontool.TrustOverride,
+			wantExtra: true,
+		},
+		{
+			name:   "compatible override",
+			schema: compatible,
+			policy: functiontool.ErrorOnConflict,
+		},
+		{
+			name:   "error on conflict",
+			schema: conflicting,
+			policy: functiontool.ErrorOnConflict,
+			wantErr: []string{
+				`type "integer" of property name is not one of ["string"]`,
+				"property extra is not a field",
+				"required field address is missing",
+			},
+		},
+		{
+			name: "nested conflict",
+			schema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {Type: "string"},
+					"address": {
+						Type:       "object",
+						Properties: map[string]*jsonschema.Schema{"city": {Type: "boolean"}},
+					},
+				},
+			},
+			policy:  functiontool.ErrorOnConflict,
+			wantErr: []string{`type "boolean" of property address.city is not one of ["string"]`},
+		},
+		{
+			name:   "prefer inferred",
+			schema: conflicting,
+			policy: functiontool.PreferInferred,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := functiontool.New(functiontool.Config{
+				Name:                 "greet",
+				InputSchema:          tc.schema,
+				SchemaConflictPolicy: tc.policy,
+			}, handler)
+			if len(tc.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("New() succeeded, want an error")
+				}
+				for _, want := range tc.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("New() error = %q, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			schema := ft.(toolinternal.FunctionTool).Declaration().ParametersJsonSchema.(*jsonschema.Schema)
+			if _, gotExtra := schema.Properties["extra"]; gotExtra != tc.wantExtra {
+				t.Errorf("got property extra in the schema = %v, want %v", gotExtra, tc.wantExtra)
+			}
+		})
+	}
+}
+
+func TestSchemaConflictPolicy_TrustOverrideRunError(t *testing.T) {
+	type args struct {
+		Count int `json:"count"`
+	}
+	ft, err := functiontool.New(functiontool.Config{
+		Name: "count",
+		InputSchema: &jsonschema.Schema{
+			Type:       "object",
+			Properties: map[string]*jsonschema.Schema{"count": {Type: "string"}},
+		},
+	}, func(tool.Context, args) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// The arguments match the trusted schema but not the arguments type.
+	_, err = ft.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{"count": "5"})
+	want := `type "string" of property count is not one of ["integer"]`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Run() error = %v, want it to contain %q", err, want)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+// ErrSecretNotAllowed is returned by Secret for secrets that are not listed
+// in the Config.Secrets of the tool.
+var ErrSecretNotAllowed = errors.New("secret not allowed")
+
+// redactedSecret replaces the secret values found in tool results.
+const redactedSecret = "[REDACTED]"
+
+// Secret returns the value of the named secret from the SecretProvider of the
+// function tool that ctx was passed to. Only the secrets listed in the
+// tool's Config.Secrets can be read.
+//
+// The value must only be used to call external services. Should it end up in
+// the result or error of the tool anyway, it is replaced with "[REDACTED]"
+// before being sent to the model.
+func Secret(ctx tool.Context, name string) (string, error) {
+	scope, ok := ctx.Value(secretScopeKey{}).(*secretScope)
+	if !ok {
+		return "", errors.New("no secret provider configured for the tool")
+	}
+	if !slices.Contains(scope.allowed, name) {
+		return "", fmt.Errorf("tool %q: %w: %q", scope.tool, ErrSecretNotAllowed, name)
+	}
+	value, err := scope.provider.Secret(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	if value != "" {
+		scope.read = append(scope.read, value)
+	}
+	return value, nil
+}
+
+type secretScopeKey struct{}
+
+// secretScope gives a single tool call access to the secrets of the tool, and
+// records the values read to redact them from the result.
+type secretScope struct {
+	tool     string
+	provider tool.SecretProvider
+	allowed  []string
+
+	mu   sync.Mutex
+	read []string
+}
+
+// secretContext is the tool.Context passed to the handlers of tools with a
+// SecretProvider.
+type secretContext struct {
+	tool.Context
+	scope *secretScope
+}
+
+func (c *secretContext) Value(key any) any {
+	if _, ok := key.(secretScopeKey); ok {
+		return c.scope
+	}
+	return c.Context.Value(key)
+}
+
+// redact replaces the secret values read during the call in v.
+func (s *secretScope) redact(v any) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.read) == 0 {
+		return v
+	}
+	return redactValue(v, s.read)
+}
+
+func (s *secretScope) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg, ok := s.redact(err.Error()).(string)
+	if !ok || msg == err.Error() {
+		return err
+	}
+	return errors.New(msg)
+}
+
+func redactValue(v any, secrets []string) any {
+	switch v := v.(type) {
+	case string:
+		for _, secret := range secrets {
+			v = strings.ReplaceAll(v, secret, redactedSecret)
+		}
+		return v
+	case map[string]any:
+		for k, e := range v {
+			v[k] = redactValue(e, secrets)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e, secrets)
+		}
+		return v
+	case []tool.ResultPart:
+		parts := slices.Clone(v)
+		for i := range parts {
+			parts[i].Text = redactValue(parts[i].Text, secrets).(string)
+		}
+		return parts
+	default:
+		return v
+	}
+}
+
+// redactResults returns a copy of the typed results of a call with the
+// secret values read during the call replaced, for Invoke. The unexported
+// fields of structs are copied as is.
+func redactResults[TResults any](s *secretScope, results TResults) TResults {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.read) == 0 {
+		return results
+	}
+	v := redactedCopy(reflect.ValueOf(&results).Elem(), s.read)
+	return v.Interface().(TResults)
+}
+
+// redactedCopy returns a deep copy of v with the secrets replaced in its
+// strings, so that the values shared with the handler are not modified.
+func redactedCopy(v reflect.Value, secrets []string) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		r := reflect.New(v.Type()).Elem()
+		r.SetString(redactValue(v.String(), secrets).(string))
+		return r
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.New(v.Type().Elem())
+		r.Elem().Set(redactedCopy(v.Elem(), secrets))
+		return r
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.New(v.Type()).Elem()
+		r.Set(redactedCopy(v.Elem(), secrets))
+		return r
+	case reflect.Struct:
+		r := reflect.New(v.Type()).Elem()
+		r.Set(v)
+		for i := range v.NumField() {
+			if r.Field(i).CanSet() {
+				r.Field(i).Set(redactedCopy(v.Field(i), secrets))
+			}
+		}
+		return r
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			r.Index(i).Set(redactedCopy(v.Index(i), secrets))
+		}
+		return r
+	case reflect.Array:
+		r := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			r.Index(i).Set(redactedCopy(v.Index(i), secrets))
+		}
+		return r
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			r.SetMapIndex(iter.Key(), redactedCopy(iter.Value(), secrets))
+		}
+		return r
+	default:
+		return v
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type mapSecretProvider map[string]string
+
+func (p mapSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	v, ok := p[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found", name)
+	}
+	return v, nil
+}
+
+func TestSecret(t *testing.T) {
+	type Args struct {
+		Secret string `json:"secret"`
+		Leak   bool   `json:"leak,omitempty"`
+		Fail   bool   `json:"fail,omitempty"`
+	}
+	handler := func(ctx tool.Context, args Args) (map[string]any, error) {
+		key, err := functiontool.Secret(ctx, args.Secret)
+		if err != nil {
+			return nil, err
+		}
+		if args.Fail {
+			return nil, fmt.Errorf("request with key %s was rejected", key)
+		}
+		if args.Leak {
+			return map[string]any{"debug": []any{"key=" + key}}, nil
+		}
+		return map[string]any{"status": "authenticated"}, nil
+	}
+	apiTool, err := functiontool.New(functiontool.Config{
+		Name:           "call_api",
+		SecretProvider: mapSecretProvider{"api_key": "s3cr3t", "admin_key": "r00t"},
+		Secrets:        []string{"api_key"},
+	}, handler)
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		want        map[string]any
+		wantErr     error
+		wantErrText string
+	}{
+		{
+			name: "allowed secret",
+			args: map[string]any{"secret": "api_key"},
+			want: map[string]any{"status": "authenticated"},
+		},
+		{
+			name:    "secret not allowed",
+			args:    map[string]any{"secret": "admin_key"},
+			wantErr: functiontool.ErrSecretNotAllowed,
+		},
+		{
+			name: "secret redacted from result",
+			args: map[string]any{"secret": "api_key", "leak": true},
+			want: map[string]any{"debug": []any{"key=[REDACTED]"}},
+		},
+		{
+			name:        "secret redacted from error",
+			args:        map[string]any{"secret": "api_key", "fail": true},
+			wantErrText: "request with key [REDACTED] was rejected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiTool.(toolinternal.FunctionTool).Run(createToolContext(t), tt.args)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && (err == nil || err.Error() != tt.wantErrText) {
+				t.Errorf("Run() error = %v, want %q", err, tt.wantErrText)
+			}
+			if tt.wantErr == nil && tt.wantErrText == "" && err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecret_NoProvider(t *testing.T) {
+	noSecrets, err := functiontool.New(functiontool.Config{
+		Name: "no_secrets",
+	}, func(ctx tool.Context, _ struct{}) (string, error) {
+		return functiontool.Secret(ctx, "api_key")
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+	if _, err := noSecrets.(toolinternal.FunctionTool).Run(createToolContext(t), nil); err == nil {
+		t.Error("Run() succeeded, want error for a tool without secret provider")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+var (
+	typeSchemasMu sync.RWMutex
+	typeSchemas   = map[reflect.Type]*jsonschema.Schema{
+		reflect.TypeFor[FileInput](): fileInputSchema,
+	}
+	// inferredSchemas caches the schemas inferred for the arguments and
+	// results types of the tools, as many tools often share a type. It is
+	// cleared when the registered type schemas change, and typeSchemasGen
+	// counts these changes.
+	inferredSchemas = map[reflect.Type]*inferredSchema{}
+	typeSchemasGen  int
+)
+
+// inferredSchema is the schema inferred for a type, resolved. They are
+// shared by the tools, and never modified.
+type inferredSchema struct {
+	schema   *jsonschema.Schema
+	resolved *jsonschema.Resolved
+}
+
+// RegisterTypeSchema registers the schema used for the type T wherever it
+// appears in the arguments or results of function tools created afterwards,
+// instead of the schema inferred by reflection. It is typically called
+// from an init function, e.g. to describe time.Time as
+//
+//	&jsonschema.Schema{Type: "string", Format: "date-time"}
+//
+// Registering a nil schema removes the registration of T. Schemas given in
+// Config.InputSchema or Config.OutputSchema take precedence.
+func RegisterTypeSchema[T any](schema *jsonschema.Schema) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	t := reflect.TypeFor[T]()
+	clear(inferredSchemas)
+	typeSchemasGen++
+	if schema == nil {
+		delete(typeSchemas, t)
+		return
+	}
+	typeSchemas[t] = schema.CloneSchemas()
+}
+
+// inferSchema returns the schema inferred for T, resolved, from the cache if
+// possible.
+func inferSchema[T any]() (*inferredSchema, error) {
+	t := reflect.TypeFor[T]()
+	typeSchemasMu.RLock()
+	cached, ok := inferredSchemas[t]
+	opts, gen := forOptions(), typeSchemasGen
+	typeSchemasMu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	schema, err := jsonschema.For[T](opts)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, err
+	}
+	inferred := &inferredSchema{schema: schema, resolved: resolved}
+
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	// Not cached if a type schema was registered during the inference.
+	if gen == typeSchemasGen {
+		inferredSchemas[t] = inferred
+	}
+	return inferred, nil
+}
+
+// forOptions returns the options of the schema inference, with a snapshot
+// of the registered type schemas. typeSchemasMu must be held.
+func forOptions() *jsonschema.ForOptions {
+	if len(typeSchemas) == 0 {
+		return nil
+	}
+	return &jsonschema.ForOptions{TypeSchemas: maps.Clone(typeSchemas)}
+}
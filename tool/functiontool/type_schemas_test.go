@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functiontool_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// deadline is encoded as an RFC 3339 string, which reflection cannot infer.
+type deadline struct {
+	t time.Time
+}
+
+func (d deadline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.t.Format(time.RFC3339))
+}
+
+func (d *deadline) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	d.t = t
+	return err
+}
+
+func TestRegisterTypeSchema(t *testing.T) {
+	functiontool.RegisterTypeSchema[deadline](&jsonschema.Schema{Type: "string", Format: "date-time"})
+	t.Cleanup(func() { functiontool.RegisterTypeSchema[deadline](nil) })
+
+	type Args struct {
+		Task string   `json:"task"`
+		Due  deadline `json:"due"`
+	}
+	var gotDue time.Time
+	schedule, err := functiontool.New(functiontool.Config{
+		Name:        "schedule",
+		Description: "schedules a task",
+	}, func(_ tool.Context, args Args) (map[string]any, error) {
+		gotDue = args.Due.t
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var req model.LLMRequest
+	if err := schedule.(toolinternal.RequestProcessor).ProcessRequest(nil, &req); err != nil {
+		t.Fatal(err)
+	}
+	decl := toolDeclaration(req.Config)
+	schema, ok := decl.ParametersJsonSchema.(*jsonschema.Schema)
+	if !ok {
+		t.Fatalf("parameters schema is %T, want *jsonschema.Schema", decl.ParametersJsonSchema)
+	}
+	due := schema.Properties["due"]
+	if due == nil || due.Type != "string" || due.Format != "date-time" {
+		t.Errorf("schema of due = %+v, want the registered schema", due)
+	}
+
+	if _, err := schedule.(toolinternal.FunctionTool).Run(createToolContext(t), map[string]any{"task": "report", "due": "2026-10-15T09:00:00Z"}); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if want := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC); !gotDue.Equal(want) {
+		t.Errorf("got due %v, want %v", gotDue, want)
+	}
+}
+
+func TestInferredSchemasAreShared(t *testing.T) {
+	type Args struct {
+		Task string   `json:"task"`
+		Due  deadline `json:"due"`
+	}
+	parameters := func(name string) *jsonschema.Schema {
+		t.Helper()
+		fn, err := functiontool.New(functiontool.Config{Name: name}, func(tool.Context, Args) (map[string]any, error) {
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fn.(toolinternal.FunctionTool).Declaration().ParametersJsonSchema.(*jsonschema.Schema)
+	}
+
+	// The tools are created concurrently, sharing the inferred schema.
+	schemas := make([]*jsonschema.Schema, 8)
+	var wg sync.WaitGroup
+	for i := range schemas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			schemas[i] = parameters("tool")
+		}()
+	}
+	wg.Wait()
+	for _, s := range schemas[1:] {
+		if s != schemas[0] {
+			t.Fatal("tools with the same arguments type have different schemas, want a shared one")
+		}
+	}
+
+	// Registering a type schema applies to the tools created afterwards.
+	functiontool.RegisterTypeSchema[deadline](&jsonschema.Schema{Type: "string", Format: "date-time"})
+	t.Cleanup(func() { functiontool.RegisterTypeSchema[deadline](nil) })
+	registered := parameters("registered")
+	if registered == schemas[0] {
+		t.Fatal("schema inferred before the type schema was registered is reused")
+	}
+	if due := registered.Properties["due"]; due == nil || due.Format != "date-time" {
+		t.Errorf("schema of due = %+v, want the registered schema", due)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminitool
+
+import (
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// CodeExecution is a built-in tool that lets Gemini 2 models write and run
+// Python code, e.g. to do calculations or analyze data.
+// The code runs in the model's sandbox: like GoogleSearch, the tool is sent as
+// a genai.Tool rather than a function declaration, it has no Run method and
+// is never executed locally. The code and its results are reported in the
+// ExecutableCode and CodeExecutionResult parts of the model responses.
+type CodeExecution struct{}
+
+// Name implements tool.Tool.
+func (c CodeExecution) Name() string {
+	return "code_execution"
+}
+
+// Description implements tool.Tool.
+func (c CodeExecution) Description() string {
+	return "Runs Python code generated by the model in a sandbox."
+}
+
+// ProcessRequest adds the CodeExecution tool to the LLM request.
+func (c CodeExecution) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return setTool(req, &genai.Tool{
+		CodeExecution: &genai.ToolCodeExecution{},
+	})
+}
+
+// IsLongRunning implements tool.Tool.
+func (c CodeExecution) IsLongRunning() bool {
+	return false
+}
@@ -25,6 +25,13 @@ import (
 // models to retrieve search results from Google Search.
 // The tool operates internally within the model and does not require or
 // perform local code execution.
+//
+// It is sent as a genai.Tool with GoogleSearch set, not as a function
+// declaration, so it has no Run method: the model never returns function
+// calls for it, and the search results it grounded its answer on are
+// reported in the GroundingMetadata of the model responses. It is not
+// registered among the function tools of the request, so it can be used
+// alongside function tools of any name.
 type GoogleSearch struct{}
 
 // Name implements tool.Tool.
@@ -20,8 +20,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
 	"google.golang.org/adk/tool/geminitool"
 )
 
@@ -91,3 +95,63 @@ func TestGeminiTool_ProcessRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestGoogleSearch_WithFunctionTools(t *testing.T) {
+	lookup, err := functiontool.New(functiontool.Config{
+		Name:        "google_search",
+		Description: "looks up the local knowledge base",
+	}, func(tool.Context, struct{}) (map[string]any, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("functiontool.New() failed: %v", err)
+	}
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("answer", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "search_agent",
+		Model: mockModel,
+		Tools: []tool.Tool{geminitool.GoogleSearch{}, lookup},
+	})
+	if err != nil {
+		t.Fatalf("llmagent.New() failed: %v", err)
+	}
+	if _, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "search")); err != nil {
+		t.Fatalf("agent run failed: %v", err)
+	}
+
+	req := mockModel.Requests[0]
+	var gotSearch, gotDecls int
+	for _, gt := range req.Config.Tools {
+		if gt.GoogleSearch != nil {
+			gotSearch++
+		}
+		gotDecls += len(gt.FunctionDeclarations)
+	}
+	if gotSearch != 1 || gotDecls != 1 {
+		t.Errorf("request tools have %d GoogleSearch and %d function declarations, want 1 and 1", gotSearch, gotDecls)
+	}
+	if _, ok := req.Tools["google_search"].(toolinternal.FunctionTool); !ok {
+		t.Errorf("req.Tools[google_search] = %T, want the function tool", req.Tools["google_search"])
+	}
+}
+
+func TestCodeExecution_ProcessRequest(t *testing.T) {
+	var codeExecution tool.Tool = geminitool.CodeExecution{}
+	if _, ok := codeExecution.(toolinternal.FunctionTool); ok {
+		t.Error("CodeExecution implements FunctionTool, want a server-side tool only")
+	}
+
+	req := &model.LLMRequest{}
+	if err := codeExecution.(toolinternal.RequestProcessor).ProcessRequest(nil, req); err != nil {
+		t.Fatalf("ProcessRequest() failed: %v", err)
+	}
+	want := []*genai.Tool{{CodeExecution: &genai.ToolCodeExecution{}}}
+	if diff := cmp.Diff(want, req.Config.Tools); diff != "" {
+		t.Errorf("ProcessRequest returned unexpected tools (-want +got):\n%s", diff)
+	}
+	if len(req.Tools) != 0 {
+		t.Errorf("req.Tools = %v, want no local tools", req.Tools)
+	}
+}
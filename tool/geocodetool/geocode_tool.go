@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geocodetool provides a tool that turns place names into
+// coordinates and coordinates into addresses.
+//
+// The lookups are done by a pluggable Provider, e.g. the HTTPProvider
+// querying a Nominatim-compatible geocoding service, and their results are
+// cached.
+package geocodetool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Place is a geocoded location.
+type Place struct {
+	// Address is the human-readable address of the place.
+	Address string `json:"address"`
+	// Latitude and Longitude are the coordinates of the place, in degrees.
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Provider geocodes places.
+type Provider interface {
+	// Geocode returns the places matching the query, e.g. "Eiffel Tower"
+	// or an address, the best match first.
+	Geocode(ctx context.Context, query string) ([]Place, error)
+	// Reverse returns the place at the given coordinates.
+	Reverse(ctx context.Context, latitude, longitude float64) (Place, error)
+}
+
+// Config is the configuration of the geocoding tool.
+type Config struct {
+	// Name of the tool. Defaults to "geocode".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// Provider does the lookups. Required.
+	Provider Provider
+	// MaxResults is the maximum number of places returned by a forward
+	// lookup. Defaults to 5.
+	MaxResults int
+	// CacheTTL is how long the results of Provider are cached. Defaults to
+	// 24 hours.
+	CacheTTL time.Duration
+	// CacheSize is the maximum number of lookups cached. When the cache is
+	// full, the entry expiring first is evicted. Defaults to 1000.
+	CacheSize int
+}
+
+// Args are the arguments of the geocoding tool.
+type Args struct {
+	Query     string   `json:"query,omitempty" jsonschema:"the place name or address to find the coordinates of"`
+	Latitude  *float64 `json:"latitude,omitempty" jsonschema:"the latitude of the coordinates to find the address of"`
+	Longitude *float64 `json:"longitude,omitempty" jsonschema:"the longitude of the coordinates to find the address of"`
+}
+
+// Result is the result of the geocoding tool.
+type Result struct {
+	// Places are the places found, the best match first.
+	Places []Place `json:"places"`
+}
+
+// New creates a geocoding tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Provider == nil {
+		return nil, fmt.Errorf("a geocoding provider is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "geocode"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Finds the coordinates of a place name or address given as query, " +
+			"or the address at the given latitude and longitude."
+	}
+	if cfg.MaxResults <= 0 {
+		cfg.MaxResults = 5
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 24 * time.Hour
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = 1000
+	}
+
+	t := &geocodeTool{
+		provider:   cfg.Provider,
+		maxResults: cfg.MaxResults,
+		ttl:        cfg.CacheTTL,
+		cacheSize:  cfg.CacheSize,
+		cache:      make(map[string]cachedPlaces),
+	}
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type cachedPlaces struct {
+	places  []Place
+	expires time.Time
+}
+
+type geocodeTool struct {
+	provider   Provider
+	maxResults int
+	ttl        time.Duration
+	cacheSize  int
+
+	mu    sync.Mutex
+	cache map[string]cachedPlaces
+}
+
+func (t *geocodeTool) run(ctx tool.Context, args Args) (Result, error) {
+	query := strings.TrimSpace(args.Query)
+	switch {
+	case query != "":
+		places, err := t.cached(ctx, "geocode:"+strings.ToLower(query), func(ctx context.Context) ([]Place, error) {
+			return t.provider.Geocode(ctx, query)
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to geocode %q: %w", query, err)
+		}
+		if len(places) > t.maxResults {
+			places = places[:t.maxResults]
+		}
+		return Result{Places: places}, nil
+	case args.Latitude != nil && args.Longitude != nil:
+		lat, lng := *args.Latitude, *args.Longitude
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return Result{}, fmt.Errorf("invalid coordinates %v, %v", lat, lng)
+		}
+		// Coordinates closer than about a meter share the cache entry.
+		key := fmt.Sprintf("reverse:%.5f,%.5f", lat, lng)
+		places, err := t.cached(ctx, key, func(ctx context.Context) ([]Place, error) {
+			place, err := t.provider.Reverse(ctx, lat, lng)
+			if err != nil {
+				return nil, err
+			}
+			return []Place{place}, nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to find the address at %v, %v: %w", lat, lng, err)
+		}
+		return Result{Places: places}, nil
+	default:
+		return Result{}, fmt.Errorf("either a query or both a latitude and a longitude are required")
+	}
+}
+
+// cached returns the places of key from the cache, or looks them up and
+// caches them.
+func (t *geocodeTool) cached(ctx context.Context, key string, lookup func(context.Context) ([]Place, error)) ([]Place, error) {
+	t.mu.Lock()
+	entry, ok := t.cache[key]
+	t.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.places, nil
+	}
+	places, err := lookup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.cache[key]; !ok && len(t.cache) >= t.cacheSize {
+		t.evict()
+	}
+	t.cache[key] = cachedPlaces{places: places, expires: time.Now().Add(t.ttl)}
+	return places, nil
+}
+
+// evict removes the expired entries from the cache or, if there are none,
+// the entry expiring first. It must be called with t.mu held.
+func (t *geocodeTool) evict() {
+	now := time.Now()
+	var first string
+	var firstExpires time.Time
+	for key, entry := range t.cache {
+		if !now.Before(entry.expires) {
+			delete(t.cache, key)
+			continue
+		}
+		if first == "" || entry.expires.Before(firstExpires) {
+			first, firstExpires = key, entry.expires
+		}
+	}
+	if len(t.cache) >= t.cacheSize {
+		delete(t.cache, first)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geocodetool_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/geocodetool"
+)
+
+// stubProvider is a Provider with a fixed set of places.
+type stubProvider struct {
+	places map[string][]geocodetool.Place
+	calls  int
+}
+
+func (p *stubProvider) Geocode(_ context.Context, query string) ([]geocodetool.Place, error) {
+	p.calls++
+	places, ok := p.places[query]
+	if !ok {
+		return nil, errors.New("no match")
+	}
+	return places, nil
+}
+
+func (p *stubProvider) Reverse(_ context.Context, lat, lng float64) (geocodetool.Place, error) {
+	p.calls++
+	for _, places := range p.places {
+		for _, place := range places {
+			if place.Latitude == lat && place.Longitude == lng {
+				return place, nil
+			}
+		}
+	}
+	return geocodetool.Place{}, errors.New("no match")
+}
+
+var eiffelTower = geocodetool.Place{Address: "Eiffel Tower, Paris, France", Latitude: 48.8584, Longitude: 2.2945}
+
+func run(t *testing.T, geocode tool.Tool, args map[string]any) (map[string]any, error) {
+	t.Helper()
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+	return geocode.(toolinternal.FunctionTool).Run(ctx, args)
+}
+
+func TestGeocodeTool(t *testing.T) {
+	provider := &stubProvider{places: map[string][]geocodetool.Place{
+		"Eiffel Tower": {eiffelTower, {Address: "Eiffel Tower, Las Vegas, USA", Latitude: 36.1125, Longitude: -115.1707}},
+	}}
+	geocode, err := geocodetool.New(geocodetool.Config{Provider: provider, MaxResults: 1})
+	if err != nil {
+		t.Fatalf("geocodetool.New() failed: %v", err)
+	}
+	wantPlaces := map[string]any{"places": []any{
+		map[string]any{"address": eiffelTower.Address, "latitude": eiffelTower.Latitude, "longitude": eiffelTower.Longitude},
+	}}
+
+	for range 2 {
+		got, err := run(t, geocode, map[string]any{"query": "Eiffel Tower"})
+		if err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		if diff := cmp.Diff(wantPlaces, got); diff != "" {
+			t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("got %d provider calls, want the places to be cached", provider.calls)
+	}
+
+	got, err := run(t, geocode, map[string]any{"latitude": eiffelTower.Latitude, "longitude": eiffelTower.Longitude})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if diff := cmp.Diff(wantPlaces, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+
+	// With a single cache entry, the places of the query were evicted by
+	// the reverse lookup.
+	small, err := geocodetool.New(geocodetool.Config{Provider: provider, CacheSize: 1})
+	if err != nil {
+		t.Fatalf("geocodetool.New() failed: %v", err)
+	}
+	provider.calls = 0
+	for _, args := range []map[string]any{
+		{"query": "Eiffel Tower"},
+		{"latitude": eiffelTower.Latitude, "longitude": eiffelTower.Longitude},
+		{"query": "Eiffel Tower"},
+	} {
+		if _, err := run(t, small, args); err != nil {
+			t.Fatalf("Run(%v) failed: %v", args, err)
+		}
+	}
+	if provider.calls != 3 {
+		t.Errorf("got %d provider calls with a cache of size 1, want 3", provider.calls)
+	}
+
+	for _, tc := range []struct {
+		args      map[string]any
+		wantError string
+	}{
+		{args: map[string]any{"query": "Atlantis"}, wantError: "no match"},
+		{args: map[string]any{"latitude": 91.0, "longitude": 0.0}, wantError: "invalid coordinates"},
+		{args: map[string]any{"latitude": 48.0}, wantError: "either a query or both"},
+	} {
+		if _, err := run(t, geocode, tc.args); err == nil || !strings.Contains(err.Error(), tc.wantError) {
+			t.Errorf("Run(%v) error = %v, want error containing %q", tc.args, err, tc.wantError)
+		}
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("format"); got != "jsonv2" {
+			t.Errorf("format = %q, want jsonv2", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search":
+			w.Write([]byte(`[{"display_name": "Eiffel Tower, Paris, France", "lat": "48.8584", "lon": "2.2945"}]`))
+		case "/reverse":
+			w.Write([]byte(`{"display_name": "Eiffel Tower, Paris, France", "lat": "48.8584", "lon": "2.2945"}`))
+		case "/redirect/search":
+			http.Redirect(w, r, "http://example.com/search", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	for _, hosts := range [][]string{{"example.com"}, {"127.0.0.*"}, {"*127.0.0.1", "127.0.0.1"}, {"*"}} {
+		if _, err := geocodetool.NewHTTPProvider(geocodetool.HTTPProviderConfig{BaseURL: server.URL, AllowedHosts: hosts}); err == nil {
+			t.Errorf("NewHTTPProvider() succeeded with the allowed hosts %q, want error", hosts)
+		}
+	}
+	for _, tc := range []struct {
+		baseURL string
+		allowed bool
+	}{
+		{baseURL: "https://example.com", allowed: true},
+		{baseURL: "https://geo.example.com", allowed: true},
+		{baseURL: "https://evilexample.com", allowed: false},
+	} {
+		_, err := geocodetool.NewHTTPProvider(geocodetool.HTTPProviderConfig{BaseURL: tc.baseURL, AllowedHosts: []string{"*.example.com"}})
+		if got := err == nil; got != tc.allowed {
+			t.Errorf("NewHTTPProvider(%q) with the allowed host %q: error = %v, want allowed = %t", tc.baseURL, "*.example.com", err, tc.allowed)
+		}
+	}
+	provider, err := geocodetool.NewHTTPProvider(geocodetool.HTTPProviderConfig{
+		BaseURL:      server.URL,
+		AllowedHosts: []string{"127.0.0.1"},
+		Client:       server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPProvider() failed: %v", err)
+	}
+
+	places, err := provider.Geocode(t.Context(), "Eiffel Tower")
+	if err != nil {
+		t.Fatalf("Geocode() failed: %v", err)
+	}
+	if diff := cmp.Diff([]geocodetool.Place{eiffelTower}, places); diff != "" {
+		t.Errorf("Geocode() mismatch (-want +got):\n%s", diff)
+	}
+	place, err := provider.Reverse(t.Context(), 48.8584, 2.2945)
+	if err != nil {
+		t.Fatalf("Reverse() failed: %v", err)
+	}
+	if diff := cmp.Diff(eiffelTower, place); diff != "" {
+		t.Errorf("Reverse() mismatch (-want +got):\n%s", diff)
+	}
+
+	redirecting, err := geocodetool.NewHTTPProvider(geocodetool.HTTPProviderConfig{
+		BaseURL:      server.URL + "/redirect",
+		AllowedHosts: []string{"127.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPProvider() failed: %v", err)
+	}
+	if _, err := redirecting.Geocode(t.Context(), "Eiffel Tower"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("Geocode() error = %v, want the redirect to be rejected", err)
+	}
+}
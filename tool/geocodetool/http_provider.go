@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geocodetool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HTTPProviderConfig is the configuration of an HTTPProvider.
+type HTTPProviderConfig struct {
+	// BaseURL is the URL of the geocoding service, e.g.
+	// "https://nominatim.openstreetmap.org". Required.
+	BaseURL string
+	// AllowedHosts are the hosts the provider may send requests to,
+	// including after redirects. An entry starting with "*." also allows
+	// the subdomains of the host. Required, and must allow the host of
+	// BaseURL.
+	AllowedHosts []string
+	// Client is the HTTP client used to send the requests. Defaults to
+	// http.DefaultClient.
+	Client *http.Client
+	// UserAgent is sent with the requests, as required by the usage policy
+	// of some services.
+	UserAgent string
+	// MaxBytes is the maximum size of the responses. Defaults to 1 MiB.
+	MaxBytes int64
+}
+
+// HTTPProvider is a Provider querying a geocoding service with the API of
+// Nominatim, the OpenStreetMap geocoder, which other services implement too.
+type HTTPProvider struct {
+	baseURL      *url.URL
+	allowedHosts []string
+	client       *http.Client
+	userAgent    string
+	maxBytes     int64
+}
+
+// NewHTTPProvider creates an HTTPProvider.
+func NewHTTPProvider(cfg HTTPProviderConfig) (*HTTPProvider, error) {
+	if len(cfg.AllowedHosts) == 0 {
+		return nil, fmt.Errorf("at least one allowed host is required")
+	}
+	for _, host := range cfg.AllowedHosts {
+		if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, fmt.Errorf("invalid allowed host %q: only a leading \"*.\" wildcard is supported", host)
+		}
+	}
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid base URL %q", cfg.BaseURL)
+	}
+	p := &HTTPProvider{
+		baseURL:      baseURL,
+		allowedHosts: cfg.AllowedHosts,
+		userAgent:    cfg.UserAgent,
+		maxBytes:     cfg.MaxBytes,
+	}
+	if !p.allowed(baseURL) {
+		return nil, fmt.Errorf("host %q of the base URL is not allowed", baseURL.Hostname())
+	}
+	if p.maxBytes <= 0 {
+		p.maxBytes = 1 << 20
+	}
+
+	client := http.DefaultClient
+	if cfg.Client != nil {
+		client = cfg.Client
+	}
+	// Copy the client, to check the redirects without modifying the
+	// client of the caller.
+	c := *client
+	checkRedirect := c.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !p.allowed(req.URL) {
+			return fmt.Errorf("redirect to host %q is not allowed", req.URL.Hostname())
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	p.client = &c
+	return p, nil
+}
+
+// nominatimPlace is a place in the responses of the service.
+type nominatimPlace struct {
+	DisplayName string `json:"display_name"`
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	Error       string `json:"error"`
+}
+
+func (p nominatimPlace) place() (Place, error) {
+	lat, err := strconv.ParseFloat(p.Lat, 64)
+	if err != nil {
+		return Place{}, fmt.Errorf("invalid latitude %q", p.Lat)
+	}
+	lng, err := strconv.ParseFloat(p.Lon, 64)
+	if err != nil {
+		return Place{}, fmt.Errorf("invalid longitude %q", p.Lon)
+	}
+	return Place{Address: p.DisplayName, Latitude: lat, Longitude: lng}, nil
+}
+
+// Geocode implements Provider.
+func (p *HTTPProvider) Geocode(ctx context.Context, query string) ([]Place, error) {
+	var results []nominatimPlace
+	if err := p.get(ctx, "search", url.Values{"q": {query}}, &results); err != nil {
+		return nil, err
+	}
+	places := make([]Place, 0, len(results))
+	for _, r := range results {
+		place, err := r.place()
+		if err != nil {
+			return nil, err
+		}
+		places = append(places, place)
+	}
+	return places, nil
+}
+
+// Reverse implements Provider.
+func (p *HTTPProvider) Reverse(ctx context.Context, latitude, longitude float64) (Place, error) {
+	var result nominatimPlace
+	params := url.Values{
+		"lat": {strconv.FormatFloat(latitude, 'f', -1, 64)},
+		"lon": {strconv.FormatFloat(longitude, 'f', -1, 64)},
+	}
+	if err := p.get(ctx, "reverse", params, &result); err != nil {
+		return Place{}, err
+	}
+	if result.Error != "" {
+		return Place{}, errors.New(result.Error)
+	}
+	return result.place()
+}
+
+// get sends a request to the endpoint of the service and decodes the JSON
+// response to v.
+func (p *HTTPProvider) get(ctx context.Context, endpoint string, params url.Values, v any) error {
+	u := p.baseURL.JoinPath(endpoint)
+	params.Set("format", "jsonv2")
+	u.RawQuery = params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocoding service returned %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.maxBytes+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(body)) > p.maxBytes {
+		return fmt.Errorf("geocoding response is larger than %d bytes", p.maxBytes)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid geocoding response: %w", err)
+	}
+	return nil
+}
+
+func (p *HTTPProvider) allowed(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return slices.ContainsFunc(p.allowedHosts, func(allowed string) bool {
+		allowed = strings.ToLower(allowed)
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok {
+			return strings.HasSuffix(host, "."+domain) || host == domain
+		}
+		return host == allowed
+	})
+}
+
+var _ Provider = (*HTTPProvider)(nil)
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagegenerationtool provides a tool that generates images from a
+// text prompt with an image generation model, such as Imagen.
+//
+// The generated images are saved with the artifact service of the current
+// session. Saving the artifacts records them in the ArtifactDelta of the tool
+// response event, which is how the images are surfaced to the user.
+package imagegenerationtool
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// ImageGenerator generates images from a prompt.
+//
+// It is implemented by the Models of a [genai.Client].
+type ImageGenerator interface {
+	GenerateImages(ctx context.Context, model string, prompt string, config *genai.GenerateImagesConfig) (*genai.GenerateImagesResponse, error)
+}
+
+// Config is the configuration of the image generation tool.
+type Config struct {
+	// Name of the tool. Defaults to "generate_image".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// Generator calls the image generation model, e.g. client.Models of a
+	// [genai.Client]. Required.
+	Generator ImageGenerator
+	// Model is the image generation model, e.g. "imagen-4.0-generate-001".
+	// Required.
+	Model string
+	// GenerateImagesConfig is the base configuration of the image generation
+	// requests. The number of images and the aspect ratio requested by the
+	// model override it. Optional.
+	GenerateImagesConfig *genai.GenerateImagesConfig
+}
+
+// Args are the arguments of the image generation tool.
+type Args struct {
+	Prompt         string `json:"prompt" jsonschema:"detailed description of the image to generate"`
+	NumberOfImages int    `json:"number_of_images,omitempty" jsonschema:"number of images to generate, defaults to 1"`
+	AspectRatio    string `json:"aspect_ratio,omitempty" jsonschema:"aspect ratio of the images, one of: 1:1, 3:4, 4:3, 9:16, 16:9"`
+}
+
+// Result is the result of the image generation tool.
+type Result struct {
+	// Artifacts are the names of the artifacts holding the generated images.
+	Artifacts []string `json:"artifacts"`
+	// Filtered is the number of images filtered out by the model's safety
+	// filters.
+	Filtered int `json:"filtered,omitempty"`
+}
+
+// New creates an image generation tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Generator == nil {
+		return nil, fmt.Errorf("image generator is required")
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("image generation model is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "generate_image"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Generates images from a text prompt and saves them as artifacts shown to the user."
+	}
+	t := &imageGenerationTool{
+		generator: cfg.Generator,
+		model:     cfg.Model,
+		config:    cfg.GenerateImagesConfig,
+	}
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type imageGenerationTool struct {
+	generator ImageGenerator
+	model     string
+	config    *genai.GenerateImagesConfig
+}
+
+func (t *imageGenerationTool) run(ctx tool.Context, args Args) (Result, error) {
+	if args.Prompt == "" {
+		return Result{}, fmt.Errorf("prompt is required")
+	}
+	if ctx.Artifacts() == nil {
+		return Result{}, fmt.Errorf("artifact service is not configured")
+	}
+
+	var cfg genai.GenerateImagesConfig
+	if t.config != nil {
+		cfg = *t.config
+	}
+	if args.NumberOfImages > 0 {
+		cfg.NumberOfImages = int32(args.NumberOfImages)
+	}
+	if args.AspectRatio != "" {
+		cfg.AspectRatio = args.AspectRatio
+	}
+
+	resp, err := t.generator.GenerateImages(ctx, t.model, args.Prompt, &cfg)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to generate images: %w", err)
+	}
+
+	var result Result
+	for _, generated := range resp.GeneratedImages {
+		if generated == nil || generated.Image == nil || len(generated.Image.ImageBytes) == 0 {
+			result.Filtered++
+			continue
+		}
+		mimeType := generated.Image.MIMEType
+		if mimeType == "" {
+			mimeType = "image/png"
+		}
+		name := artifactName(ctx.FunctionCallID(), len(result.Artifacts), mimeType)
+		if _, err := ctx.Artifacts().Save(ctx, name, genai.NewPartFromBytes(generated.Image.ImageBytes, mimeType)); err != nil {
+			return Result{}, fmt.Errorf("failed to save image artifact %q: %w", name, err)
+		}
+		result.Artifacts = append(result.Artifacts, name)
+	}
+	if len(result.Artifacts) == 0 {
+		return Result{}, fmt.Errorf("no image was generated, %d filtered by safety filters", result.Filtered)
+	}
+	return result, nil
+}
+
+func artifactName(functionCallID string, index int, mimeType string) string {
+	ext := ".png"
+	if mimeType == "image/jpeg" {
+		ext = ".jpg"
+	}
+	return fmt.Sprintf("image_%s_%d%s", functionCallID, index, ext)
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagegenerationtool_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/imagegenerationtool"
+)
+
+type fakeGenerator struct {
+	gotModel, gotPrompt string
+	gotConfig           *genai.GenerateImagesConfig
+
+	resp *genai.GenerateImagesResponse
+	err  error
+}
+
+func (g *fakeGenerator) GenerateImages(ctx context.Context, model, prompt string, config *genai.GenerateImagesConfig) (*genai.GenerateImagesResponse, error) {
+	g.gotModel, g.gotPrompt, g.gotConfig = model, prompt, config
+	return g.resp, g.err
+}
+
+func TestImageGenerationTool_Run(t *testing.T) {
+	generator := &fakeGenerator{
+		resp: &genai.GenerateImagesResponse{
+			GeneratedImages: []*genai.GeneratedImage{
+				{Image: &genai.Image{ImageBytes: []byte("png bytes"), MIMEType: "image/png"}},
+				{RAIFilteredReason: "filtered"},
+				{Image: &genai.Image{ImageBytes: []byte("jpeg bytes"), MIMEType: "image/jpeg"}},
+			},
+		},
+	}
+	imageTool, err := imagegenerationtool.New(imagegenerationtool.Config{
+		Generator:            generator,
+		Model:                "imagen-4.0-generate-001",
+		GenerateImagesConfig: &genai.GenerateImagesConfig{AspectRatio: "1:1", AddWatermark: true},
+	})
+	if err != nil {
+		t.Fatalf("imagegenerationtool.New() failed: %v", err)
+	}
+	toolImpl := imageTool.(toolinternal.FunctionTool)
+
+	actions := &session.EventActions{}
+	ctx := createToolContext(t, actions)
+	result, err := toolImpl.Run(ctx, map[string]any{
+		"prompt":           "a cat reading a book",
+		"number_of_images": 3,
+	})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	want := map[string]any{
+		"artifacts": []any{"image_call_1_0.png", "image_call_1_1.jpg"},
+		"filtered":  float64(1),
+	}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	if generator.gotModel != "imagen-4.0-generate-001" || generator.gotPrompt != "a cat reading a book" {
+		t.Errorf("GenerateImages() called with (%q, %q)", generator.gotModel, generator.gotPrompt)
+	}
+	wantConfig := &genai.GenerateImagesConfig{AspectRatio: "1:1", AddWatermark: true, NumberOfImages: 3}
+	if diff := cmp.Diff(wantConfig, generator.gotConfig); diff != "" {
+		t.Errorf("GenerateImages() config mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int64{"image_call_1_0.png": 1, "image_call_1_1.jpg": 1}, actions.ArtifactDelta); diff != "" {
+		t.Errorf("ArtifactDelta mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err := ctx.Artifacts().Load(ctx, "image_call_1_1.jpg")
+	if err != nil {
+		t.Fatalf("failed to load image artifact: %v", err)
+	}
+	if got := resp.Part.InlineData; got.MIMEType != "image/jpeg" || !bytes.Equal(got.Data, []byte("jpeg bytes")) {
+		t.Errorf("loaded artifact = %+v, want the generated jpeg", got)
+	}
+}
+
+func TestImageGenerationTool_RunErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator *fakeGenerator
+		args      map[string]any
+	}{
+		{
+			name:      "empty prompt",
+			generator: &fakeGenerator{},
+			args:      map[string]any{"prompt": ""},
+		},
+		{
+			name:      "generation fails",
+			generator: &fakeGenerator{err: errors.New("quota exceeded")},
+			args:      map[string]any{"prompt": "a cat"},
+		},
+		{
+			name: "all images filtered",
+			generator: &fakeGenerator{resp: &genai.GenerateImagesResponse{
+				GeneratedImages: []*genai.GeneratedImage{{RAIFilteredReason: "filtered"}},
+			}},
+			args: map[string]any{"prompt": "a cat"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageTool, err := imagegenerationtool.New(imagegenerationtool.Config{
+				Generator: tt.generator,
+				Model:     "imagen",
+			})
+			if err != nil {
+				t.Fatalf("imagegenerationtool.New() failed: %v", err)
+			}
+			if _, err := imageTool.(toolinternal.FunctionTool).Run(createToolContext(t, &session.EventActions{}), tt.args); err == nil {
+				t.Errorf("Run(%v) succeeded unexpectedly, want error", tt.args)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	if _, err := imagegenerationtool.New(imagegenerationtool.Config{Model: "imagen"}); err == nil {
+		t.Error("New() without generator succeeded unexpectedly, want error")
+	}
+	if _, err := imagegenerationtool.New(imagegenerationtool.Config{Generator: &fakeGenerator{}}); err == nil {
+		t.Error("New() without model succeeded unexpectedly, want error")
+	}
+}
+
+func createToolContext(t *testing.T, actions *session.EventActions) tool.Context {
+	t.Helper()
+
+	artifacts := &artifactinternal.Artifacts{
+		Service:   artifact.InMemoryService(),
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "session",
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Artifacts: artifacts,
+	})
+	return toolinternal.NewToolContext(ctx, "call_1", actions, nil)
+}
+
+var _ imagegenerationtool.ImageGenerator = genai.Models{}
@@ -0,0 +1,29 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool
+
+// SetInstruction sets the section key of the agent's system instruction to
+// text, for the following model calls of the agent in the current
+// invocation, e.g. for a tool letting the model switch persona. Sections are
+// appended after the agent's instruction, sorted by key. Setting a key again
+// replaces its section, and an empty text removes it. The sections are
+// dropped when the invocation ends.
+func SetInstruction(ctx Context, key, text string) {
+	actions := ctx.Actions()
+	if actions.InstructionUpdates == nil {
+		actions.InstructionUpdates = make(map[string]string)
+	}
+	actions.InstructionUpdates[key] = text
+}
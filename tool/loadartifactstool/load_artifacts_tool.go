@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/genai"
@@ -42,7 +43,7 @@ type artifactsTool struct {
 func New() tool.Tool {
 	return &artifactsTool{
 		name:        "load_artifacts",
-		description: "Loads the artifacts and adds them to the session.",
+		description: "Loads the artifacts and adds them to the session. Reports the names of the artifacts that do not exist.",
 	}
 }
 
@@ -112,6 +113,28 @@ func (t *artifactsTool) Run(ctx tool.Context, args any) (map[string]any, error)
 	result := map[string]any{
 		"artifact_names": artifactNames,
 	}
+	if len(artifactNames) == 0 {
+		return result, nil
+	}
+	// Report the missing artifacts to the model, and only load the others,
+	// rather than failing the call.
+	resp, err := ctx.Artifacts().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	found := []string{}
+	var missing []string
+	for _, name := range artifactNames {
+		if slices.Contains(resp.FileNames, name) {
+			found = append(found, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	result["artifact_names"] = found
+	if len(missing) > 0 {
+		result["missing_artifact_names"] = missing
+	}
 	return result, nil
 }
 
@@ -33,6 +33,11 @@ import (
 func TestLoadArtifactsTool_Run(t *testing.T) {
 	loadArtifactsTool := loadartifactstool.New()
 	tc := createToolContext(t)
+	for _, name := range []string{"file1", "file2", "fileA", "fileB"} {
+		if _, err := tc.Artifacts().Save(t.Context(), name, genai.NewPartFromText("content")); err != nil {
+			t.Fatalf("Failed to save artifact %s: %v", name, err)
+		}
+	}
 
 	toolImpl, ok := loadArtifactsTool.(toolinternal.FunctionTool)
 	if !ok {
@@ -97,6 +102,26 @@ func TestLoadArtifactsTool_Run(t *testing.T) {
 				"artifact_names": []string{},
 			},
 		},
+		{
+			name: "missing artifacts",
+			args: map[string]any{
+				"artifact_names": []any{"file1", "missing1", "missing2"},
+			},
+			want: map[string]any{
+				"artifact_names":         []string{"file1"},
+				"missing_artifact_names": []string{"missing1", "missing2"},
+			},
+		},
+		{
+			name: "only missing artifacts",
+			args: map[string]any{
+				"artifact_names": []any{"missing1"},
+			},
+			want: map[string]any{
+				"artifact_names":         []string{},
+				"missing_artifact_names": []string{"missing1"},
+			},
+		},
 		{
 			name: "incorrect type (not a slice)",
 			args: map[string]any{
@@ -790,3 +790,49 @@ func TestNewToolSet_RequireConfirmationProvider_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestMCPToolMultimodalContent(t *testing.T) {
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "chart_server", Version: "v1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "plot", Description: "plots the data"}, func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			&mcp.TextContent{Text: "Here is the chart."},
+			&mcp.ImageContent{Data: []byte("png"), MIMEType: "image/png"},
+		}}, nil, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "fail", Description: "always fails"}, func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "quota exceeded"}}}, nil, nil
+	})
+	if _, err := server.Connect(t.Context(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := mcptoolset.New(mcptoolset.Config{Transport: clientTransport})
+	if err != nil {
+		t.Fatalf("Failed to create MCP tool set: %v", err)
+	}
+	invCtx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{})
+	tools, err := ts.Tools(icontext.NewReadonlyContext(invCtx))
+	if err != nil {
+		t.Fatalf("Failed to get tools: %v", err)
+	}
+	byName := make(map[string]toolinternal.FunctionTool)
+	for _, tl := range tools {
+		byName[tl.Name()] = tl.(toolinternal.FunctionTool)
+	}
+	toolCtx := toolinternal.NewToolContext(invCtx, "", &session.EventActions{}, nil)
+
+	got, err := byName["plot"].Run(toolCtx, map[string]any{})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	want := tool.NewResult(tool.TextPart("Here is the chart."), tool.BlobPart([]byte("png"), "image/png"))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := byName["fail"].Run(toolCtx, map[string]any{}); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Run() error = %v, want the MCP tool error", err)
+	}
+}
@@ -151,19 +151,53 @@ func (t *mcpTool) Run(ctx tool.Context, args any) (map[string]any, error) {
 		}, nil
 	}
 
-	textResponse := strings.Builder{}
+	return convertContent(res.Content)
+}
 
-	for _, c := range res.Content {
-		textContent, ok := c.(*mcp.TextContent)
-		if !ok {
+// convertContent converts the content of a tool result to the result map.
+// Text content is returned in the "output" field. Images, audio and binary
+// resources are returned as multimodal result parts, see tool.NewResult,
+// so that the model gets them as such.
+func convertContent(content []mcp.Content) (map[string]any, error) {
+	var parts []tool.ResultPart
+	textResponse := strings.Builder{}
+	hasBlobs := false
+	for _, c := range content {
+		var part tool.ResultPart
+		switch c := c.(type) {
+		case *mcp.TextContent:
+			part = tool.TextPart(c.Text)
+		case *mcp.ImageContent:
+			part = tool.BlobPart(c.Data, c.MIMEType)
+		case *mcp.AudioContent:
+			part = tool.BlobPart(c.Data, c.MIMEType)
+		case *mcp.EmbeddedResource:
+			switch {
+			case c.Resource == nil:
+				continue
+			case c.Resource.Blob != nil:
+				part = tool.BlobPart(c.Resource.Blob, c.Resource.MIMEType)
+			default:
+				part = tool.TextPart(c.Resource.Text)
+			}
+		case *mcp.ResourceLink:
+			part = tool.TextPart(fmt.Sprintf("[Resource %s: %s]", c.Name, c.URI))
+		default:
 			continue
 		}
-
-		if _, err := textResponse.WriteString(textContent.Text); err != nil {
+		if part.InlineData != nil {
+			hasBlobs = true
+		} else if part.Text == "" {
+			continue
+		} else if _, err := textResponse.WriteString(part.Text); err != nil {
 			return nil, fmt.Errorf("failed to write text response: %w", err)
 		}
+		parts = append(parts, part)
 	}
 
+	if hasBlobs {
+		return tool.NewResult(parts...), nil
+	}
 	if textResponse.Len() == 0 {
 		return nil, errors.New("no text content in tool response")
 	}
@@ -0,0 +1,197 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multiplextool provides a tool that exposes several related
+// operations to the model with a single function declaration.
+//
+// The model selects the operation with the "operation" field and passes its
+// arguments in the "args" field. This keeps groups of related operations,
+// such as create/read/update/delete, compact in the model's view.
+package multiplextool
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config is the configuration of a multiplexed tool.
+type Config struct {
+	// Name of the tool.
+	Name string
+	// Description of the tool. The descriptions of the operations are
+	// appended to it.
+	Description string
+	// Operations are the tools the multiplexed tool routes to, keyed by their
+	// names. They must be function tools created with functiontool.New.
+	Operations []tool.Tool
+}
+
+// New creates a tool that routes its calls to one of the operations,
+// selected by the "operation" argument.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("tool name is required")
+	}
+	if len(cfg.Operations) == 0 {
+		return nil, fmt.Errorf("tool %q has no operations", cfg.Name)
+	}
+
+	t := &multiplexTool{
+		name:       cfg.Name,
+		operations: make(map[string]toolinternal.FunctionTool),
+	}
+	var (
+		names      []any
+		argSchemas []*jsonschema.Schema
+		opDocs     []string
+	)
+	for _, op := range cfg.Operations {
+		fnTool, ok := op.(toolinternal.FunctionTool)
+		if !ok {
+			return nil, fmt.Errorf("operation %q is not a function tool", op.Name())
+		}
+		if op.IsLongRunning() {
+			return nil, fmt.Errorf("operation %q is long-running, which is not supported", op.Name())
+		}
+		if _, ok := t.operations[op.Name()]; ok {
+			return nil, fmt.Errorf("duplicate operation %q", op.Name())
+		}
+		decl := fnTool.Declaration()
+		argSchema, err := parametersSchema(decl)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", op.Name(), err)
+		}
+		t.operations[op.Name()] = fnTool
+		names = append(names, op.Name())
+		argSchemas = append(argSchemas, argSchema)
+		opDocs = append(opDocs, fmt.Sprintf("- %s: %s", op.Name(), op.Description()))
+	}
+
+	t.description = strings.TrimSpace(cfg.Description + "\n\nOperations:\n" + strings.Join(opDocs, "\n"))
+	t.schema = &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"operation": {
+				Type:        "string",
+				Description: "operation to perform",
+				Enum:        names,
+			},
+			"args": {
+				Description: "arguments of the operation",
+				AnyOf:       argSchemas,
+			},
+		},
+		Required: []string{"operation"},
+	}
+	return t, nil
+}
+
+// parametersSchema returns a copy of the parameters schema of decl, titled
+// after the operation, so the model can tell the alternatives apart.
+func parametersSchema(decl *genai.FunctionDeclaration) (*jsonschema.Schema, error) {
+	if decl.Parameters != nil {
+		return nil, fmt.Errorf("declarations with genai.Schema parameters are not supported")
+	}
+	var s *jsonschema.Schema
+	switch p := decl.ParametersJsonSchema.(type) {
+	case nil:
+		s = &jsonschema.Schema{Type: "object"}
+	case *jsonschema.Schema:
+		s = p.CloneSchemas()
+	default:
+		return nil, fmt.Errorf("unsupported parameters schema type %T", p)
+	}
+	s.Title = decl.Name
+	return s, nil
+}
+
+type multiplexTool struct {
+	name        string
+	description string
+	schema      *jsonschema.Schema
+	operations  map[string]toolinternal.FunctionTool
+}
+
+// Name implements tool.Tool.
+func (t *multiplexTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *multiplexTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *multiplexTool) IsLongRunning() bool {
+	return false
+}
+
+// ProcessRequest packs the tool's declaration into the LLM request.
+func (t *multiplexTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+// Declaration returns the single declaration covering all the operations.
+func (t *multiplexTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:                 t.name,
+		Description:          t.description,
+		ParametersJsonSchema: t.schema,
+	}
+}
+
+// Run routes the call to the operation selected by the "operation" argument.
+func (t *multiplexTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	name, _ := m["operation"].(string)
+	op, ok := t.operations[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q for tool %q, must be one of: %s", name, t.name, strings.Join(t.operationNames(), ", "))
+	}
+	opArgs, ok := m["args"].(map[string]any)
+	if !ok {
+		if m["args"] != nil {
+			return nil, fmt.Errorf("args of operation %q must be an object, got: %T", name, m["args"])
+		}
+		opArgs = map[string]any{}
+	}
+	return op.Run(ctx, opArgs)
+}
+
+func (t *multiplexTool) operationNames() []string {
+	var names []string
+	for name := range t.operations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*multiplexTool)(nil)
+	_ toolinternal.RequestProcessor = (*multiplexTool)(nil)
+)
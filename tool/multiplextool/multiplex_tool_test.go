@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiplextool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/multiplextool"
+)
+
+type createArgs struct {
+	Title string `json:"title"`
+}
+
+type deleteArgs struct {
+	ID int `json:"id"`
+}
+
+func newNotesTool(t *testing.T) toolinternal.FunctionTool {
+	t.Helper()
+
+	create, err := functiontool.New(functiontool.Config{
+		Name:        "create",
+		Description: "creates a note",
+	}, func(_ tool.Context, args createArgs) (map[string]any, error) {
+		return map[string]any{"created": args.Title}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	del, err := functiontool.New(functiontool.Config{
+		Name:        "delete",
+		Description: "deletes a note",
+	}, func(_ tool.Context, args deleteArgs) (map[string]any, error) {
+		return map[string]any{"deleted": args.ID}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notes, err := multiplextool.New(multiplextool.Config{
+		Name:        "notes",
+		Description: "Manages notes.",
+		Operations:  []tool.Tool{create, del},
+	})
+	if err != nil {
+		t.Fatalf("multiplextool.New() failed: %v", err)
+	}
+	return notes.(toolinternal.FunctionTool)
+}
+
+func TestMultiplexTool_Declaration(t *testing.T) {
+	notes := newNotesTool(t)
+
+	req := &model.LLMRequest{}
+	if err := notes.(toolinternal.RequestProcessor).ProcessRequest(nil, req); err != nil {
+		t.Fatalf("ProcessRequest() failed: %v", err)
+	}
+	if got := len(req.Config.Tools[0].FunctionDeclarations); got != 1 {
+		t.Fatalf("got %d function declarations, want 1", got)
+	}
+
+	decl := notes.Declaration()
+	wantDescription := "Manages notes.\n\nOperations:\n- create: creates a note\n- delete: deletes a note"
+	if decl.Description != wantDescription {
+		t.Errorf("Description = %q, want %q", decl.Description, wantDescription)
+	}
+	schema := decl.ParametersJsonSchema.(*jsonschema.Schema)
+	if diff := cmp.Diff([]any{"create", "delete"}, schema.Properties["operation"].Enum); diff != "" {
+		t.Errorf("operation enum mismatch (-want +got):\n%s", diff)
+	}
+	var titles []string
+	for _, s := range schema.Properties["args"].AnyOf {
+		titles = append(titles, s.Title)
+	}
+	if diff := cmp.Diff([]string{"create", "delete"}, titles); diff != "" {
+		t.Errorf("args variants mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"operation"}, schema.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMultiplexTool_Run(t *testing.T) {
+	notes := newNotesTool(t)
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", &session.EventActions{}, nil)
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "routes to create",
+			args: map[string]any{"operation": "create", "args": map[string]any{"title": "groceries"}},
+			want: map[string]any{"created": "groceries"},
+		},
+		{
+			name: "routes to delete",
+			args: map[string]any{"operation": "delete", "args": map[string]any{"id": 7}},
+			want: map[string]any{"deleted": float64(7)},
+		},
+		{
+			name:    "missing args are validated by the operation",
+			args:    map[string]any{"operation": "delete"},
+			wantErr: `missing properties: ["id"]`,
+		},
+		{
+			name:    "unknown operation",
+			args:    map[string]any{"operation": "update"},
+			wantErr: `unknown operation "update" for tool "notes", must be one of: create, delete`,
+		},
+		{
+			name:    "args not an object",
+			args:    map[string]any{"operation": "create", "args": "groceries"},
+			wantErr: "must be an object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := notes.Run(ctx, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	op, err := functiontool.New(functiontool.Config{Name: "op"}, func(_ tool.Context, args createArgs) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  multiplextool.Config
+	}{
+		{
+			name: "no name",
+			cfg:  multiplextool.Config{Operations: []tool.Tool{op}},
+		},
+		{
+			name: "no operations",
+			cfg:  multiplextool.Config{Name: "tool"},
+		},
+		{
+			name: "duplicate operation",
+			cfg:  multiplextool.Config{Name: "tool", Operations: []tool.Tool{op, op}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := multiplextool.New(tt.cfg); err == nil {
+				t.Errorf("New() succeeded unexpectedly, want error")
+			}
+		})
+	}
+}
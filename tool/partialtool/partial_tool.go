@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package partialtool provides partial application of function tools: a
+// general tool, such as query_database(db, sql), is exposed to an agent as
+// a tool with some of its arguments bound, such as query_users_db(sql).
+package partialtool
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config is the configuration of a partially applied tool.
+type Config struct {
+	// Name of the tool. Defaults to the name of the base tool.
+	Name string
+	// Description of the tool. Defaults to the description of the base tool.
+	Description string
+	// Args are the bound arguments, keyed by parameter name. They are
+	// removed from the declaration of the tool and passed to the base tool
+	// on each call, overriding any value given by the model.
+	Args map[string]any
+}
+
+// New returns base with the arguments of cfg bound. base must be a function
+// tool, e.g. created with functiontool.New, whose parameters are declared
+// with a JSON schema.
+func New(base tool.Tool, cfg Config) (tool.Tool, error) {
+	fnTool, ok := base.(toolinternal.FunctionTool)
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not a function tool", base.Name())
+	}
+	if cfg.Name == "" {
+		cfg.Name = base.Name()
+	}
+	if cfg.Description == "" {
+		cfg.Description = base.Description()
+	}
+
+	decl := fnTool.Declaration()
+	if decl.Parameters != nil {
+		return nil, fmt.Errorf("tool %q: declarations with genai.Schema parameters are not supported", base.Name())
+	}
+	var schema *jsonschema.Schema
+	switch p := decl.ParametersJsonSchema.(type) {
+	case nil:
+		schema = &jsonschema.Schema{Type: "object"}
+	case *jsonschema.Schema:
+		schema = p.CloneSchemas()
+	default:
+		return nil, fmt.Errorf("tool %q: unsupported parameters schema type %T", base.Name(), p)
+	}
+	// The clone shares the Required slice with the base schema.
+	schema.Required = slices.Clone(schema.Required)
+	for name := range cfg.Args {
+		if _, ok := schema.Properties[name]; !ok {
+			return nil, fmt.Errorf("tool %q has no parameter %q", base.Name(), name)
+		}
+		delete(schema.Properties, name)
+		schema.Required = slices.DeleteFunc(schema.Required, func(r string) bool { return r == name })
+	}
+
+	return &partialTool{
+		base:        fnTool,
+		name:        cfg.Name,
+		description: cfg.Description,
+		schema:      schema,
+		response:    decl.ResponseJsonSchema,
+		args:        maps.Clone(cfg.Args),
+	}, nil
+}
+
+type partialTool struct {
+	base        toolinternal.FunctionTool
+	name        string
+	description string
+	schema      *jsonschema.Schema
+	response    any
+	args        map[string]any
+}
+
+// Name implements tool.Tool.
+func (t *partialTool) Name() string {
+	return t.name
+}
+
+// Description implements tool.Tool.
+func (t *partialTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *partialTool) IsLongRunning() bool {
+	return t.base.IsLongRunning()
+}
+
+// ProcessRequest packs the tool's declaration into the LLM request.
+func (t *partialTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+// Declaration returns the declaration of the base tool without the bound
+// parameters.
+func (t *partialTool) Declaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:                 t.name,
+		Description:          t.description,
+		ParametersJsonSchema: t.schema,
+		ResponseJsonSchema:   t.response,
+	}
+}
+
+// Run calls the base tool with the arguments of the model merged with the
+// bound arguments.
+func (t *partialTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok && args != nil {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	merged := make(map[string]any, len(m)+len(t.args))
+	maps.Copy(merged, m)
+	maps.Copy(merged, t.args)
+	return t.base.Run(ctx, merged)
+}
+
+// FormatError formats the errors as the base tool does.
+func (t *partialTool) FormatError(err error) string {
+	if f, ok := t.base.(toolinternal.ErrorFormatter); ok {
+		return f.FormatError(err)
+	}
+	return err.Error()
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*partialTool)(nil)
+	_ toolinternal.RequestProcessor = (*partialTool)(nil)
+	_ toolinternal.ErrorFormatter   = (*partialTool)(nil)
+)
@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partialtool_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/partialtool"
+)
+
+type queryArgs struct {
+	DB  string `json:"db"`
+	SQL string `json:"sql"`
+}
+
+func newQueryTool(t *testing.T) tool.Tool {
+	t.Helper()
+
+	query, err := functiontool.New(functiontool.Config{
+		Name:        "query_database",
+		Description: "runs a SQL query",
+	}, func(_ tool.Context, args queryArgs) (map[string]any, error) {
+		return map[string]any{"db": args.DB, "sql": args.SQL}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query
+}
+
+func TestPartialTool(t *testing.T) {
+	queryUsers, err := partialtool.New(newQueryTool(t), partialtool.Config{
+		Name:        "query_users_db",
+		Description: "runs a SQL query on the users database",
+		Args:        map[string]any{"db": "users"},
+	})
+	if err != nil {
+		t.Fatalf("partialtool.New() failed: %v", err)
+	}
+	fnTool := queryUsers.(toolinternal.FunctionTool)
+
+	decl := fnTool.Declaration()
+	if decl.Name != "query_users_db" || decl.Description != "runs a SQL query on the users database" {
+		t.Errorf("got declaration %q: %q, want the configured name and description", decl.Name, decl.Description)
+	}
+	schema := decl.ParametersJsonSchema.(*jsonschema.Schema)
+	if _, ok := schema.Properties["db"]; ok {
+		t.Error("bound parameter db is declared")
+	}
+	if diff := cmp.Diff([]string{"sql"}, schema.Required); diff != "" {
+		t.Errorf("required mismatch (-want +got):\n%s", diff)
+	}
+
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", &session.EventActions{}, nil)
+	// The bound argument overrides the model's.
+	got, err := fnTool.Run(ctx, map[string]any{"sql": "SELECT 1", "db": "billing"})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"db": "users", "sql": "SELECT 1"}, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPartialTool_UnknownParameter(t *testing.T) {
+	if _, err := partialtool.New(newQueryTool(t), partialtool.Config{Args: map[string]any{"table": "users"}}); err == nil {
+		t.Error("partialtool.New() succeeded, want an error for an unknown parameter")
+	}
+}
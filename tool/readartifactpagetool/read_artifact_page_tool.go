@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readartifactpagetool provides a tool that reads a text artifact
+// page by page.
+//
+// Unlike loadartifactstool, which adds whole artifacts to the LLM request,
+// this tool returns a bounded window of the artifact content, so that the
+// model can iterate through artifacts too large to fit in its context.
+package readartifactpagetool
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Config is the configuration of the read artifact page tool.
+type Config struct {
+	// Name of the tool. Defaults to "read_artifact_page".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// DefaultLength is the page length in bytes used when the model does not
+	// specify one. Defaults to 4096.
+	DefaultLength int
+	// MaxLength is the maximum page length in bytes the model can request.
+	// Defaults to 16384.
+	MaxLength int
+}
+
+// Args are the arguments of the read artifact page tool.
+type Args struct {
+	Artifact string `json:"artifact" jsonschema:"name of the artifact to read"`
+	Offset   int    `json:"offset,omitempty" jsonschema:"byte offset to start reading at, 0 for the beginning"`
+	Length   int    `json:"length,omitempty" jsonschema:"maximum number of bytes to read"`
+}
+
+// Result is the result of the read artifact page tool.
+type Result struct {
+	// Content is the text of the page.
+	Content string `json:"content"`
+	// Offset is the byte offset of the page.
+	Offset int `json:"offset"`
+	// TotalSize is the size of the whole artifact in bytes.
+	TotalSize int `json:"total_size"`
+	// NextOffset is the offset to read the next page from. It is only
+	// meaningful when HasMore is true.
+	NextOffset int `json:"next_offset"`
+	// HasMore reports whether the artifact has content after this page.
+	HasMore bool `json:"has_more"`
+}
+
+// New creates a read artifact page tool.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = "read_artifact_page"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Reads a page of a text artifact, starting at the given byte offset. " +
+			"Use next_offset from the result to read the following page while has_more is true."
+	}
+	if cfg.MaxLength <= 0 {
+		cfg.MaxLength = 16384
+	}
+	if cfg.DefaultLength <= 0 {
+		cfg.DefaultLength = 4096
+	}
+	cfg.DefaultLength = min(cfg.DefaultLength, cfg.MaxLength)
+
+	t := &readArtifactPageTool{defaultLength: cfg.DefaultLength, maxLength: cfg.MaxLength}
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+	}, t.run)
+}
+
+type readArtifactPageTool struct {
+	defaultLength, maxLength int
+}
+
+func (t *readArtifactPageTool) run(ctx tool.Context, args Args) (Result, error) {
+	if args.Artifact == "" {
+		return Result{}, fmt.Errorf("artifact name is required")
+	}
+	if ctx.Artifacts() == nil {
+		return Result{}, fmt.Errorf("artifact service is not configured")
+	}
+
+	resp, err := ctx.Artifacts().Load(ctx, args.Artifact)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to load artifact %q: %w", args.Artifact, err)
+	}
+	var data []byte
+	switch part := resp.Part; {
+	case part == nil:
+		return Result{}, fmt.Errorf("artifact %q is empty", args.Artifact)
+	case part.InlineData != nil:
+		data = part.InlineData.Data
+	default:
+		data = []byte(part.Text)
+	}
+	if !utf8.Valid(data) {
+		return Result{}, fmt.Errorf("artifact %q is not a text artifact", args.Artifact)
+	}
+
+	return page(data, args.Offset, t.pageLength(args.Length))
+}
+
+func (t *readArtifactPageTool) pageLength(length int) int {
+	if length <= 0 {
+		return t.defaultLength
+	}
+	return min(length, t.maxLength)
+}
+
+// page returns the page of data starting at offset and at most length bytes
+// long. The page end is moved back to a rune boundary, so that multi-byte
+// characters are never split between pages.
+func page(data []byte, offset, length int) (Result, error) {
+	if offset < 0 || offset > len(data) {
+		return Result{}, fmt.Errorf("offset %d is out of range [0, %d]", offset, len(data))
+	}
+	if offset < len(data) && !utf8.RuneStart(data[offset]) {
+		return Result{}, fmt.Errorf("offset %d is in the middle of a character", offset)
+	}
+
+	end := min(offset+length, len(data))
+	for end < len(data) && end > offset && !utf8.RuneStart(data[end]) {
+		end--
+	}
+	if end == offset && offset < len(data) {
+		// The page is shorter than the next character, return it whole.
+		_, size := utf8.DecodeRune(data[offset:])
+		end = offset + size
+	}
+
+	return Result{
+		Content:    string(data[offset:end]),
+		Offset:     offset,
+		TotalSize:  len(data),
+		NextOffset: end,
+		HasMore:    end < len(data),
+	}, nil
+}
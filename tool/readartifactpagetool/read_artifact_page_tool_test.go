@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readartifactpagetool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	artifactinternal "google.golang.org/adk/internal/artifact"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/readartifactpagetool"
+)
+
+func TestReadArtifactPageTool_Run(t *testing.T) {
+	readTool, err := readartifactpagetool.New(readartifactpagetool.Config{DefaultLength: 4})
+	if err != nil {
+		t.Fatalf("readartifactpagetool.New() failed: %v", err)
+	}
+	toolImpl, ok := readTool.(toolinternal.FunctionTool)
+	if !ok {
+		t.Fatal("readTool does not implement FunctionTool")
+	}
+
+	ctx := createToolContext(t)
+	if _, err := ctx.Artifacts().Save(ctx, "notes.txt", genai.NewPartFromText("héllo world")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.Artifacts().Save(ctx, "data.bin", genai.NewPartFromBytes([]byte{0xff, 0xfe}, "application/octet-stream")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Page through the whole artifact.
+	var pages []string
+	offset := 0
+	for {
+		result, err := toolImpl.Run(ctx, map[string]any{"artifact": "notes.txt", "offset": offset})
+		if err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		if got, want := result["total_size"], float64(len("héllo world")); got != want {
+			t.Errorf("total_size = %v, want %v", got, want)
+		}
+		pages = append(pages, result["content"].(string))
+		if !result["has_more"].(bool) {
+			break
+		}
+		offset = int(result["next_offset"].(float64))
+	}
+	// "é" is two bytes long, it is not split between pages.
+	if diff := cmp.Diff([]string{"hél", "lo w", "orld"}, pages); diff != "" {
+		t.Errorf("pages mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{
+			name: "missing artifact name",
+			args: map[string]any{},
+		},
+		{
+			name: "unknown artifact",
+			args: map[string]any{"artifact": "unknown.txt"},
+		},
+		{
+			name: "binary artifact",
+			args: map[string]any{"artifact": "data.bin"},
+		},
+		{
+			name: "offset out of range",
+			args: map[string]any{"artifact": "notes.txt", "offset": 100},
+		},
+		{
+			name: "offset in the middle of a character",
+			args: map[string]any{"artifact": "notes.txt", "offset": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toolImpl.Run(ctx, tt.args); err == nil {
+				t.Errorf("Run(%v) succeeded unexpectedly, want error", tt.args)
+			}
+		})
+	}
+}
+
+func TestReadArtifactPageTool_MaxLength(t *testing.T) {
+	readTool, err := readartifactpagetool.New(readartifactpagetool.Config{MaxLength: 5})
+	if err != nil {
+		t.Fatalf("readartifactpagetool.New() failed: %v", err)
+	}
+	toolImpl := readTool.(toolinternal.FunctionTool)
+
+	ctx := createToolContext(t)
+	if _, err := ctx.Artifacts().Save(ctx, "notes.txt", genai.NewPartFromText(strings.Repeat("a", 20))); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := toolImpl.Run(ctx, map[string]any{"artifact": "notes.txt", "offset": 3, "length": 100})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	delete(result, functiontool.ProvenanceKey)
+	want := map[string]any{
+		"content":     "aaaaa",
+		"offset":      float64(3),
+		"total_size":  float64(20),
+		"next_offset": float64(8),
+		"has_more":    true,
+	}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func createToolContext(t *testing.T) tool.Context {
+	t.Helper()
+
+	artifacts := &artifactinternal.Artifacts{
+		Service:   artifact.InMemoryService(),
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "session",
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Artifacts: artifacts,
+	})
+	return toolinternal.NewToolContext(ctx, "", &session.EventActions{}, nil)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remotetool provides tools built from a function declaration and a
+// function invoking them, e.g. tools served by another process and known
+// only by their serialized declarations, for gateways and proxies.
+package remotetool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// InvokeFunc runs a remote tool with the arguments of the model's function
+// call and returns its result. The context is the tool.Context of the call.
+type InvokeFunc func(ctx context.Context, args map[string]any) (map[string]any, error)
+
+// New creates a tool declared to the model with decl, whose calls are run by
+// invoke. The arguments are passed to invoke as sent by the model, the
+// remote side being responsible for validating them against the
+// declaration.
+func New(decl *genai.FunctionDeclaration, invoke InvokeFunc) (tool.Tool, error) {
+	if decl == nil || decl.Name == "" {
+		return nil, errors.New("a function declaration with a name is required")
+	}
+	if invoke == nil {
+		return nil, fmt.Errorf("no invoke function given for tool %q", decl.Name)
+	}
+	d := *decl
+	return &remoteTool{decl: &d, invoke: invoke}, nil
+}
+
+// NewFromJSON is like New, with the function declaration in its JSON
+// encoding.
+func NewFromJSON(decl []byte, invoke InvokeFunc) (tool.Tool, error) {
+	var d genai.FunctionDeclaration
+	if err := json.Unmarshal(decl, &d); err != nil {
+		return nil, fmt.Errorf("invalid function declaration: %w", err)
+	}
+	return New(&d, invoke)
+}
+
+type remoteTool struct {
+	decl   *genai.FunctionDeclaration
+	invoke InvokeFunc
+}
+
+// Name implements tool.Tool.
+func (t *remoteTool) Name() string {
+	return t.decl.Name
+}
+
+// Description implements tool.Tool.
+func (t *remoteTool) Description() string {
+	return t.decl.Description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *remoteTool) IsLongRunning() bool {
+	return false
+}
+
+func (t *remoteTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+func (t *remoteTool) Declaration() *genai.FunctionDeclaration {
+	return t.decl
+}
+
+func (t *remoteTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok && args != nil {
+		return nil, fmt.Errorf("unexpected args type for tool %q, got: %T", t.Name(), args)
+	}
+	if m == nil {
+		m = map[string]any{}
+	}
+	result, err := t.invoke(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("remote tool %q failed: %w", t.Name(), err)
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/remotetool"
+)
+
+const weatherDecl = `{
+	"name": "get_weather",
+	"description": "Returns the weather in a city.",
+	"parametersJsonSchema": {
+		"type": "object",
+		"properties": {"city": {"type": "string"}},
+		"required": ["city"]
+	}
+}`
+
+func TestNewFromJSON(t *testing.T) {
+	var gotArgs map[string]any
+	weather, err := remotetool.NewFromJSON([]byte(weatherDecl), func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		gotArgs = args
+		if _, ok := ctx.(tool.Context); !ok {
+			t.Errorf("got context %T, want a tool.Context", ctx)
+		}
+		return map[string]any{"forecast": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewFromJSON() failed: %v", err)
+	}
+	if weather.Name() != "get_weather" || weather.Description() != "Returns the weather in a city." {
+		t.Errorf("got tool %q: %q, want the declared name and description", weather.Name(), weather.Description())
+	}
+
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+		genai.NewContentFromText("It is sunny in Paris.", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "weather_agent",
+		Model: mockModel,
+		Tools: []tool.Tool{weather},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts, err := testutil.CollectParts(testutil.NewTestAgentRunner(t, a).Run(t, "session", "weather in Paris?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decls := mockModel.Requests[0].Config.Tools[0].FunctionDeclarations
+	if len(decls) != 1 || decls[0].Name != "get_weather" || decls[0].ParametersJsonSchema == nil {
+		t.Errorf("got declarations %+v, want the get_weather declaration", decls)
+	}
+	if diff := cmp.Diff(map[string]any{"city": "Paris"}, gotArgs); diff != "" {
+		t.Errorf("invoke args mismatch (-want +got):\n%s", diff)
+	}
+	var gotResponse map[string]any
+	for _, p := range parts {
+		if p.FunctionResponse != nil {
+			gotResponse = p.FunctionResponse.Response
+		}
+	}
+	if diff := cmp.Diff(map[string]any{"forecast": "sunny"}, gotResponse); diff != "" {
+		t.Errorf("function response mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	invoke := func(context.Context, map[string]any) (map[string]any, error) {
+		return nil, errors.New("unreachable")
+	}
+	if _, err := remotetool.New(nil, invoke); err == nil {
+		t.Error("New(nil, _) succeeded, want an error")
+	}
+	if _, err := remotetool.New(&genai.FunctionDeclaration{Description: "no name"}, invoke); err == nil {
+		t.Error("New() with a nameless declaration succeeded, want an error")
+	}
+	if _, err := remotetool.New(&genai.FunctionDeclaration{Name: "f"}, nil); err == nil {
+		t.Error("New() without invoke function succeeded, want an error")
+	}
+	if _, err := remotetool.NewFromJSON([]byte("{"), invoke); err == nil {
+		t.Error("NewFromJSON() with invalid JSON succeeded, want an error")
+	}
+}
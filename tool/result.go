@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool
+
+import "google.golang.org/genai"
+
+// ResultPartsKey is the reserved key of a tool result holding the
+// multimodal parts of the result. The agent loop removes it from the
+// function response and translates the parts: text parts are joined into
+// the "output" field of the response, inline blobs and artifacts are sent
+// as the parts of the function response.
+const ResultPartsKey = "_parts"
+
+// ResultPart is one typed part of a multimodal tool result. Exactly one of
+// its fields should be set.
+type ResultPart struct {
+	// Text is a textual part of the result.
+	Text string `json:"text,omitempty"`
+	// InlineData is binary data, for example a generated image.
+	InlineData *genai.Blob `json:"inline_data,omitempty"`
+	// Artifact is the name of an artifact of the session, for example one
+	// saved by the tool. Its latest version is loaded by the agent loop.
+	Artifact string `json:"artifact,omitempty"`
+	// Ref refers to content the model has already seen, so that it is not
+	// sent again.
+	Ref *ResultRef `json:"ref,omitempty"`
+}
+
+// ResultRef refers to content already present in the conversation. The
+// agent loop checks that the referenced content exists and replaces the
+// reference with a short text pointing the model to it, instead of
+// sending the full payload again. Exactly one of its fields should be set.
+type ResultRef struct {
+	// Artifact is the name of an artifact of the session.
+	Artifact string `json:"artifact,omitempty"`
+	// FunctionCallID is the ID of an earlier function call of the
+	// session, whose response is already in the conversation.
+	FunctionCallID string `json:"function_call_id,omitempty"`
+}
+
+// TextPart returns a result part holding text.
+func TextPart(text string) ResultPart {
+	return ResultPart{Text: text}
+}
+
+// BlobPart returns a result part holding inline data of the given MIME type.
+func BlobPart(data []byte, mimeType string) ResultPart {
+	return ResultPart{InlineData: &genai.Blob{Data: data, MIMEType: mimeType}}
+}
+
+// ArtifactPart returns a result part referring to the named artifact.
+func ArtifactPart(name string) ResultPart {
+	return ResultPart{Artifact: name}
+}
+
+// ArtifactRefPart returns a result part referring to the named artifact
+// without loading it, for artifacts the model has already seen.
+func ArtifactRefPart(name string) ResultPart {
+	return ResultPart{Ref: &ResultRef{Artifact: name}}
+}
+
+// FunctionResponseRefPart returns a result part referring to the response
+// of an earlier function call with the given ID.
+func FunctionResponseRefPart(functionCallID string) ResultPart {
+	return ResultPart{Ref: &ResultRef{FunctionCallID: functionCallID}}
+}
+
+// NewResult returns a tool result carrying the given parts. Tools may add
+// other fields to the returned map.
+func NewResult(parts ...ResultPart) map[string]any {
+	return map[string]any{ResultPartsKey: parts}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemavalidatetool provides a tool that validates JSON data against
+// JSON schemas, so that agents producing structured data can check their work.
+//
+// The schemas are registered when the tool is created and referenced by name,
+// the model cannot supply arbitrary schemas.
+package schemavalidatetool
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Config is the configuration of the schema validation tool.
+type Config struct {
+	// Name of the tool. Defaults to "validate_json".
+	Name string
+	// Description of the tool. Defaults to a generic description.
+	Description string
+	// Schemas are the schemas data can be validated against, keyed by the
+	// names the model uses to reference them. Required.
+	Schemas map[string]*jsonschema.Schema
+}
+
+// Args are the arguments of the schema validation tool.
+type Args struct {
+	Schema string `json:"schema" jsonschema:"name of the schema to validate against"`
+	JSON   string `json:"json" jsonschema:"the JSON value to validate"`
+}
+
+// Result is the result of the schema validation tool.
+type Result struct {
+	// Valid reports whether the value conforms to the schema.
+	Valid bool `json:"valid"`
+	// Violations describe why the value does not conform to the schema.
+	Violations []string `json:"violations,omitempty"`
+}
+
+// New creates a schema validation tool.
+func New(cfg Config) (tool.Tool, error) {
+	if len(cfg.Schemas) == 0 {
+		return nil, fmt.Errorf("at least one schema is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "validate_json"
+	}
+	if cfg.Description == "" {
+		cfg.Description = "Validates a JSON value against one of the known schemas and returns the violations, if any."
+	}
+
+	t := &validateTool{schemas: make(map[string]*jsonschema.Resolved, len(cfg.Schemas))}
+	var names []any
+	for name, s := range cfg.Schemas {
+		resolved, err := s.Resolve(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve schema %q: %w", name, err)
+		}
+		t.schemas[name] = resolved
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b any) int { return strings.Compare(a.(string), b.(string)) })
+
+	inputSchema, err := jsonschema.For[Args](nil)
+	if err != nil {
+		return nil, err
+	}
+	inputSchema.Properties["schema"].Enum = names
+
+	return functiontool.New(functiontool.Config{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: inputSchema,
+	}, t.run)
+}
+
+type validateTool struct {
+	schemas map[string]*jsonschema.Resolved
+}
+
+func (t *validateTool) run(_ tool.Context, args Args) (Result, error) {
+	resolved, ok := t.schemas[args.Schema]
+	if !ok {
+		return Result{}, fmt.Errorf("unknown schema %q", args.Schema)
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(args.JSON), &value); err != nil {
+		return Result{Violations: []string{fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+	if err := resolved.Validate(value); err != nil {
+		return Result{Violations: violations(err)}, nil
+	}
+	return Result{Valid: true}, nil
+}
+
+// violations flattens joined validation errors into one message per
+// violation.
+func violations(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var msgs []string
+		for _, e := range joined.Unwrap() {
+			msgs = append(msgs, violations(e)...)
+		}
+		return msgs
+	}
+	var msgs []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			msgs = append(msgs, line)
+		}
+	}
+	if len(msgs) == 0 {
+		msgs = append(msgs, "value does not conform to the schema")
+	}
+	return msgs
+}
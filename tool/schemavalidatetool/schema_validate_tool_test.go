@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemavalidatetool_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/jsonschema-go/jsonschema"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/tool/schemavalidatetool"
+)
+
+func TestSchemaValidateTool(t *testing.T) {
+	validateTool, err := schemavalidatetool.New(schemavalidatetool.Config{
+		Schemas: map[string]*jsonschema.Schema{
+			"person": {
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {Type: "string"},
+					"age":  {Type: "integer", Minimum: jsonschema.Ptr(0.0)},
+				},
+				Required: []string{"name"},
+			},
+			"tags": {
+				Type:  "array",
+				Items: &jsonschema.Schema{Type: "string"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("schemavalidatetool.New() failed: %v", err)
+	}
+	toolImpl := validateTool.(toolinternal.FunctionTool)
+
+	schema := toolImpl.Declaration().ParametersJsonSchema.(*jsonschema.Schema)
+	if diff := cmp.Diff([]any{"person", "tags"}, schema.Properties["schema"].Enum); diff != "" {
+		t.Errorf("schema enum mismatch (-want +got):\n%s", diff)
+	}
+
+	ctx := toolinternal.NewToolContext(icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{}), "", nil, nil)
+	tests := []struct {
+		name          string
+		args          map[string]any
+		wantValid     bool
+		wantViolation string
+		wantErr       bool
+	}{
+		{
+			name:      "valid object",
+			args:      map[string]any{"schema": "person", "json": `{"name": "Ada", "age": 36}`},
+			wantValid: true,
+		},
+		{
+			name:          "missing required property",
+			args:          map[string]any{"schema": "person", "json": `{"age": 36}`},
+			wantViolation: "name",
+		},
+		{
+			name:          "wrong type",
+			args:          map[string]any{"schema": "tags", "json": `["a", 1]`},
+			wantViolation: "type",
+		},
+		{
+			name:          "invalid JSON",
+			args:          map[string]any{"schema": "tags", "json": `["a"`},
+			wantViolation: "invalid JSON",
+		},
+		{
+			name:    "unknown schema",
+			args:    map[string]any{"schema": "invoice", "json": `{}`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toolImpl.Run(ctx, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got["valid"] != tt.wantValid {
+				t.Errorf("valid = %v, want %v", got["valid"], tt.wantValid)
+			}
+			if tt.wantViolation == "" {
+				if got["violations"] != nil {
+					t.Errorf("violations = %v, want none", got["violations"])
+				}
+				return
+			}
+			violations, _ := got["violations"].([]any)
+			if len(violations) == 0 || !strings.Contains(violations[0].(string), tt.wantViolation) {
+				t.Errorf("violations = %v, want one containing %q", violations, tt.wantViolation)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	if _, err := schemavalidatetool.New(schemavalidatetool.Config{}); err == nil {
+		t.Error("New() without schemas succeeded unexpectedly")
+	}
+	_, err := schemavalidatetool.New(schemavalidatetool.Config{
+		Schemas: map[string]*jsonschema.Schema{"bad": {Ref: "#/$defs/missing"}},
+	})
+	if err == nil {
+		t.Error("New() with an unresolvable schema succeeded unexpectedly")
+	}
+}
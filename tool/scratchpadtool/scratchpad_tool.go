@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scratchpadtool provides a tool that lets the model keep notes in a
+// scratchpad stored in the session state.
+//
+// The model can offload intermediate results of long multi-step tasks to the
+// scratchpad and read them back later, without adding them to the visible
+// conversation.
+package scratchpadtool
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/multiplextool"
+)
+
+// DefaultStateKey is the session state key holding the scratchpad, unless
+// Config.StateKey is set.
+const DefaultStateKey = "_adk_scratchpad"
+
+// Config is the configuration of the scratchpad tool.
+type Config struct {
+	// Name of the tool. Defaults to "scratchpad".
+	Name string
+	// StateKey is the session state key holding the scratchpad. Defaults to
+	// DefaultStateKey. Use a "user:" or "app:" prefixed key to share the
+	// scratchpad across sessions.
+	StateKey string
+	// MaxSize is the maximum size of the scratchpad in bytes. Appending to a
+	// full scratchpad fails. Defaults to 32768.
+	MaxSize int
+}
+
+// AppendArgs are the arguments of the append operation.
+type AppendArgs struct {
+	Note string `json:"note" jsonschema:"note to add at the end of the scratchpad"`
+}
+
+// Result is the result of the scratchpad operations.
+type Result struct {
+	// Content is the content of the scratchpad, returned by read.
+	Content string `json:"content,omitempty"`
+	// Size is the size of the scratchpad in bytes after the operation.
+	Size int `json:"size"`
+}
+
+// New creates a scratchpad tool with the append, read and clear operations.
+func New(cfg Config) (tool.Tool, error) {
+	if cfg.Name == "" {
+		cfg.Name = "scratchpad"
+	}
+	if cfg.StateKey == "" {
+		cfg.StateKey = DefaultStateKey
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 32768
+	}
+	s := &scratchpad{key: cfg.StateKey, maxSize: cfg.MaxSize}
+
+	appendOp, err := functiontool.New(functiontool.Config{
+		Name:        "append",
+		Description: "Appends a note to the scratchpad.",
+	}, s.append)
+	if err != nil {
+		return nil, err
+	}
+	readOp, err := functiontool.New(functiontool.Config{
+		Name:        "read",
+		Description: "Returns the content of the scratchpad.",
+	}, s.read)
+	if err != nil {
+		return nil, err
+	}
+	clearOp, err := functiontool.New(functiontool.Config{
+		Name:        "clear",
+		Description: "Removes all the notes from the scratchpad.",
+	}, s.clear)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiplextool.New(multiplextool.Config{
+		Name: cfg.Name,
+		Description: "A private scratchpad to keep intermediate notes while working on a task. " +
+			"The notes are not shown to the user.",
+		Operations: []tool.Tool{appendOp, readOp, clearOp},
+	})
+}
+
+type scratchpad struct {
+	key     string
+	maxSize int
+}
+
+func (s *scratchpad) load(ctx tool.Context) (string, error) {
+	v, err := ctx.State().Get(s.key)
+	if errors.Is(err, session.ErrStateKeyNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read scratchpad: %w", err)
+	}
+	if v == nil {
+		return "", nil
+	}
+	content, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected scratchpad type %T in state key %q", v, s.key)
+	}
+	return content, nil
+}
+
+func (s *scratchpad) append(ctx tool.Context, args AppendArgs) (Result, error) {
+	if args.Note == "" {
+		return Result{}, fmt.Errorf("note is required")
+	}
+	content, err := s.load(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	if content != "" {
+		content += "\n"
+	}
+	content += args.Note
+	if len(content) > s.maxSize {
+		return Result{}, fmt.Errorf("scratchpad is full (%d bytes max), clear it or write a shorter note", s.maxSize)
+	}
+	if err := ctx.State().Set(s.key, content); err != nil {
+		return Result{}, fmt.Errorf("failed to write scratchpad: %w", err)
+	}
+	return Result{Size: len(content)}, nil
+}
+
+func (s *scratchpad) read(ctx tool.Context, _ struct{}) (Result, error) {
+	content, err := s.load(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Content: content, Size: len(content)}, nil
+}
+
+func (s *scratchpad) clear(ctx tool.Context, _ struct{}) (Result, error) {
+	if err := ctx.State().Set(s.key, ""); err != nil {
+		return Result{}, fmt.Errorf("failed to clear scratchpad: %w", err)
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scratchpadtool_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/scratchpadtool"
+)
+
+func TestScratchpadTool(t *testing.T) {
+	scratchpad, err := scratchpadtool.New(scratchpadtool.Config{MaxSize: 20})
+	if err != nil {
+		t.Fatalf("scratchpadtool.New() failed: %v", err)
+	}
+	toolImpl := scratchpad.(toolinternal.FunctionTool)
+	if got, want := scratchpad.Name(), "scratchpad"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+
+	sess := createSession(t)
+	actions := &session.EventActions{}
+	ctx := createToolContext(t, sess, actions)
+
+	steps := []struct {
+		args    map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			args: map[string]any{"operation": "read"},
+			want: map[string]any{"size": float64(0)},
+		},
+		{
+			args: map[string]any{"operation": "append", "args": map[string]any{"note": "x = 42"}},
+			want: map[string]any{"size": float64(6)},
+		},
+		{
+			args: map[string]any{"operation": "append", "args": map[string]any{"note": "y = x * 2"}},
+			want: map[string]any{"size": float64(16)},
+		},
+		{
+			args: map[string]any{"operation": "read"},
+			want: map[string]any{"content": "x = 42\ny = x * 2", "size": float64(16)},
+		},
+		{
+			// Exceeds the max size.
+			args:    map[string]any{"operation": "append", "args": map[string]any{"note": "z = 0"}},
+			wantErr: true,
+		},
+		{
+			args:    map[string]any{"operation": "append", "args": map[string]any{"note": ""}},
+			wantErr: true,
+		},
+		{
+			args: map[string]any{"operation": "clear"},
+			want: map[string]any{"size": float64(0)},
+		},
+		{
+			args: map[string]any{"operation": "read"},
+			want: map[string]any{"size": float64(0)},
+		},
+	}
+	for _, step := range steps {
+		got, err := toolImpl.Run(ctx, step.args)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("Run(%v) error = %v, wantErr %v", step.args, err, step.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if diff := cmp.Diff(step.want, got); diff != "" {
+			t.Errorf("Run(%v) mismatch (-want +got):\n%s", step.args, diff)
+		}
+	}
+
+	if got := actions.StateDelta[scratchpadtool.DefaultStateKey]; got != "" {
+		t.Errorf("StateDelta[%q] = %q, want the cleared scratchpad", scratchpadtool.DefaultStateKey, got)
+	}
+}
+
+func TestScratchpadTool_ReadsSessionState(t *testing.T) {
+	scratchpad, err := scratchpadtool.New(scratchpadtool.Config{StateKey: "user:notes"})
+	if err != nil {
+		t.Fatalf("scratchpadtool.New() failed: %v", err)
+	}
+	toolImpl := scratchpad.(toolinternal.FunctionTool)
+
+	sess := createSession(t)
+	if err := sess.State().Set("user:notes", "from a previous session"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := createToolContext(t, sess, &session.EventActions{})
+
+	got, err := toolImpl.Run(ctx, map[string]any{"operation": "read"})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	want := map[string]any{"content": "from a previous session", "size": float64(23)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func createSession(t *testing.T) session.Session {
+	t.Helper()
+
+	resp, err := session.InMemoryService().Create(t.Context(), &session.CreateRequest{
+		AppName: "app",
+		UserID:  "user",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Session
+}
+
+func createToolContext(t *testing.T, sess session.Session, actions *session.EventActions) tool.Context {
+	t.Helper()
+
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Session: sess,
+	})
+	return toolinternal.NewToolContext(ctx, "", actions, nil)
+}
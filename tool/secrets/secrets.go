@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets provides implementations of tool.SecretProvider.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/adk/tool"
+)
+
+// ErrNotFound is returned when a secret does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// NewEnvProvider returns a tool.SecretProvider that reads secrets from
+// environment variables.
+//
+// The variable of a secret is its name prefixed with prefix, upper-cased,
+// with the characters other than letters and digits replaced by
+// underscores. For example, with the prefix "MYAPP_", the secret "db-password"
+// is read from MYAPP_DB_PASSWORD. Unset and empty variables are reported as
+// ErrNotFound.
+func NewEnvProvider(prefix string) tool.SecretProvider {
+	return envProvider{prefix: prefix}
+}
+
+type envProvider struct {
+	prefix string
+}
+
+func (p envProvider) Secret(_ context.Context, name string) (string, error) {
+	key := p.prefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	value := os.Getenv(key)
+	if value == "" {
+		return "", fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	return value, nil
+}
+
+// NewFileProvider returns a tool.SecretProvider that reads each secret from
+// the file of the same name in dir, like the secrets mounted as files by
+// Docker or Kubernetes. Trailing newlines are removed from the values.
+//
+// Names that are not plain file names, e.g. containing a path separator,
+// are rejected, so that tools cannot read files outside dir.
+func NewFileProvider(dir string) tool.SecretProvider {
+	return fileProvider{dir: dir}
+}
+
+type fileProvider struct {
+	dir string
+}
+
+func (p fileProvider) Secret(_ context.Context, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+	b, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %q: %w", name, err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/adk/tool/secrets"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("MYAPP_DB_PASSWORD", "hunter2")
+	p := secrets.NewEnvProvider("MYAPP_")
+
+	got, err := p.Secret(t.Context(), "db-password")
+	if err != nil {
+		t.Fatalf("Secret() failed: %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("Secret() = %q, want %q", got, "hunter2")
+	}
+
+	if _, err := p.Secret(t.Context(), "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Secret() error = %v, want %v", err, secrets.ErrNotFound)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api_key"), []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := secrets.NewFileProvider(dir)
+
+	got, err := p.Secret(t.Context(), "api_key")
+	if err != nil {
+		t.Fatalf("Secret() failed: %v", err)
+	}
+	if got != "s3cr3t" {
+		t.Errorf("Secret() = %q, want %q", got, "s3cr3t")
+	}
+
+	if _, err := p.Secret(t.Context(), "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Secret() error = %v, want %v", err, secrets.ErrNotFound)
+	}
+	for _, name := range []string{"", "..", "../api_key", "sub/api_key"} {
+		if _, err := p.Secret(t.Context(), name); err == nil {
+			t.Errorf("Secret(%q) succeeded, want error", name)
+		}
+	}
+}
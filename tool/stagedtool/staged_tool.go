@@ -0,0 +1,269 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stagedtool wraps destructive function tools in a two-phase
+// confirm-then-execute pattern, so that the model cannot run an
+// irreversible action in a single step.
+//
+// The first call of a staged tool returns a preview of what the call would
+// do, e.g. the rows a delete would remove, with a confirmation token. The
+// action is only executed by a second call with the same arguments and the
+// token. Unlike the confirmations of functiontool.Config.RequireConfirmation,
+// no user approval is involved: the model is given a chance to check the
+// preview, or show it to the user, before proceeding.
+package stagedtool
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// TokenKey is the name of the parameter the confirmation token is passed
+// in, and of the field of the preview result holding it.
+const TokenKey = "confirmation_token"
+
+// stateKeyPrefix prefixes the session state key of the pending confirmation
+// of each staged tool.
+const stateKeyPrefix = "_staged_confirmation:"
+
+// Config is the configuration of a staged tool.
+type Config struct {
+	// Preview describes what a call with the given arguments would do,
+	// without doing it. Its result is returned to the model under
+	// "preview". Required.
+	Preview func(ctx tool.Context, args map[string]any) (any, error)
+	// TTL is how long a confirmation token is valid. Defaults to 5 minutes.
+	TTL time.Duration
+}
+
+// New returns base wrapped in the confirm-then-execute pattern. base must be
+// a function tool, e.g. created with functiontool.New, whose parameters are
+// declared with a JSON schema.
+//
+// A call without a confirmation token runs cfg.Preview and returns its
+// result with a new token, replacing the pending one of the session. A call
+// with the pending token and the same arguments as the preview runs base.
+// Tokens are single use, expire after cfg.TTL, and are kept in the session
+// state, so that the confirmation may follow the preview in a later turn.
+func New(base tool.Tool, cfg Config) (tool.Tool, error) {
+	fnTool, ok := base.(toolinternal.FunctionTool)
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not a function tool", base.Name())
+	}
+	if cfg.Preview == nil {
+		return nil, fmt.Errorf("tool %q: a preview function is required", base.Name())
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 5 * time.Minute
+	}
+
+	decl := fnTool.Declaration()
+	if decl.Parameters != nil {
+		return nil, fmt.Errorf("tool %q: declarations with genai.Schema parameters are not supported", base.Name())
+	}
+	var schema *jsonschema.Schema
+	switch p := decl.ParametersJsonSchema.(type) {
+	case nil:
+		schema = &jsonschema.Schema{Type: "object"}
+	case *jsonschema.Schema:
+		schema = p.CloneSchemas()
+	default:
+		return nil, fmt.Errorf("tool %q: unsupported parameters schema type %T", base.Name(), p)
+	}
+	if _, ok := schema.Properties[TokenKey]; ok {
+		return nil, fmt.Errorf("tool %q already has a parameter %q", base.Name(), TokenKey)
+	}
+	// The clone shares the Properties map with the base schema.
+	schema.Properties = maps.Clone(schema.Properties)
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	schema.Properties[TokenKey] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "The confirmation token returned by the preview of the call. Omit it to get the preview.",
+	}
+
+	return &stagedTool{
+		base:   fnTool,
+		cfg:    cfg,
+		schema: schema,
+		description: base.Description() + "\n\nThis action is irreversible and runs in two steps: a call " +
+			"without " + TokenKey + " returns a preview of the action and a confirmation token, a call " +
+			"with the same arguments and the token executes it.",
+	}, nil
+}
+
+type stagedTool struct {
+	base        toolinternal.FunctionTool
+	cfg         Config
+	schema      *jsonschema.Schema
+	description string
+}
+
+// Name implements tool.Tool.
+func (t *stagedTool) Name() string {
+	return t.base.Name()
+}
+
+// Description implements tool.Tool.
+func (t *stagedTool) Description() string {
+	return t.description
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *stagedTool) IsLongRunning() bool {
+	return t.base.IsLongRunning()
+}
+
+// ProcessRequest packs the tool's declaration into the LLM request.
+func (t *stagedTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	return toolutils.PackTool(req, t)
+}
+
+// Declaration returns the declaration of the base tool with the
+// confirmation token parameter.
+func (t *stagedTool) Declaration() *genai.FunctionDeclaration {
+	decl := *t.base.Declaration()
+	decl.Description = t.description
+	decl.ParametersJsonSchema = t.schema
+	return &decl
+}
+
+// pending is the pending confirmation of a staged tool, as kept in the
+// session state.
+type pending struct {
+	Token   string `json:"token"`
+	Args    string `json:"args"`
+	Expires string `json:"expires"`
+}
+
+// Run returns a preview of the call, or runs the base tool if the call is
+// confirmed.
+func (t *stagedTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok && args != nil {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	m = maps.Clone(m)
+	if m == nil {
+		m = map[string]any{}
+	}
+	token, _ := m[TokenKey].(string)
+	delete(m, TokenKey)
+	digest, err := argsDigest(m)
+	if err != nil {
+		return nil, err
+	}
+	key := stateKeyPrefix + t.Name()
+
+	if token == "" {
+		preview, err := t.cfg.Preview(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		expires := time.Now().Add(t.cfg.TTL).UTC()
+		p := pending{Token: rand.Text(), Args: digest, Expires: expires.Format(time.RFC3339Nano)}
+		if err := ctx.State().Set(key, map[string]any{"token": p.Token, "args": p.Args, "expires": p.Expires}); err != nil {
+			return nil, fmt.Errorf("failed to save the confirmation token: %w", err)
+		}
+		return map[string]any{
+			"preview":   preview,
+			TokenKey:    p.Token,
+			"expires":   p.Expires,
+			"next_step": "To execute the action, call the tool again with the same arguments and " + TokenKey + ".",
+		}, nil
+	}
+
+	p, err := pendingConfirmation(ctx.State(), key)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || subtle.ConstantTimeCompare([]byte(p.Token), []byte(token)) != 1 {
+		return nil, fmt.Errorf("invalid confirmation token, call the tool without %s to get a new preview", TokenKey)
+	}
+	if expires, err := time.Parse(time.RFC3339Nano, p.Expires); err != nil || time.Now().After(expires) {
+		return nil, fmt.Errorf("the confirmation token has expired, call the tool without %s to get a new preview", TokenKey)
+	}
+	if p.Args != digest {
+		return nil, fmt.Errorf("the arguments differ from the ones of the preview, call the tool without %s to get a new preview", TokenKey)
+	}
+	// The token is single use.
+	if err := ctx.State().Delete(key); err != nil {
+		return nil, fmt.Errorf("failed to consume the confirmation token: %w", err)
+	}
+	return t.base.Run(ctx, m)
+}
+
+// FormatError formats the errors as the base tool does.
+func (t *stagedTool) FormatError(err error) string {
+	if f, ok := t.base.(toolinternal.ErrorFormatter); ok {
+		return f.FormatError(err)
+	}
+	return err.Error()
+}
+
+// pendingConfirmation returns the pending confirmation of the state, or nil
+// if there is none.
+func pendingConfirmation(state session.State, key string) (*pending, error) {
+	v, err := state.Get(key)
+	if errors.Is(err, session.ErrStateKeyNotExist) || (err == nil && v == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the confirmation token: %w", err)
+	}
+	// The value is a map, or its JSON form once reloaded from storage.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pending confirmation: %w", err)
+	}
+	var p pending
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("invalid pending confirmation: %w", err)
+	}
+	return &p, nil
+}
+
+// argsDigest returns a digest of the arguments, the keys of the maps being
+// sorted by the JSON encoding.
+func argsDigest(args map[string]any) (string, error) {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the arguments: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+var (
+	_ toolinternal.FunctionTool     = (*stagedTool)(nil)
+	_ toolinternal.RequestProcessor = (*stagedTool)(nil)
+	_ toolinternal.ErrorFormatter   = (*stagedTool)(nil)
+)
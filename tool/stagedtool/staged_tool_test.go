@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stagedtool_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/stagedtool"
+)
+
+type deleteArgs struct {
+	Table string `json:"table"`
+}
+
+func newDeleteTool(t *testing.T, deleted *[]string, ttl time.Duration) toolinternal.FunctionTool {
+	t.Helper()
+	base, err := functiontool.New(functiontool.Config{
+		Name:        "delete_table",
+		Description: "Deletes a table.",
+	}, func(_ tool.Context, args deleteArgs) (map[string]any, error) {
+		*deleted = append(*deleted, args.Table)
+		return map[string]any{"deleted": args.Table}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	staged, err := stagedtool.New(base, stagedtool.Config{
+		Preview: func(_ tool.Context, args map[string]any) (any, error) {
+			return fmt.Sprintf("would delete the 42 rows of %v", args["table"]), nil
+		},
+		TTL: ttl,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return staged.(toolinternal.FunctionTool)
+}
+
+func createToolContext(t *testing.T) tool.Context {
+	t.Helper()
+	resp, err := session.InMemoryService().Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{Session: resp.Session})
+	return toolinternal.NewToolContext(ctx, "", &session.EventActions{StateDelta: map[string]any{}}, nil)
+}
+
+func TestStagedTool(t *testing.T) {
+	var deleted []string
+	staged := newDeleteTool(t, &deleted, 0)
+	ctx := createToolContext(t)
+
+	schema := staged.Declaration().ParametersJsonSchema.(*jsonschema.Schema)
+	if _, ok := schema.Properties[stagedtool.TokenKey]; !ok {
+		t.Errorf("got parameters %v, want a %s parameter", schema.Properties, stagedtool.TokenKey)
+	}
+
+	// The first call only previews the action.
+	preview, err := staged.Run(ctx, map[string]any{"table": "users"})
+	if err != nil {
+		t.Fatalf("Run() preview failed: %v", err)
+	}
+	if got, want := preview["preview"], "would delete the 42 rows of users"; got != want {
+		t.Errorf("got preview %v, want %q", got, want)
+	}
+	token, _ := preview[stagedtool.TokenKey].(string)
+	if token == "" || len(deleted) != 0 {
+		t.Fatalf("got preview %v and deleted %v, want a token and nothing deleted", preview, deleted)
+	}
+
+	for _, tc := range []struct {
+		args    map[string]any
+		wantErr string
+	}{
+		{map[string]any{"table": "users", stagedtool.TokenKey: "forged"}, "invalid confirmation token"},
+		{map[string]any{"table": "orders", stagedtool.TokenKey: token}, "arguments differ"},
+	} {
+		if _, err := staged.Run(ctx, tc.args); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Run(%v) error = %v, want it to contain %q", tc.args, err, tc.wantErr)
+		}
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("deleted %v before the confirmation", deleted)
+	}
+
+	// The call with the token executes the action, once.
+	got, err := staged.Run(ctx, map[string]any{"table": "users", stagedtool.TokenKey: token})
+	if err != nil {
+		t.Fatalf("Run() with the token failed: %v", err)
+	}
+	if got["deleted"] != "users" || len(deleted) != 1 {
+		t.Errorf("got result %v and deleted %v, want users deleted", got, deleted)
+	}
+	if _, err := staged.Run(ctx, map[string]any{"table": "users", stagedtool.TokenKey: token}); err == nil {
+		t.Error("Run() with a used token succeeded, want an error")
+	}
+	if len(deleted) != 1 {
+		t.Errorf("deleted %v, want users deleted once", deleted)
+	}
+}
+
+func TestStagedTool_ExpiredToken(t *testing.T) {
+	var deleted []string
+	staged := newDeleteTool(t, &deleted, time.Nanosecond)
+	ctx := createToolContext(t)
+
+	preview, err := staged.Run(ctx, map[string]any{"table": "users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	_, err = staged.Run(ctx, map[string]any{"table": "users", stagedtool.TokenKey: preview[stagedtool.TokenKey]})
+	if err == nil || !strings.Contains(err.Error(), "expired") || len(deleted) != 0 {
+		t.Errorf("Run() with an expired token: error = %v, deleted %v, want an expiry error", err, deleted)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	base, err := functiontool.New(functiontool.Config{Name: "delete_table"},
+		func(tool.Context, deleteArgs) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stagedtool.New(base, stagedtool.Config{}); err == nil {
+		t.Error("New() without a preview function succeeded, want an error")
+	}
+}
@@ -37,6 +37,20 @@ type Tool interface {
 	IsLongRunning() bool
 }
 
+// PartialArgsReceiver is implemented by tools that can start working while
+// the model streams the arguments of their call, e.g. to prefetch data as
+// soon as the first field is known.
+//
+// Most tools don't need it: Run always gets the complete arguments, once
+// fully received, and function tools validate them there as usual.
+type PartialArgsReceiver interface {
+	// ReceivePartialArgs is called each time more arguments of the call
+	// arrive, with the arguments received so far. They are incomplete and
+	// not validated, e.g. a string value may be truncated. callID is the ID
+	// of the function call later run by the tool.
+	ReceivePartialArgs(ctx context.Context, callID string, args map[string]any)
+}
+
 // Context defines the interface for the context passed to a tool when it's
 // called. It provides access to invocation-specific information and allows
 // the tool to interact with the agent's state and memory.
@@ -45,6 +59,10 @@ type Context interface {
 	// FunctionCallID returns the unique identifier of the function call
 	// that triggered this tool execution.
 	FunctionCallID() string
+	// RawArgs returns the arguments of the function call as sent by the
+	// model, before they are converted to the tool's argument type. It is
+	// nil when the context was not created for a function call.
+	RawArgs() map[string]any
 
 	// Actions returns the EventActions for the current event. This can be
 	// used by the tool to modify the agent's state, transfer to another
@@ -89,6 +107,16 @@ type Context interface {
 	RequestConfirmation(hint string, payload any) error
 }
 
+// SecretProvider gives tools access to secrets, e.g. API keys, without
+// passing them through the model context.
+//
+// Implementations are in the tool/secrets package. Function tools read
+// secrets with functiontool.Secret.
+type SecretProvider interface {
+	// Secret returns the value of the named secret.
+	Secret(ctx context.Context, name string) (string, error)
+}
+
 // Toolset is an interface for a collection of tools. It allows grouping
 // related tools together and providing them to an agent.
 type Toolset interface {
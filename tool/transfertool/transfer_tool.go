@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transfertool provides a tool that lets the model hand off control
+// to another agent, e.g. for coordinator agents dispatching the requests to
+// specialized agents.
+//
+// The tool, named "transfer_to_agent", takes the name of one of the agents it
+// was created with. When the model calls it, it sets the TransferToAgent
+// action of its tool.Context, which is recorded in the EventActions of the
+// function response event. The LLM flow then runs the target agent in the
+// same invocation, and the runner keeps running it for the next user
+// messages, as for the transfers to sub-agents.
+//
+// The agents must belong to the agent tree of the runner, not necessarily as
+// sub-agents of the agent using the tool. An agent given this tool does not
+// get the transfer tool and instructions added automatically for its
+// sub-agents, parent and peers, so the agents it can transfer to are exactly
+// the ones of the tool.
+package transfertool
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// Name is the name of the transfer tool.
+const Name = "transfer_to_agent"
+
+// Args are the arguments of the transfer tool.
+type Args struct {
+	// AgentName is the name of the agent to transfer to.
+	AgentName string `json:"agent_name"`
+}
+
+// New creates a transfer tool handing off control to one of the given
+// agents. Their names and descriptions are listed in the description of the
+// tool, for the model to pick the most suitable one.
+func New(agents []agent.Agent) (tool.Tool, error) {
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("at least one agent to transfer to is required")
+	}
+	var names []string
+	var sb strings.Builder
+	sb.WriteString("Transfers the question to another agent, when it is more suitable to answer it " +
+		"according to its description. When transferring, do not generate any text other than " +
+		"the function call. The agents are:")
+	for i, a := range agents {
+		if a == nil {
+			return nil, fmt.Errorf("agents[%d] is nil", i)
+		}
+		if slices.Contains(names, a.Name()) {
+			return nil, fmt.Errorf("duplicate agent %q", a.Name())
+		}
+		names = append(names, a.Name())
+		fmt.Fprintf(&sb, "\n- %s: %s", a.Name(), a.Description())
+	}
+
+	enum := make([]any, len(names))
+	for i, name := range names {
+		enum[i] = name
+	}
+	t := &transferTool{names: names}
+	return functiontool.New(functiontool.Config{
+		Name:        Name,
+		Description: sb.String(),
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"agent_name": {
+					Type:        "string",
+					Description: "the name of the agent to transfer to",
+					Enum:        enum,
+				},
+			},
+			Required: []string{"agent_name"},
+		},
+	}, t.run)
+}
+
+type transferTool struct {
+	names []string
+}
+
+func (t *transferTool) run(ctx tool.Context, args Args) (map[string]any, error) {
+	if !slices.Contains(t.names, args.AgentName) {
+		return nil, fmt.Errorf("unknown agent %q, want one of %s", args.AgentName, strings.Join(t.names, ", "))
+	}
+	ctx.Actions().TransferToAgent = args.AgentName
+	return map[string]any{}, nil
+}
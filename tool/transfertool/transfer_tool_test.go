@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfertool_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/transfertool"
+)
+
+func TestNew_Errors(t *testing.T) {
+	billing, err := llmagent.New(llmagent.Config{Name: "billing", Model: &testutil.MockModel{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, agents := range [][]agent.Agent{nil, {nil}, {billing, billing}} {
+		if _, err := transfertool.New(agents); err == nil {
+			t.Errorf("New(%v) succeeded, want an error", agents)
+		}
+	}
+}
+
+func TestTransferToAgent(t *testing.T) {
+	billingModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("You owe $5.", genai.RoleModel),
+		genai.NewContentFromText("It is due on Monday.", genai.RoleModel),
+	}}
+	billing, err := llmagent.New(llmagent.Config{
+		Name:        "billing",
+		Description: "Answers questions about bills.",
+		Model:       billingModel,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	support, err := llmagent.New(llmagent.Config{
+		Name:        "support",
+		Description: "Answers technical questions.",
+		Model:       &testutil.MockModel{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transferTool, err := transfertool.New([]agent.Agent{billing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinatorModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall(transfertool.Name, map[string]any{"agent_name": "support"}, genai.RoleModel),
+		genai.NewContentFromFunctionCall(transfertool.Name, map[string]any{"agent_name": "billing"}, genai.RoleModel),
+	}}
+	coordinator, err := llmagent.New(llmagent.Config{
+		Name:      "coordinator",
+		Model:     coordinatorModel,
+		Tools:     []tool.Tool{transferTool},
+		SubAgents: []agent.Agent{billing, support},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := testutil.NewTestAgentRunner(t, coordinator)
+	events, err := testutil.CollectEvents(runner.Run(t, "session", "How much do I owe?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the transfer tool of the agent is declared.
+	req := coordinatorModel.Requests[0]
+	var names []string
+	for _, decl := range req.Config.Tools[0].FunctionDeclarations {
+		names = append(names, decl.Name)
+	}
+	if len(req.Config.Tools) != 1 || len(names) != 1 || names[0] != transfertool.Name {
+		t.Errorf("got declared tools %v, want only %q", names, transfertool.Name)
+	}
+	if desc := req.Config.Tools[0].FunctionDeclarations[0].Description; !strings.Contains(desc, "billing: Answers questions about bills.") {
+		t.Errorf("got tool description %q, want it to list the billing agent", desc)
+	}
+
+	// The transfer to support, unknown to the tool, is rejected, and the
+	// model retries with billing.
+	var gotError bool
+	for _, ev := range events {
+		if ev.Content == nil {
+			continue
+		}
+		for _, part := range ev.Content.Parts {
+			if part.FunctionResponse != nil && part.FunctionResponse.Response["error"] != nil {
+				gotError = true
+			}
+		}
+	}
+	if !gotError {
+		t.Errorf("got no error for the transfer to support, want one")
+	}
+	last := events[len(events)-1]
+	if last.Author != "billing" || last.Content.Parts[0].Text != "You owe $5." {
+		t.Errorf("got last event of %q with %+v, want the answer of billing", last.Author, last.Content)
+	}
+
+	// The runner resumes with billing for the next message.
+	texts, err := testutil.CollectTextParts(runner.Run(t, "session", "When is it due?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(texts) != 1 || texts[0] != "It is due on Monday." {
+		t.Errorf("got texts %q, want the answer of billing", texts)
+	}
+	if len(coordinatorModel.Requests) != 2 {
+		t.Errorf("got %d calls to the coordinator model, want 2", len(coordinatorModel.Requests))
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+)
+
+// Prober is implemented by tools that can check their dependencies, e.g.
+// that a remote service is reachable or that credentials are valid.
+type Prober interface {
+	// Probe returns an error if the tool cannot work.
+	Probe(ctx context.Context) error
+}
+
+// validName matches the function names accepted by the models: a letter or
+// an underscore, followed by at most 63 letters, digits, underscores, dots,
+// colons or dashes.
+var validName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.:-]{0,63}$`)
+
+// ValidateTools checks the tools, to surface configuration errors at startup
+// rather than when the model calls them. For each tool, it checks that:
+//   - the function declaration, if the tool has one, has a valid name, the
+//     name of the tool, and JSON schemas that resolve;
+//   - no other tool has the same name;
+//   - Probe succeeds, if the tool implements Prober.
+//
+// It returns the errors of all the tools, joined.
+func ValidateTools(ctx context.Context, tools ...Tool) error {
+	var errs []error
+	seen := make(map[string]bool)
+	for i, t := range tools {
+		if t == nil {
+			errs = append(errs, fmt.Errorf("tools[%d] is nil", i))
+			continue
+		}
+		name := t.Name()
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("duplicate tool %q", name))
+		}
+		seen[name] = true
+		if err := validateDeclaration(t); err != nil {
+			errs = append(errs, fmt.Errorf("tool %q: %w", name, err))
+		}
+		if p, ok := t.(Prober); ok {
+			if err := p.Probe(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("tool %q: probe failed: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateDeclaration checks the function declaration of t. Tools without
+// one, e.g. the built-in tools of the models, have nothing to check.
+func validateDeclaration(t Tool) error {
+	declarer, ok := t.(interface {
+		Declaration() *genai.FunctionDeclaration
+	})
+	if !ok {
+		return nil
+	}
+	decl := declarer.Declaration()
+	switch {
+	case decl == nil:
+		return fmt.Errorf("no function declaration")
+	case !validName.MatchString(decl.Name):
+		return fmt.Errorf("invalid function name %q", decl.Name)
+	case decl.Name != t.Name():
+		return fmt.Errorf("function declared as %q", decl.Name)
+	}
+	if err := resolveSchema(decl.ParametersJsonSchema); err != nil {
+		return fmt.Errorf("invalid parameters schema: %w", err)
+	}
+	if err := resolveSchema(decl.ResponseJsonSchema); err != nil {
+		return fmt.Errorf("invalid response schema: %w", err)
+	}
+	return nil
+}
+
+// resolveSchema resolves s if it is a *jsonschema.Schema. Schemas of other
+// types are left to the models to check.
+func resolveSchema(s any) error {
+	schema, ok := s.(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return nil
+	}
+	_, err := schema.Resolve(nil)
+	return err
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
+	"google.golang.org/adk/tool/remotetool"
+)
+
+type probedTool struct {
+	tool.Tool
+	err error
+}
+
+func (p probedTool) Probe(context.Context) error {
+	return p.err
+}
+
+func TestValidateTools(t *testing.T) {
+	type args struct {
+		City string `json:"city"`
+	}
+	weather, err := functiontool.New(functiontool.Config{Name: "weather", Description: "Gets the weather."},
+		func(tool.Context, args) (string, error) { return "sunny", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := func(decl *genai.FunctionDeclaration) tool.Tool {
+		t.Helper()
+		rt, err := remotetool.New(decl, func(context.Context, map[string]any) (map[string]any, error) { return nil, nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rt
+	}
+	errUnreachable := errors.New("unreachable")
+
+	tests := []struct {
+		name    string
+		tools   []tool.Tool
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			tools: []tool.Tool{weather, geminitool.GoogleSearch{}, probedTool{Tool: remote(&genai.FunctionDeclaration{Name: "ns.lookup"})}},
+		},
+		{
+			name:    "nil tool",
+			tools:   []tool.Tool{weather, nil},
+			wantErr: "tools[1] is nil",
+		},
+		{
+			name:    "duplicate",
+			tools:   []tool.Tool{weather, weather},
+			wantErr: `duplicate tool "weather"`,
+		},
+		{
+			name:    "invalid name",
+			tools:   []tool.Tool{remote(&genai.FunctionDeclaration{Name: "get weather"})},
+			wantErr: `invalid function name "get weather"`,
+		},
+		{
+			name: "unresolved schema",
+			tools: []tool.Tool{remote(&genai.FunctionDeclaration{
+				Name: "lookup",
+				ParametersJsonSchema: &jsonschema.Schema{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{"id": {Ref: "#/$defs/missing"}},
+				},
+			})},
+			wantErr: `tool "lookup": invalid parameters schema`,
+		},
+		{
+			name:    "failed probe",
+			tools:   []tool.Tool{probedTool{Tool: weather, err: errUnreachable}},
+			wantErr: `tool "weather": probe failed: unreachable`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tool.ValidateTools(t.Context(), tc.tools...)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateTools() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ValidateTools() error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	err = tool.ValidateTools(t.Context(), probedTool{Tool: weather, err: errUnreachable})
+	if !errors.Is(err, errUnreachable) {
+		t.Errorf("ValidateTools() error = %v, want it to wrap the probe error", err)
+	}
+}